@@ -1,225 +1,120 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"hash_interface/configs"
-	"hash_interface/internal/cluster"
-	"hash_interface/internal/storage"
-	"net/http"
-
-	"hash_interface/internal/models"
-	"hash_interface/internal/models/response"
+	"hash_interface/pkg/client"
 )
 
 // Naver LABS internal Server "http://10.113.93.194:8001"
 
 func requestAddClientToServer(dataFlags ClientFlag) error {
 
-	requestURI := fmt.Sprintf("%s/clients", baseUrl)
-
-	client := &http.Client{}
-
-	requestData := models.NewClientRequestContainer{}
+	var nodeList client.NodeList
+	var err error
+	role := "master"
 
 	if dataFlags.SlaveAddress != "" {
-
-		requestData.Address = dataFlags.SlaveAddress
-		requestData.Role = "slave"
-		requestData.MasterAddress = dataFlags.MasterAddress
-
+		role = "slave"
+		nodeList, err = kvClient.AddSlave(context.Background(), dataFlags.SlaveAddress, dataFlags.MasterAddress)
 	} else {
-		requestData.Address = dataFlags.MasterAddress
-		requestData.Role = "master"
+		nodeList, err = kvClient.AddMaster(context.Background(), dataFlags.MasterAddress)
 	}
 
-	encodedData, err := json.Marshal(requestData)
 	if err != nil {
 		return err
 	}
 
-	requestBody := bytes.NewBuffer(encodedData)
+	fmt.Printf("  Add New Client (%s) 명령 수행 : \n", role)
+	printNodeList(nodeList)
 
-	setRequest, err := http.NewRequest("POST", requestURI, requestBody)
-	if err != nil {
-		return err
-	}
+	return nil
+}
+
+func requestRemoveClientToServer(address string) error {
 
-	res, err := client.Do(setRequest)
+	nodeList, err := kvClient.RemoveNode(context.Background(), address)
 	if err != nil {
 		return err
 	}
-	defer res.Body.Close()
-
-	var hashServerResponse response.RedisListTemplate
-	decoder := json.NewDecoder(res.Body)
 
-	if err := decoder.Decode(&hashServerResponse); err != nil {
-		return err
-	}
+	fmt.Printf("  Remove Client (%s) 명령 수행 : \n", address)
+	printNodeList(nodeList)
 
-	fmt.Printf("  Add New Client (%s) 명령 수행 : \n", requestData.Role)
-	fmt.Printf("    - 결과 : %s\n", hashServerResponse.Message)
-	fmt.Printf("    - 현재 등록된 마스터 : \n")
-	for i, eachMaster := range hashServerResponse.Masters {
-		fmt.Printf("        %d) : %s\n", i+1, eachMaster.Address)
-	}
-	fmt.Printf("    - 현재 등록된 슬레이브 : \n")
-	for i, eachSlave := range hashServerResponse.Slaves {
-		fmt.Printf("        %d) : %s\n", i+1, eachSlave.Address)
-	}
 	return nil
 }
 
 func requestClientListToServer() error {
 
-	requestURI := fmt.Sprintf("%s/clients", baseUrl)
-
-	res, err := http.Get(requestURI)
+	nodeList, err := kvClient.Nodes(context.Background())
 	if err != nil {
 		return err
 	}
-	defer res.Body.Close()
 
-	var hashServerResponse response.RedisListTemplate
-	decoder := json.NewDecoder(res.Body)
+	fmt.Printf("  Get Client List 명령 수행 : \n")
+	printNodeList(nodeList)
 
-	if err := decoder.Decode(&hashServerResponse); err != nil {
-		return err
-	}
+	return nil
+}
+
+func printNodeList(nodeList client.NodeList) {
 
-	fmt.Printf("  Get Client List 명령 수행 : \n")
 	fmt.Printf("    - 현재 등록된 마스터 : \n")
-	for i, eachMaster := range hashServerResponse.Masters {
-		fmt.Printf("        %d) : %s\n", i+1, eachMaster.Address)
+	for i, eachMaster := range nodeList.Masters {
+		fmt.Printf("        %d) : %s\n", i+1, eachMaster)
 	}
 	fmt.Printf("    - 현재 등록된 슬레이브 : \n")
-	for i, eachSlave := range hashServerResponse.Slaves {
-		fmt.Printf("        %d) : %s\n", i+1, eachSlave.Address)
+	for i, eachSlave := range nodeList.Slaves {
+		fmt.Printf("        %d) : %s\n", i+1, eachSlave)
+	}
+	if len(nodeList.Maintenance) > 0 {
+		fmt.Printf("    - 점검 중인 노드 : %v\n", nodeList.Maintenance)
 	}
-
-	return nil
 }
 
 func requestGetToServer(key string) error {
-	requestURI := fmt.Sprintf("%s/hash/data/%s", baseUrl, key)
-
-	res, err := http.Get(requestURI)
-	if err != nil {
-		return err
-	}
-	defer res.Body.Close()
 
-	var hashServerResponse response.GetResultTemplate
-	decoder := json.NewDecoder(res.Body)
+	value, err := kvClient.Get(context.Background(), key)
+	if client.IsNotFound(err) {
+		value.Value = "nil(없음)"
 
-	if err := decoder.Decode(&hashServerResponse); err != nil {
+	} else if err != nil {
 		return err
 	}
 
 	fmt.Printf("  Get %s 명령 수행 : \n", key)
-	fmt.Printf("    - 결과 : %s\n", hashServerResponse.Result)
-	fmt.Printf("    - 처리한 레디스 주소 : %s\n", hashServerResponse.NodeAdrress)
+	fmt.Printf("    - 결과 : %s (버전 : %d)\n", value.Value, value.Version)
+	fmt.Printf("    - 처리한 레디스 주소 : %s\n", value.Node)
 
 	return nil
 }
 
 func requestSetToServer(dataFlags DataFlag) error {
 
-	requestURI := fmt.Sprintf("%s/hash/data", baseUrl)
-
-	client := &http.Client{}
-
-	KeyValue := storage.KeyValuePair{
-		Key:   dataFlags.Key,
-		Value: dataFlags.Value,
-	}
-
-	requestData := models.DataRequestContainer{}
-	requestData.Data = append(requestData.Data, KeyValue)
-
-	encodedData, err := json.Marshal(requestData)
-	if err != nil {
-		return err
-	}
-
-	requestBody := bytes.NewBuffer(encodedData)
-
-	setRequest, err := http.NewRequest("POST", requestURI, requestBody)
-	if err != nil {
-		return err
-	}
-
-	res, err := client.Do(setRequest)
-	if err != nil {
-		return err
-	}
-	defer res.Body.Close()
-
-	var hashServerResponse response.SetResultTemplate
-	decoder := json.NewDecoder(res.Body)
-
-	if err := decoder.Decode(&hashServerResponse); err != nil {
+	if err := kvClient.Set(context.Background(), dataFlags.Key, dataFlags.Value); err != nil {
 		return err
 	}
 
 	fmt.Printf("  Set %s 명령 수행 : \n", dataFlags.Key)
-	fmt.Printf("    - 결과 : %s\n", hashServerResponse.Results[0].Result)
-	fmt.Printf("    - 처리한 레디스 주소 : %s\n", hashServerResponse.Results[0].NodeAdrress)
+	fmt.Printf("    - 결과 : %s\n", dataFlags.Value)
 
 	return nil
 }
 
 func requestNodeRegistration(clusterflag ClusterFlag) error {
 
-	requestURI := fmt.Sprintf(
-		"%s/api/v1/cluster?handshake=%t&startPoint=%t",
-		baseUrl,
+	err := kvClient.RegisterClusterNode(
+		context.Background(),
+		clusterflag.Host,
 		clusterflag.Handshake,
 		clusterflag.Startpoint,
 	)
-
-	newHost := cluster.Register{
-		Address: clusterflag.Host,
-	}
-
-	encodedData, err := json.Marshal(newHost)
 	if err != nil {
-		return err
-	}
-
-	requestBody := bytes.NewBuffer(encodedData)
-
-	req, err := http.NewRequest(
-		http.MethodPost,
-		requestURI,
-		requestBody,
-	)
-
-	if err != nil {
-		return err
-	}
-
-	client := &http.Client{}
-	res, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer res.Body.Close()
-
-	var response response.BasicTemplate
-	decoder := json.NewDecoder(res.Body)
-
-	if err := decoder.Decode(&response); err != nil {
-		return err
-	}
-
-	if res.StatusCode > 400 {
 		return fmt.Errorf(
 			"클러스터 노드(%s) 등록 실패 - %s",
 			clusterflag.Host,
-			response.Message,
+			err.Error(),
 		)
 	}
 
@@ -234,38 +129,10 @@ func requestNodeRegistration(clusterflag ClusterFlag) error {
 
 func requestClusterStart() error {
 
-	requestURI := fmt.Sprintf(
-		"%s/api/v1/cluster",
-		baseUrl,
-	)
-
-	req, err := http.NewRequest(
-		http.MethodPut,
-		requestURI,
-		nil,
-	)
-	if err != nil {
-		return err
-	}
-
-	client := &http.Client{}
-	res, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer res.Body.Close()
-
-	var response response.BasicTemplate
-	decoder := json.NewDecoder(res.Body)
-
-	if err := decoder.Decode(&response); err != nil {
-		return err
-	}
-
-	if res.StatusCode > 400 {
+	if err := kvClient.StartCluster(context.Background()); err != nil {
 		return fmt.Errorf(
 			"클러스터 시작 실패 : %s",
-			response.Message,
+			err.Error(),
 		)
 	}
 
@@ -276,42 +143,15 @@ func requestClusterStart() error {
 
 func printRegisteredNodes() error {
 
-	requestURI := fmt.Sprintf(
-		"%s/api/v1/cluster",
-		baseUrl,
-	)
-
-	req, err := http.NewRequest(
-		http.MethodGet,
-		requestURI,
-		nil,
-	)
-	if err != nil {
-		return err
-	}
-
-	client := &http.Client{}
-	res, err := client.Do(req)
+	nodes, err := kvClient.ClusterNodes(context.Background())
 	if err != nil {
-		return err
-	}
-	defer res.Body.Close()
-
-	var response response.ClusterNodeListTemplate
-	decoder := json.NewDecoder(res.Body)
-
-	if err := decoder.Decode(&response); err != nil {
-		return err
-	}
-
-	if res.StatusCode > 400 {
 		return fmt.Errorf(
 			"클러스터 등록 노드 가져오기 실패 : %s",
-			response.Message,
+			err.Error(),
 		)
 	}
 
-	for _, eachNode := range response.Nodes {
+	for _, eachNode := range nodes {
 
 		name, isSet := configs.ServerIpToDomainMap[eachNode]
 		if !isSet {
@@ -327,48 +167,83 @@ func printRegisteredNodes() error {
 
 func printLeader() error {
 
-	requestURI := fmt.Sprintf(
-		"%s/api/v1/cluster/leader",
-		baseUrl,
-	)
-
-	req, err := http.NewRequest(
-		http.MethodGet,
-		requestURI,
-		nil,
-	)
+	leader, err := kvClient.Leader(context.Background())
 	if err != nil {
-		return err
+		return fmt.Errorf(
+			"리더 가져오기 실패 : %s",
+			err.Error(),
+		)
 	}
 
-	client := &http.Client{}
-	res, err := client.Do(req)
+	name, isSet := configs.ServerIpToDomainMap[leader]
+	if !isSet {
+		fmt.Printf("  현재 리더 : %s \n", leader)
+		return nil
+	}
+
+	fmt.Printf("  현재 리더 : %s \n", name)
+
+	return nil
+}
+
+func requestSlotMapToServer() error {
+
+	slotMap, err := kvClient.SlotMap(context.Background())
 	if err != nil {
-		return err
+		return fmt.Errorf(
+			"해쉬 슬롯 현황 가져오기 실패 : %s",
+			err.Error(),
+		)
 	}
-	defer res.Body.Close()
 
-	var response response.BasicTemplate
-	decoder := json.NewDecoder(res.Body)
+	fmt.Printf("  Get Slots 명령 수행 (구성 버전 : %d) : \n", slotMap.Epoch)
+	for i, eachRange := range slotMap.Ranges {
+		fmt.Printf(
+			"        %d) %5d ~ %5d : 마스터 %s, 레플리카 %v\n",
+			i+1,
+			eachRange.Start,
+			eachRange.End,
+			eachRange.MasterAddress,
+			eachRange.ReplicaAddresses,
+		)
+	}
 
-	if err := decoder.Decode(&response); err != nil {
-		return err
+	fmt.Printf("    - 노드 별 해쉬 슬롯 : \n")
+	for _, eachNode := range slotMap.Nodes {
+		if eachNode.MasterAddress != "" {
+			fmt.Printf("        %s (%s of %s)\n", eachNode.Address, eachNode.Role, eachNode.MasterAddress)
+			continue
+		}
+		fmt.Printf(
+			"        %s (%s) : %d개 %v\n",
+			eachNode.Address,
+			eachNode.Role,
+			eachNode.SlotCount,
+			eachNode.Slots,
+		)
 	}
 
-	if res.StatusCode > 400 {
+	return nil
+}
+
+func requestKeySlotToServer(key string) error {
+
+	keySlot, err := kvClient.KeySlot(context.Background(), key)
+	if err != nil {
 		return fmt.Errorf(
-			"리더 가져오기 실패 : %s",
-			response.Message,
+			"Key(%s) 해쉬 슬롯 가져오기 실패 : %s",
+			key,
+			err.Error(),
 		)
 	}
 
-	name, isSet := configs.ServerIpToDomainMap[response.Message]
-	if !isSet {
-		fmt.Printf("  현재 리더 : %s \n", response.Message)
-		return nil
+	fmt.Printf("  Get Slot of %s 명령 수행 : \n", key)
+	fmt.Printf("    - 해쉬 슬롯 : %d\n", keySlot.Slot)
+	fmt.Printf("    - 담당 마스터 : %s\n", keySlot.MasterAddress)
+	fmt.Printf("    - 레플리카 : %v\n", keySlot.ReplicaAddresses)
+	if keySlot.ImportingAddress != "" {
+		fmt.Printf("    - 이동 중 (IMPORTING) : %s\n", keySlot.ImportingAddress)
 	}
 
-	fmt.Printf("  현재 리더 : %s \n", name)
-
 	return nil
 }
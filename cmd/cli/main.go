@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	flags "github.com/jessevdk/go-flags"
+
+	"hash_interface/pkg/client"
 )
 
 type DataFlag struct {
@@ -20,6 +22,14 @@ type ClientFlag struct {
 	SlaveAddress  string `short:"s" long:"slave" description:"If slave to be added, master flag must also be passed with specific address"`
 }
 
+type RemoveFlag struct {
+	Address string `short:"a" long:"address" description:"Address of Redis node (master / slave) to remove"`
+}
+
+type SlotFlag struct {
+	Key string `short:"k" long:"key" description:"Print hash slot and owner of the key"`
+}
+
 type ClusterFlag struct {
 	Host        string `short:"h" long:"host" description:"register each others' ip addresses - current host and passed host"`
 	Startpoint  bool   `long:"startpoint" description:"register each others' ip addresses - current host and passed host"`
@@ -41,15 +51,20 @@ const (
 	Get     = "get"
 	Set     = "set"
 	Add     = "add"
+	Remove  = "remove"
 	Ls      = "ls"
 	List    = "list"
 	Exit    = "exit"
 	Quit    = "quit"
 	Cluster = "cluster"
+	Slots   = "slots"
 )
 
+// baseUrl : 인터페이스 서버 주소, 콤마로 여러 서버 지정 가능 (요청 실패 시 다음 서버로)
 var baseUrl = os.Getenv("CLUSTER_SEVER_URL")
 
+var kvClient *client.Client
+
 func main() {
 
 	stdReader := bufio.NewReader(os.Stdin)
@@ -57,6 +72,14 @@ func main() {
 		baseUrl = "http://localhost:8001"
 	}
 
+	var err error
+	kvClient, err = client.New(client.Config{
+		Endpoints: strings.Split(baseUrl, ","),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	for {
 
 		fmt.Print("hash-interface > ")
@@ -121,6 +144,25 @@ func main() {
 
 			break
 
+		case Remove:
+			removeFlags := RemoveFlag{}
+			if _, err := flags.ParseArgs(&removeFlags, words); err != nil {
+				fmt.Println(err)
+				continue
+			}
+
+			if removeFlags.Address == "" {
+				fmt.Println("Address Flag must be presented")
+				continue
+			}
+
+			if err := requestRemoveClientToServer(removeFlags.Address); err != nil {
+				fmt.Println(err)
+				continue
+			}
+
+			break
+
 		case List, Ls:
 			if err := requestClientListToServer(); err != nil {
 				fmt.Println(err)
@@ -129,6 +171,26 @@ func main() {
 
 			break
 
+		case Slots:
+			slotFlags := SlotFlag{}
+			if _, err := flags.ParseArgs(&slotFlags, words); err != nil {
+				fmt.Println(err)
+				continue
+			}
+
+			if slotFlags.Key != "" {
+				err = requestKeySlotToServer(slotFlags.Key)
+			} else {
+				err = requestSlotMapToServer()
+			}
+
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+
+			break
+
 		case Cluster:
 			clusterFlags := ClusterFlag{}
 			err := parseClusterFlag(&clusterFlags, words)
@@ -184,7 +246,9 @@ func printInstructions() {
 	fmt.Println("get 		Retreieve stored value with passed key")
 	fmt.Println("set 		Store key and value")
 	fmt.Println("add 		Add new Redis client node (master / slave)")
+	fmt.Println("remove 		Remove Redis client node (master's hash slots move to other masters)")
 	fmt.Println("list/ls 	Print current registered Redis master, slave clients list")
+	fmt.Println("slots 		Print hash slot ranges with master, replica addresses")
 	fmt.Println("exit/quit 	Exit cli")
 	fmt.Println(" ")
 	fmt.Println("get/set Options : ")
@@ -197,6 +261,10 @@ func printInstructions() {
 	fmt.Println("				if 'slave' flag is set)")
 	fmt.Println("-s, --slave= 	new Redis Slave node address")
 	fmt.Println("				'master' flag must be set to specify new slave's master")
+	fmt.Println("remove Options : ")
+	fmt.Println("-a, --address= 	Redis node address to remove (ex. remove -a 127.0.0.1:8000)")
+	fmt.Println("slots Options : ")
+	fmt.Println("-k, --key= 	print hash slot and owner of the key (ex. slots -k foo)")
 
 }
 
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"hash_interface/internal/storage"
+)
+
+/*
+ * 이전 형식(버전 1, "%d %s %s %s")의 데이터 로그 파일들을 현재 형식으로 변환한다.
+ * 인터페이스 서버를 모두 멈춘 뒤 실행해야 한다.
+ *
+ * ex) go run ./cmd/datalog_migrate -dir ./internal/cluster/dump
+ */
+func main() {
+
+	directory := flag.String("dir", "./internal/cluster/dump", "데이터 로그 파일 디렉토리")
+	flag.Parse()
+
+	files, err := ioutil.ReadDir(*directory)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "디렉토리(%s) 읽기 에러 - %s\n", *directory, err.Error())
+		os.Exit(1)
+	}
+
+	hasFailed := false
+
+	for _, eachFile := range files {
+
+		// 변환 / 압축 중 남은 임시 파일, 변환 전 원본은 제외
+		if eachFile.IsDir() ||
+			strings.HasSuffix(eachFile.Name(), ".v1") ||
+			strings.HasSuffix(eachFile.Name(), ".migrating") ||
+			strings.HasSuffix(eachFile.Name(), ".compacting") {
+			continue
+		}
+
+		filePath := filepath.Join(*directory, eachFile.Name())
+
+		migratedRecords, isMigrated, err := storage.MigrateDataLogFile(filePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s : 변환 실패 - %s\n", filePath, err.Error())
+			hasFailed = true
+			continue
+		}
+
+		if !isMigrated {
+			fmt.Printf("%s : 이미 버전 %d 형식\n", filePath, storage.DataLogVersion)
+			continue
+		}
+
+		fmt.Printf("%s : 레코드 %d 개 변환 완료 (원본 : %s.v1)\n", filePath, migratedRecords, filePath)
+	}
+
+	if hasFailed {
+		os.Exit(1)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"time"
 
 	"hash_interface/configs"
 	"hash_interface/internal/handlers"
@@ -34,47 +35,86 @@ func main() {
 
 	tools.SetUpLogger("hash_server")
 
-	// Redis Master Containers들과 Connection설정
-	err := storage.NodeConnectionSetup(
-		configs.GetInitialMasterAddressList(),
-		storage.Default,
-	)
-
-	if err != nil {
+	// 마스터-슬레이브 복제 방식 설정 (슬레이브 연결 전)
+	if err := storage.SetReplicationMode(configs.ReplicationMode); err != nil {
 		tools.ErrorLogger.Fatalln(
-			"Error - Node connection error : ",
+			"Error - Replication mode setup failure : ",
 			err.Error(),
 		)
 	}
 
-	// create Hash Map (Index -> Redis Master Nodes)
-	if err := storage.MakeHashMapToRedis(); err != nil {
+	// 노드가 죽었다고 판단하는 데 필요한 최소 투표 수
+	if err := storage.SetMonitorQuorum(configs.MonitorQuorum); err != nil {
 		tools.ErrorLogger.Fatalln(
-			"Error - Redis Node Address Mapping to Hash Map failure: ",
+			"Error - Monitor quorum setup failure : ",
 			err.Error(),
 		)
 	}
 
-	// Redis Slave Containers들과 Connection설정
-	err = storage.NodeConnectionSetup(
-		configs.GetInitialSlaveAddressList(),
-		storage.InitSlaveSetup,
+	// 죽은 노드 재시작 방식 설정 (docker / process / none)
+	err := storage.SetNodeRestarter(
+		configs.NodeRestarter,
+		configs.RedisServerPath,
+		configs.RedisConfigPattern,
 	)
 	if err != nil {
 		tools.ErrorLogger.Fatalln(
-			"Error - Node connection error : ",
+			"Error - Node restarter setup failure : ",
+			err.Error(),
+		)
+	}
+
+	// 자동 Failover (승격 / 해쉬 슬롯 재분배) 도 Raft 로그로 모든 인터페이스 서버에 반영
+	storage.SetTopologyDispatcher(handlers.DispatchTopologyEntry)
+
+	// 기록된 클러스터 구성이 있으면 그대로 복원 (재시작)
+	isRestored, err := storage.RestoreTopology()
+	if err != nil {
+		tools.ErrorLogger.Fatalln(
+			"Error - Topology restore failure : ",
 			err.Error(),
 		)
 	}
 
+	if !isRestored {
+		setUpInitialTopology()
+	}
+
 	// storage.PrintCurrentMasterSlaves()
 
 	/* Set Data modification Logger for each Nodes*/
-	storage.SetUpModificationLogger(configs.GetInitialTotalAddressList())
+	storage.SetUpModificationLogger(storage.GetNodeAddresses())
+
+	// 데이터 로그에 기록된 Key 버전 복원 (CAS 의 expected_version)
+	if _, err := storage.RestoreKeyVersions(); err != nil {
+		tools.ErrorLogger.Fatalln(
+			"Error - Key version restore failure : ",
+			err.Error(),
+		)
+	}
+
+	// 해쉬 함수 변경 (CRC16-XMODEM, Hash Tag) 전에 저장되어 담당이 아닌 마스터에 남은 Key 이동
+	movedKeys, err := storage.RehashMisplacedKeys()
+	if err != nil {
+		tools.ErrorLogger.Fatalln(
+			"Error - Rehash misplaced keys failure : ",
+			err.Error(),
+		)
+	}
+
+	if movedKeys > 0 {
+		tools.InfoLogger.Printf("Rehashed misplaced keys to their slot owners : %d", movedKeys)
+	}
+
+	// 데이터 로그가 커지면 Key 별 최신 상태만 남도록 압축
+	storage.StartDataLogCompaction(1*time.Minute, storage.DefaultDataLogCompactionPolicy)
 
 	// 타이머로 Redis Node들 모니터링 시작
 	// storage.StartMonitorNodes()
 
+	// Native 복제 모드일 경우, 각 그룹의 복제 지연(offset) 확인 시작
+	storage.StartReplicationWatcher(1 * time.Second)
+
 	router := mux.NewRouter()
 
 	router.PathPrefix("/api/v1/docs/").
@@ -102,3 +142,42 @@ func main() {
 		http.ListenAndServe(":"+strconv.Itoa(configs.Port), router),
 	)
 }
+
+// setUpInitialTopology : 설정 파일의 초기 노드 목록으로 마스터 / 슬레이브 연결, 해쉬 슬롯 할당
+func setUpInitialTopology() {
+
+	// Redis Master Containers들과 Connection설정
+	err := storage.NodeConnectionSetup(
+		configs.GetInitialMasterAddressList(),
+		storage.Default,
+	)
+
+	if err != nil {
+		tools.ErrorLogger.Fatalln(
+			"Error - Node connection error : ",
+			err.Error(),
+		)
+	}
+
+	// create Hash Map (Index -> Redis Master Nodes)
+	if err := storage.MakeHashMapToRedis(); err != nil {
+		tools.ErrorLogger.Fatalln(
+			"Error - Redis Node Address Mapping to Hash Map failure: ",
+			err.Error(),
+		)
+	}
+
+	// Redis Slave Containers들과 Connection설정
+	err = storage.NodeConnectionSetup(
+		configs.GetInitialSlaveAddressList(),
+		storage.InitSlaveSetup,
+	)
+	if err != nil {
+		tools.ErrorLogger.Fatalln(
+			"Error - Node connection error : ",
+			err.Error(),
+		)
+	}
+
+	storage.SaveTopologySnapshot()
+}
@@ -1,13 +1,19 @@
 package main
 
 import (
+	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
+	"time"
 
 	"hash_interface/configs"
 	"hash_interface/internal/handlers"
 	"hash_interface/internal/routers"
 	"hash_interface/internal/storage"
+	msg "hash_interface/internal/storage/message"
 	"hash_interface/tools"
 
 	"github.com/gorilla/mux"
@@ -15,6 +21,7 @@ import (
 
 /*
  * 모니터 서버는 마스터와 슬레이브를 구분하지 않는다.
+ * 인터페이스 서버의 노드 목록과 별개로, 감시 중인 노드마다 자신의 연결을 가진다.
  */
 
 // @title Redis Cluster Interface Test Server
@@ -35,23 +42,33 @@ func main() {
 			"Error - Get Go-App IP error : ", err.Error())
 	}
 
-	// Redis Master Containers들과 Connection설정
-	err = storage.NodeConnectionSetup(
-		configs.GetInitialMasterAddressList(),
-		storage.Default,
-	)
-	if err != nil {
-		tools.ErrorLogger.Fatalln("Error - Node connection error : ", err.Error())
+	// 노드가 죽었다고 판단하는 데 필요한 최소 투표 수 (odown 정족수)
+	if err = storage.SetMonitorQuorum(configs.MonitorQuorum); err != nil {
+		tools.ErrorLogger.Fatalln("Error - Monitor quorum setup failure : ", err.Error())
 	}
 
-	// Redis Slave Containers들과 Connection설정
-	err = storage.NodeConnectionSetup(
-		configs.GetInitialSlaveAddressList(),
-		storage.Default)
-	if err != nil {
-		tools.ErrorLogger.Fatalln("Error - Node connection error : ", err.Error())
+	// Redis Master / Slave Containers들 감시 시작 (모니터 서버 전용 연결)
+	for _, eachAddress := range configs.GetInitialTotalAddressList() {
+		if err = storage.WatchNode(eachAddress); err != nil {
+			tools.ErrorLogger.Fatalln("Error - Node connection error : ", err.Error())
+		}
 	}
 
+	// 인터페이스 서버에 모니터 등록 (종료 시 해제)
+	selfAddress := fmt.Sprintf("%s:%d", configs.CurrentIP, configs.Port)
+	go registerMonitor(selfAddress)
+	go deregisterMonitorOnShutdown(selfAddress)
+
+	// 노드 감시 & 다른 모니터 서버들과 Gossip 시작, odown 이 되면 인터페이스 서버에 알림
+	// Gossip 대상은 인터페이스 서버에 등록된 모니터 목록으로 갱신된다
+	storage.StartFailureDetector(
+		selfAddress,
+		[]string{},
+		configs.InterfaceNodeAddress,
+		1*time.Second,
+		3*time.Second,
+	)
+
 	router := mux.NewRouter()
 
 	moniterRouter := router.PathPrefix("/monitor").Subrouter()
@@ -69,3 +86,35 @@ func main() {
 		http.ListenAndServe(":"+strconv.Itoa(configs.Port), router),
 	)
 }
+
+// registerMonitor : 인터페이스 서버에 등록될 때까지 다시 시도 (인터페이스 서버가 늦게 뜨는 경우)
+func registerMonitor(selfAddress string) {
+
+	for {
+		err := storage.RegisterToInterface(configs.InterfaceNodeAddress, selfAddress)
+		if err == nil {
+			tools.InfoLogger.Printf(msg.MonitorRegistered, configs.InterfaceNodeAddress, selfAddress)
+			return
+		}
+
+		tools.ErrorLogger.Printf(msg.MonitorRegisterRetry, configs.InterfaceNodeAddress, err.Error())
+		time.Sleep(1 * time.Second)
+	}
+}
+
+// deregisterMonitorOnShutdown : SIGINT / SIGTERM 을 받으면 인터페이스 서버에서 해제 후 종료
+func deregisterMonitorOnShutdown(selfAddress string) {
+
+	signalChannel := make(chan os.Signal, 1)
+	signal.Notify(signalChannel, os.Interrupt, syscall.SIGTERM)
+	<-signalChannel
+
+	err := storage.DeregisterFromInterface(configs.InterfaceNodeAddress, selfAddress)
+	if err != nil {
+		tools.ErrorLogger.Printf(msg.MonitorDeregisterFail, configs.InterfaceNodeAddress, err.Error())
+		os.Exit(1)
+	}
+
+	tools.InfoLogger.Printf(msg.MonitorDeregistered, configs.InterfaceNodeAddress, selfAddress)
+	os.Exit(0)
+}
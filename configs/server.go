@@ -43,6 +43,21 @@ const (
 // CurrentIP is IP address of Go-application, will be initialized in main.go
 var CurrentIP = os.Getenv("DOCKER_HOST_IP")
 
+// ReplicationMode : 마스터-슬레이브 복제 방식 ("replay"(default) / "native")
+var ReplicationMode = os.Getenv("REPLICATION_MODE")
+
+// NodeRestarter : Failover 후 죽은 레디스 노드 재시작 방식 ("docker"(default) / "process" / "none")
+var NodeRestarter = os.Getenv("NODE_RESTARTER")
+
+// RedisServerPath : process 재시작 방식의 redis-server 실행 파일 (default : PATH 의 redis-server)
+// RedisConfigPattern : process 재시작 방식의 노드 설정 파일 경로, %s 에 노드 포트 (ex. ./redis/%s.conf)
+var RedisServerPath = os.Getenv("REDIS_SERVER_PATH")
+var RedisConfigPattern = os.Getenv("REDIS_CONFIG_PATTERN")
+
+// MonitorQuorum : 노드가 죽었다고 판단하는 데 필요한 최소 투표 수 (default : 2)
+// 모니터 서버 / 인터페이스 서버 모두 과반수와 이 값 중 큰 값을 정족수로 사용
+var MonitorQuorum = os.Getenv("MONITOR_QUORUM")
+
 func GetInitialMasterAddressList() []string {
 	return []string{
 		RedisMasterOneAddress,
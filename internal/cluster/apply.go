@@ -0,0 +1,66 @@
+package cluster
+
+import (
+	"hash_interface/internal/storage"
+	"hash_interface/tools"
+)
+
+const (
+	// applyQueueSize : 커밋되었지만 아직 Key Value Store에 반영되지 않은 엔트리 최대 개수
+	applyQueueSize = 1024
+)
+
+// applyTask : 커밋된 WAL 엔트리의 Key Value Store 반영 요청
+type applyTask struct {
+	keyValuePair storage.KeyValuePair
+
+	durability string
+
+	// interruptChannel : 반영 결과를 기다리는 HTTP 요청 쓰레드, nil 이면 결과를 기다리지 않는다
+	interruptChannel *(chan error)
+
+	// applyResult : 반영 결과를 받을 곳 (ex. INCRBY 의 새로운 값), nil 이면 무시
+	applyResult *string
+}
+
+// startApplier : 리더가 커밋한 엔트리를 커밋 순서대로 Key Value Store에 반영하는 고루틴
+// 이벤트 루프를 막지 않기 위해 별도의 고루틴에서 반영한다
+//
+func (this *StateMachine) startApplier() {
+
+	go func() {
+		for task := range this.applyChannel {
+
+			result, err := saveData(task.keyValuePair, task.durability)
+			if err != nil {
+				tools.ErrorLogger.Printf(
+					"Key Value Store에 반영 실패 - (key, value) : (%s, %s), %s",
+					task.keyValuePair.Key,
+					task.keyValuePair.Value,
+					err.Error(),
+				)
+			}
+
+			if task.applyResult != nil {
+				*task.applyResult = result
+			}
+
+			if task.interruptChannel != nil {
+				*(task.interruptChannel) <- err
+			}
+		}
+	}()
+}
+
+// requiresApplyAck : 요청이 Key Value Store 반영까지 기다려야 하는지
+//  - durability 설정 시 (복제 확인)
+//  - 조건부 쓰기, 분산 트랜잭션 단계 (반영 결과가 곧 요청 결과)
+//
+func requiresApplyAck(entry storage.KeyValuePair, durability string) bool {
+
+	if storage.RequiresApplyResult(entry) {
+		return true
+	}
+
+	return durability != "" && durability != string(storage.DurabilityNone)
+}
@@ -40,7 +40,7 @@ func (this *StateMachine) StartEventLoop() {
 	}
 }
 
-func (this *StateMachine) writeOnWal(idx uint64, key, value string) {
+func (this *StateMachine) writeOnWal(idx uint64, entry storage.KeyValuePair) {
 
 	if idx >= uint64(len(this.WriteAheadLog)) {
 		newWriteAheadLog := make(
@@ -51,10 +51,11 @@ func (this *StateMachine) writeOnWal(idx uint64, key, value string) {
 		for i, keyValuePair := range this.WriteAheadLog {
 			newWriteAheadLog[i] = keyValuePair
 		}
+
+		this.WriteAheadLog = newWriteAheadLog
 	}
 
-	this.WriteAheadLog[idx].Key = key
-	this.WriteAheadLog[idx].Value = value
+	this.WriteAheadLog[idx] = entry
 }
 
 func setMsgTimeOut() <-chan time.Time {
@@ -34,14 +34,17 @@ func (this *Dispatcher) DispatchTimeoutRefresh() {
 }
 
 func (this *Dispatcher) DispatchPassToLeader(
-	key, value string,
+	keyValuPair storage.KeyValuePair,
+	metaDataMap map[string]interface{},
 	interruptChannel *(chan error),
 ) {
 
 	*(this.ScheduleChannel) <- ClusterMsg{
 		Type:             ToLeader,
-		Msg:              key,
-		Value:            value,
+		Msg:              keyValuPair.Key,
+		Value:            keyValuPair.Value,
+		Entry:            keyValuPair,
+		MetaData:         metaDataMap,
 		InterruptChannel: interruptChannel,
 	}
 
@@ -57,6 +60,7 @@ func (this *Dispatcher) DispatchAppendWal(
 		Type:             AppendEntry,
 		Msg:              keyValuPair.Key,
 		Value:            keyValuPair.Value,
+		Entry:            keyValuPair,
 		IndexTime:        metaDataMap[IndexTimeHeader].(uint64),
 		MetaData:         metaDataMap,
 		InterruptChannel: interruptChannel,
@@ -88,6 +92,7 @@ func (this *Dispatcher) DispatchUpdateWalFromLeaader(
 		Type:             UpdateEntry,
 		Msg:              keyValuPair.Key,
 		Value:            keyValuPair.Value,
+		Entry:            keyValuPair,
 		IndexTime:        targetIdx,
 		InterruptChannel: interruptChannel,
 	}
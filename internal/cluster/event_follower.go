@@ -205,7 +205,14 @@ func (this *StateMachine) listenFollowerEventLoop() {
 
 			case ToLeader:
 
-				err := this.SendToLeader(msg.Msg, msg.Value)
+				durability, _ := msg.MetaData[DurabilityField].(string)
+
+				result, err := this.SendToLeader(msg.Entry, durability)
+
+				if applyResult, isSet := msg.MetaData[ApplyResultField].(*string); isSet {
+					*applyResult = result
+				}
+
 				(*msg.InterruptChannel) <- err
 
 			case VoteRequest:
@@ -278,13 +285,10 @@ func (this *StateMachine) updateEntryFromLeader(msg ClusterMsg) {
 	this.WriteLock.Lock()
 
 	targetIdx := msg.IndexTime
-	key := msg.Msg
-	value := msg.Value
 
 	this.writeOnWal(
 		targetIdx,
-		key,
-		value,
+		msg.Entry,
 	)
 
 	this.MetaDataLock.Lock()
@@ -309,8 +313,8 @@ func (this *StateMachine) updateEntryFromLeader(msg ClusterMsg) {
 	tools.InfoLogger.Printf(
 		"Write Ahead Log에 추가 성공 : 타겟인덱스 : %d / (키, 밸류) : (%s, %s)",
 		targetIdx,
-		key,
-		value,
+		msg.Entry.Key,
+		msg.Entry.Value,
 	)
 
 	*(msg.InterruptChannel) <- nil
@@ -624,17 +628,20 @@ func (this *StateMachine) saveCommitedData(
 
 		keyValuePair := this.WriteAheadLog[idx]
 
-		if keyValuePair.Key == "" {
+		if keyValuePair.IsEmpty() {
 			isWalContaminated = true
 			startIdxToPurge = idx
 			break
 		}
 
-		err := saveData(
+		_, err := saveData(
 			this.WriteAheadLog[idx],
+			"",
 		)
 
-		if err != nil {
+		// 조건 불일치 등 결정적인 거절은 리더와 같은 결과이므로 반영된 것으로 보고 넘어간다
+		// 전송 오류, 5xx 는 커밋 인덱스를 그대로 두고 다음 커밋 / 하트비트 때 다시 반영한다
+		if err != nil && !isApplyRejected(err) {
 			tools.ErrorLogger.Printf(
 				"Key Value Store에 커밋 에러 발생 (인덱스 : %d) - %s",
 				idx,
@@ -643,6 +650,14 @@ func (this *StateMachine) saveCommitedData(
 			break
 		}
 
+		if err != nil {
+			tools.InfoLogger.Printf(
+				"Key Value Store가 거절한 엔트리 (인덱스 : %d) - %s",
+				idx,
+				err.Error(),
+			)
+		}
+
 		this.MetaDataLock.Lock()
 		this.setCommitIndex(idx)
 		this.MetaDataLock.Unlock()
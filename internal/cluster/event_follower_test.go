@@ -0,0 +1,197 @@
+package cluster
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"hash_interface/internal/models"
+	"hash_interface/internal/models/response"
+	"hash_interface/internal/storage"
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+func TestMain(m *testing.M) {
+
+	tools.InfoLogger = log.New(ioutil.Discard, "", 0)
+	tools.ErrorLogger = log.New(ioutil.Discard, "", 0)
+
+	os.Exit(m.Run())
+}
+
+// newTestFollower : @entries 를 WAL 1번부터 가진 Follower (커밋 인덱스 0)
+func newTestFollower(entries []storage.KeyValuePair) *StateMachine {
+
+	scheduleChannel := make(chan ClusterMsg, 1)
+
+	follower := &StateMachine{
+		Status:               Follower,
+		MetaDataLock:         &sync.Mutex{},
+		WriteLock:            &sync.Mutex{},
+		UpdateFromLeaderLock: &sync.Mutex{},
+		ScheduleChannel:      &scheduleChannel,
+	}
+
+	follower.WriteAheadLog = append([]storage.KeyValuePair{{}}, entries...)
+	follower.IndexTime = uint64(len(entries))
+
+	return follower
+}
+
+// newTestStore : Key 별 응답 상태 코드를 돌려주는 Key Value Store, 반영 요청받은 Key 순서 기록
+func newTestStore(t *testing.T, statusCodes map[string]int) (*[]string, func()) {
+
+	appliedKeys := []string{}
+	mutex := &sync.Mutex{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+
+		var requestData models.DataRequestContainer
+		if err := json.NewDecoder(req.Body).Decode(&requestData); err != nil {
+			t.Fatalf("잘못된 반영 요청 : %s", err.Error())
+		}
+
+		key := requestData.Data[0].Key
+
+		mutex.Lock()
+		appliedKeys = append(appliedKeys, key)
+		mutex.Unlock()
+
+		statusCode, isSet := statusCodes[key]
+		if !isSet {
+			statusCode = http.StatusOK
+		}
+
+		res.WriteHeader(statusCode)
+		json.NewEncoder(res).Encode(response.BasicTemplate{Message: http.StatusText(statusCode)})
+	}))
+
+	originURI := storeURI
+	storeURI = server.URL
+
+	return &appliedKeys, func() {
+		storeURI = originURI
+		server.Close()
+	}
+}
+
+func TestSaveCommitedDataSkipsRejectedEntries(t *testing.T) {
+
+	expectedVersion := uint64(7)
+
+	fixtures := []struct {
+		name              string
+		statusCodes       map[string]int
+		expectedCommitIdx uint64
+		expectedApplied   []string
+	}{
+		{
+			name:              "조건 불일치 (409)",
+			statusCodes:       map[string]int{"cas": http.StatusConflict},
+			expectedCommitIdx: 3,
+			expectedApplied:   []string{"before", "cas", "after"},
+		},
+		{
+			name:              "없는 Key (404)",
+			statusCodes:       map[string]int{"cas": http.StatusNotFound},
+			expectedCommitIdx: 3,
+			expectedApplied:   []string{"before", "cas", "after"},
+		},
+		{
+			name:              "자료형 불일치 (400)",
+			statusCodes:       map[string]int{"cas": http.StatusBadRequest},
+			expectedCommitIdx: 3,
+			expectedApplied:   []string{"before", "cas", "after"},
+		},
+		{
+			name:              "Key Value Store 오류 (500) 는 다시 반영",
+			statusCodes:       map[string]int{"cas": http.StatusInternalServerError},
+			expectedCommitIdx: 1,
+			expectedApplied:   []string{"before", "cas"},
+		},
+	}
+
+	for _, fixture := range fixtures {
+
+		appliedKeys, closeStore := newTestStore(t, fixture.statusCodes)
+
+		follower := newTestFollower([]storage.KeyValuePair{
+			{Key: "before", Value: "1"},
+			{
+				Key:             "cas",
+				Value:           "2",
+				Condition:       storage.ConditionCompareAndSwap,
+				ExpectedVersion: &expectedVersion,
+			},
+			{Key: "after", Value: "3"},
+		})
+
+		if _, err := follower.saveCommitedData(3); err != nil {
+			t.Errorf("%s : saveCommitedData() 에러 %s", fixture.name, err.Error())
+		}
+
+		closeStore()
+
+		if commitIdx := follower.getCommitIdx(); commitIdx != fixture.expectedCommitIdx {
+			t.Errorf("%s : 커밋 인덱스 %d, expected : %d", fixture.name, commitIdx, fixture.expectedCommitIdx)
+		}
+
+		if len(*appliedKeys) != len(fixture.expectedApplied) {
+			t.Errorf("%s : 반영 요청 %v, expected : %v", fixture.name, *appliedKeys, fixture.expectedApplied)
+			continue
+		}
+
+		for i, eachKey := range fixture.expectedApplied {
+			if (*appliedKeys)[i] != eachKey {
+				t.Errorf("%s : 반영 요청 %v, expected : %v", fixture.name, *appliedKeys, fixture.expectedApplied)
+				break
+			}
+		}
+	}
+}
+
+func TestSaveDataKeepsRejectedMessage(t *testing.T) {
+
+	fixtures := map[int]bool{
+		http.StatusBadRequest:          true,
+		http.StatusNotFound:            true,
+		http.StatusConflict:            true,
+		http.StatusInternalServerError: false,
+		http.StatusGatewayTimeout:      false,
+	}
+
+	for statusCode, expectedRejected := range fixtures {
+
+		server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+			res.WriteHeader(statusCode)
+			json.NewEncoder(res).Encode(response.BasicTemplate{Message: msg.ConditionFailedMarker})
+		}))
+
+		originURI := storeURI
+		storeURI = server.URL
+
+		_, err := saveData(storage.KeyValuePair{Key: "key"}, "")
+
+		storeURI = originURI
+		server.Close()
+
+		if err == nil {
+			t.Errorf("saveData() %d 응답에 에러가 없습니다", statusCode)
+			continue
+		}
+
+		if isApplyRejected(err) != expectedRejected {
+			t.Errorf("isApplyRejected(%d) = %v, expected : %v", statusCode, !expectedRejected, expectedRejected)
+		}
+
+		if err.Error() != msg.ConditionFailedMarker {
+			t.Errorf("saveData() %d 에러 메세지 %q, expected : %q", statusCode, err.Error(), msg.ConditionFailedMarker)
+		}
+	}
+}
@@ -10,6 +10,9 @@ func (this *StateMachine) listenLeaderEventLoop() {
 
 	heartbeatTimer := setHeartbeatTimer()
 
+	// 이전 리더가 남긴 분산 트랜잭션 마무리
+	go this.watchPreparedTransactions()
+
 	for this.GetStatus() == Leader {
 		select {
 
@@ -18,18 +21,22 @@ func (this *StateMachine) listenLeaderEventLoop() {
 			switch msg.Type {
 			case AppendEntry:
 
-				key := msg.Msg
-				value := msg.Value
-
 				tools.InfoLogger.Printf(
 					"나는 Leader : AppendEntry 전달 받음 - (key, value) : (%s, %s)",
-					key,
-					value,
+					msg.Msg,
+					msg.Value,
 				)
 
-				err := this.handleAppendEntry(key, value)
+				durability, _ := msg.MetaData[DurabilityField].(string)
+				applyResult, _ := msg.MetaData[ApplyResultField].(*string)
 
-				(*msg.InterruptChannel) <- err
+				// 요청 쓰레드에게 결과 응답은 handleAppendEntry 가 담당
+				this.handleAppendEntry(
+					msg.Entry,
+					durability,
+					applyResult,
+					msg.InterruptChannel,
+				)
 				break
 
 			case VoteRequest:
@@ -175,10 +182,15 @@ func (this *StateMachine) updateFollowersWal(
 }
 
 // 내부적으로 Write Lock
+// 커밋 실패 시 바로 @interruptChannel 에 에러 응답
+// 커밋 성공 시 @durability 가 설정되어 있거나 분산 트랜잭션 단계이면, Key Value Store 반영까지 끝난 뒤 응답
 //
 func (this *StateMachine) handleAppendEntry(
-	key, value string,
-) error {
+	entry storage.KeyValuePair,
+	durability string,
+	applyResult *string,
+	interruptChannel *(chan error),
+) {
 	// Queue에서 하나씩 꺼내서 전파
 	this.WriteLock.Lock()
 
@@ -187,8 +199,7 @@ func (this *StateMachine) handleAppendEntry(
 
 	this.writeOnWal(
 		nextIdx,
-		key,
-		value,
+		entry,
 	)
 
 	this.MetaDataLock.Lock()
@@ -197,10 +208,7 @@ func (this *StateMachine) handleAppendEntry(
 
 	// Tell Others to Write on Logs
 	// 내부에서 대기
-	err := this.broadcastAppendWal(
-		key,
-		value,
-	)
+	err := this.broadcastAppendWal(entry)
 
 	// 과반수 이상이 Log 작성 성공한 경우
 	if err == nil {
@@ -215,20 +223,30 @@ func (this *StateMachine) handleAppendEntry(
 
 		this.broadcastCommit()
 
-		// 자신의 Key Value Store 에 저장
-		go saveData(storage.KeyValuePair{
-			Key:   key,
-			Value: value,
-		})
+		task := applyTask{
+			keyValuePair: entry,
+			durability:   durability,
+		}
+
+		// 자신의 Key Value Store 에 커밋 순서대로 저장
+		if requiresApplyAck(entry, durability) {
+			task.interruptChannel = interruptChannel
+			task.applyResult = applyResult
+			this.applyChannel <- task
+
+		} else {
+			this.applyChannel <- task
+			*interruptChannel <- nil
+		}
 
 	} else {
 		tools.ErrorLogger.Printf(
 			"과반수 이상의 노드가 AppendWal 실패 : %s",
 			err.Error(),
 		)
+
+		*interruptChannel <- err
 	}
 
 	this.WriteLock.Unlock()
-
-	return err
 }
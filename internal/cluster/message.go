@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash_interface/internal/models"
+	"hash_interface/internal/models/response"
 	"hash_interface/internal/storage"
 	"hash_interface/tools"
 	"net/http"
@@ -22,6 +23,13 @@ const (
 
 	// startIndexTimeHeader : WAL 업데이트를 요청한 Follower의 Index Time
 	StartIndexTimeHeader = "startIndexTime"
+
+	// DurabilityField : 메타데이터 맵에서 요청의 복제 확인 수준 Key
+	DurabilityField = "durability"
+
+	// ApplyResultField : 메타데이터 맵에서 반영 결과(*string)를 받을 Key
+	// 반영 결과를 기다리는 엔트리(ex. INCRBY 의 새로운 값)만 채워진다
+	ApplyResultField = "applyResult"
 )
 
 func (this *StateMachine) sendWalUpdateMsg(
@@ -348,7 +356,7 @@ func sendElectedMsg(targetAddress, newLeader string, term uint64) {
 	return
 }
 
-func (this *StateMachine) broadcastAppendWal(key, value string) error {
+func (this *StateMachine) broadcastAppendWal(entry storage.KeyValuePair) error {
 
 	resultChannel := make(chan error)
 
@@ -359,8 +367,8 @@ func (this *StateMachine) broadcastAppendWal(key, value string) error {
 
 	tools.InfoLogger.Printf(
 		"AppendEntry 다른 친구들에게 전부! 전달 : key : %s, value : %s, 현재 term : %d, 리더 Index : %d",
-		key,
-		value,
+		entry.Key,
+		entry.Value,
 		curTerm,
 		leaderIdx,
 	)
@@ -368,11 +376,11 @@ func (this *StateMachine) broadcastAppendWal(key, value string) error {
 	for _, eachNode := range this.Cluster.nodeAddressList {
 		go func(resultChannel *(chan error), target string) {
 
-			err := sendAppendWalMsg(
+			_, err := sendAppendWalMsg(
 				target,
-				key,
-				value,
+				entry,
 				this.Cluster.curIpAddress,
+				"",
 				curTerm,
 				leaderIdx,
 				FromLeader,
@@ -430,11 +438,16 @@ func (this *StateMachine) broadcastAppendWal(key, value string) error {
 	}
 }
 
+// sendAppendWalMsg : @targetAddress 에 AppendWal 전달
+// 팔로워가 리더에게 전달한 경우, 리더의 반영 결과를 함께 반환
+//
 func sendAppendWalMsg(
-	targetAddress, key, value, leader string,
+	targetAddress string,
+	entry storage.KeyValuePair,
+	leader, durability string,
 	curTerm, indexTime uint64,
 	isFromLeader bool,
-) error {
+) (string, error) {
 
 	// 등록 상대 노드에도 등록 요청
 	requestURI := fmt.Sprintf(
@@ -446,13 +459,12 @@ func sendAppendWalMsg(
 		requestURI += "/leader"
 	}
 
-	requestData := models.DataRequestContainer{}
+	requestData := models.DataRequestContainer{
+		Durability: durability,
+	}
 	requestData.Data = append(
 		requestData.Data,
-		storage.KeyValuePair{
-			Key:   key,
-			Value: value,
-		},
+		entry,
 	)
 
 	encodedData, _ := json.Marshal(requestData)
@@ -504,9 +516,11 @@ func sendAppendWalMsg(
 			"리더가 AppendWal 보내는데 에러 : %s",
 			err.Error(),
 		)
-		return err
+		return "", err
 	}
 
+	defer res.Body.Close()
+
 	if res.StatusCode >= 400 {
 
 		tools.InfoLogger.Printf(
@@ -514,10 +528,32 @@ func sendAppendWalMsg(
 			targetAddress,
 		)
 
-		return fmt.Errorf("Update Error")
+		// 복제 확인 실패 등, 리더의 에러 메세지를 그대로 전달
+		if errMsg := readErrorMessage(res); errMsg != "" {
+			return "", fmt.Errorf(errMsg)
+		}
+
+		return "", fmt.Errorf("Update Error")
 	}
 
-	return nil
+	if isFromLeader {
+		return "", nil
+	}
+
+	return readApplyResult(res), nil
+}
+
+// readErrorMessage : 에러 응답(response.BasicTemplate)의 메세지 추출
+func readErrorMessage(res *http.Response) string {
+
+	var errorResponse response.BasicTemplate
+	decoder := json.NewDecoder(res.Body)
+
+	if err := decoder.Decode(&errorResponse); err != nil {
+		return ""
+	}
+
+	return errorResponse.Message
 }
 
 func (this *StateMachine) broadcastHeartbeat() {
@@ -692,16 +728,38 @@ func sendCommitMsg(targetNode string, commitIdx, term, leaderIdxTime uint64) {
 	}
 }
 
-func saveData(keyValuePair storage.KeyValuePair) error {
+// storeURI : 커밋된 엔트리를 반영할 자신의 Key Value Store
+// 컨테이너는 독립된 가상 네트워크로 구성되어있으므로
+// 컨테이너 기준 로컬호스트는 컨테이너가 둘러쌓여진 가상네트워크 공간이다
+// 따라서 컨테이너가 떠있는 포트로 전달해야한다!
+//
+var storeURI = "http://localhost:8888/api/v1/hash/data"
 
-	// 컨테이너는 독립된 가상 네트워크로 구성되어있으므로
-	// 컨테이너 기준 로컬호스트는 컨테이너가 둘러쌓여진 가상네트워크 공간이다
-	// 따라서 컨테이너가 떠있는 포트로 전달해야한다!
-	requestURI := fmt.Sprintf(
-		"http://localhost:8888/api/v1/hash/data",
-	)
+// applyRejectedError : Key Value Store 가 엔트리를 거절한 응답 (4xx)
+// ex. 조건부 쓰기의 조건 불일치, 없는 Key, 잠긴 Key, 타입 불일치, 반영할 수 없는 구성 변경
+// 같은 로그를 같은 순서로 반영하는 모든 서버에서 같은 결과가 나오므로, 반영된 엔트리로 본다
+//
+type applyRejectedError struct {
+	statusCode int
+	message    string
+}
 
-	requestData := models.DataRequestContainer{}
+func (err *applyRejectedError) Error() string {
+	return err.message
+}
+
+// isApplyRejected : 결정적인 거절인지, 아니면 (전송 오류, 5xx) 다시 반영해야 하는지
+func isApplyRejected(err error) bool {
+	_, isRejected := err.(*applyRejectedError)
+	return isRejected
+}
+
+// saveData : 엔트리를 자신의 Key Value Store 에 반영, 반영 결과 반환
+func saveData(keyValuePair storage.KeyValuePair, durability string) (string, error) {
+
+	requestData := models.DataRequestContainer{
+		Durability: durability,
+	}
 	requestData.Data = append(
 		requestData.Data,
 		keyValuePair,
@@ -709,14 +767,14 @@ func saveData(keyValuePair storage.KeyValuePair) error {
 
 	encodedData, err := json.Marshal(requestData)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	requestBody := bytes.NewBuffer(encodedData)
 
 	saveReq, err := http.NewRequest(
 		http.MethodPost,
-		requestURI,
+		storeURI,
 		requestBody,
 	)
 
@@ -728,12 +786,38 @@ func saveData(keyValuePair storage.KeyValuePair) error {
 	client := &http.Client{}
 	res, err := client.Do(saveReq)
 	if err != nil {
-		return err
+		return "", err
 	}
+	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		return fmt.Errorf("데이터 저장 실패")
+		errMsg := readErrorMessage(res)
+		if errMsg == "" {
+			errMsg = "데이터 저장 실패"
+		}
+
+		if res.StatusCode < http.StatusInternalServerError {
+			return "", &applyRejectedError{
+				statusCode: res.StatusCode,
+				message:    errMsg,
+			}
+		}
+
+		return "", fmt.Errorf(errMsg)
 	}
 
-	return nil
+	return readApplyResult(res), nil
+}
+
+// readApplyResult : 반영 성공 응답(response.SetResultTemplate)의 첫 번째 결과 추출
+func readApplyResult(res *http.Response) string {
+
+	var resultResponse response.SetResultTemplate
+	decoder := json.NewDecoder(res.Body)
+
+	if err := decoder.Decode(&resultResponse); err != nil || len(resultResponse.Results) == 0 {
+		return ""
+	}
+
+	return resultResponse.Results[0].Result
 }
@@ -50,6 +50,7 @@ type ClusterMsg struct {
 	IsAskedToBeUpdate bool
 	IsElected         bool
 	KeyValuePairs     []storage.KeyValuePair
+	Entry             storage.KeyValuePair
 	MetaData          map[string]interface{}
 	InterruptChannel  *(chan error)
 }
@@ -77,6 +78,9 @@ type StateMachine struct {
 
 	ScheduleChannel *(chan ClusterMsg)
 
+	// applyChannel : 커밋된 엔트리를 순서대로 Key Value Store에 반영하기 위한 큐
+	applyChannel chan applyTask
+
 	Cluster *ClusterInfo
 }
 
@@ -116,6 +120,9 @@ func (this *StateMachine) Init() error {
 	scheduleChannel := make(chan ClusterMsg)
 	this.ScheduleChannel = &scheduleChannel
 
+	this.applyChannel = make(chan applyTask, applyQueueSize)
+	this.startApplier()
+
 	this.Cluster = &ClusterInfo{}
 	err := this.Cluster.Init()
 	if err != nil {
@@ -135,7 +142,7 @@ func (this *StateMachine) Start(isStartPoint bool) {
 	}()
 }
 
-func (this *StateMachine) SendToLeader(key, value string) error {
+func (this *StateMachine) SendToLeader(entry storage.KeyValuePair, durability string) (string, error) {
 
 	leader := this.GetLeader()
 
@@ -147,24 +154,22 @@ func (this *StateMachine) SendToLeader(key, value string) error {
 	tools.InfoLogger.Printf(
 		"리더 (%s) 에게 set key : %s, value : %s 전달",
 		leader,
-		key,
-		value,
+		entry.Key,
+		entry.Value,
 	)
 
 	// 재시도 하지 않고 대기로 변경
 	// 재시도 했다가 중복된 연산이 WAL에 쌓일까봐
-	err := sendAppendWalMsg(
+	return sendAppendWalMsg(
 		leader,
-		key,
-		value,
+		entry,
 		"",
+		durability,
 		term,
 		idxTime,
 		FromFollower,
 	)
 
-	return err
-
 }
 
 func (this *StateMachine) GetLeader() string {
@@ -0,0 +1,124 @@
+package cluster
+
+import (
+	"time"
+
+	"hash_interface/internal/storage"
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+// watchPreparedTransactions : 리더가 된 노드가 결정이 반영되지 않은 분산 트랜잭션(2PC)을 마무리하는 고루틴
+//  - 리더가 되자마자 : 이전 리더 / 코디네이터가 남긴 트랜잭션 중 결정 기한이 지난 것
+//  - 이후 주기적으로 : 결정 기한(PREPARE 후 TransactionTimeout)이 지난 트랜잭션 (코디네이터가 죽은 경우)
+// 기한 전인 트랜잭션은 코디네이터가 새 리더에게 결정을 보낼 수 있으므로 취소하지 않는다
+//
+func (this *StateMachine) watchPreparedTransactions() {
+
+	this.resolvePreparedTransactions(time.Now())
+
+	ticker := time.NewTicker(storage.TransactionTimeout)
+	defer ticker.Stop()
+
+	for range ticker.C {
+
+		if this.GetStatus() != Leader {
+			return
+		}
+
+		this.resolvePreparedTransactions(time.Now())
+	}
+}
+
+// resolvePreparedTransactions : 결정 기한이 @now 이전인 PREPARE 상태 분산 트랜잭션의 결정을 WAL 에 기록
+//  - WAL 에 이미 결정(COMMIT / ABORT)이 있으면 같은 결정을 다시 기록 (반영은 멱등, 실패한 COMMIT 재시도)
+//  - 결정이 없으면 코디네이터가 죽은 것으로 보고 ABORT (Presumed Abort)
+//
+func (this *StateMachine) resolvePreparedTransactions(now time.Time) {
+
+	decisions, preparedInWal := this.scanTransactionDecisions(now)
+
+	// WAL 에는 있지만 아직 반영되지 않은 PREPARE 도 마무리
+	pendingTxIDs := append(storage.GetPreparedTransactions(now), preparedInWal...)
+
+	isResolved := make(map[string]bool)
+
+	for _, txID := range pendingTxIDs {
+
+		if isResolved[txID] {
+			continue
+		}
+		isResolved[txID] = true
+
+		decision, isDecided := decisions[txID]
+		if !isDecided {
+			decision = storage.TxnAbortCommand
+		}
+
+		tools.InfoLogger.Printf(msg.TransactionRecovery, txID, decision)
+
+		if err := this.appendTransactionDecision(txID, decision); err != nil {
+			tools.ErrorLogger.Printf(
+				msg.TransactionRecoveryFail,
+				txID,
+				decision,
+				err.Error(),
+			)
+		}
+	}
+}
+
+// scanTransactionDecisions : WAL 의 분산 트랜잭션 결정 (트랜잭션 ID -> 마지막 결정) 과
+// 결정 없이 PREPARE 만 기록되고 결정 기한이 @now 이전인 트랜잭션 ID 목록
+//
+func (this *StateMachine) scanTransactionDecisions(now time.Time) (map[string]string, []string) {
+
+	this.WriteLock.Lock()
+	defer this.WriteLock.Unlock()
+
+	decisions := make(map[string]string)
+	preparedTxIDs := []string{}
+
+	lastIdx := this.GetIndexTime(true)
+
+	for idx := uint64(0); idx <= lastIdx && idx < uint64(len(this.WriteAheadLog)); idx++ {
+
+		entry := this.WriteAheadLog[idx]
+
+		switch entry.Command {
+		case storage.TxnPrepareCommand:
+			if !now.Before(storage.PrepareDeadline(entry, now)) {
+				preparedTxIDs = append(preparedTxIDs, entry.TxID)
+			}
+
+		case storage.TxnCommitCommand, storage.TxnAbortCommand:
+			decisions[entry.TxID] = entry.Command
+		}
+	}
+
+	undecidedTxIDs := []string{}
+	for _, txID := range preparedTxIDs {
+		if _, isDecided := decisions[txID]; !isDecided {
+			undecidedTxIDs = append(undecidedTxIDs, txID)
+		}
+	}
+
+	return decisions, undecidedTxIDs
+}
+
+// appendTransactionDecision : 리더 자신의 이벤트 루프에 결정 엔트리 추가, 반영될 때까지 대기
+func (this *StateMachine) appendTransactionDecision(txID, decision string) error {
+
+	interruptChannel := make(chan error)
+
+	EventDispatcher.DispatchAppendWal(
+		storage.KeyValuePair{
+			Command: decision,
+			TxID:    txID,
+		},
+		map[string]interface{}{},
+		&interruptChannel,
+	)
+
+	return <-interruptChannel
+}
@@ -0,0 +1,42 @@
+package cluster
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"hash_interface/internal/storage"
+)
+
+// 리더가 되었을 때, 결정 기한이 지나지 않은 PREPARE 는 취소 대상이 아니다
+func TestScanTransactionDecisions(t *testing.T) {
+
+	now := time.Now()
+	commands := []storage.KeyValuePair{{Key: "{a}1", Value: "x"}, {Key: "{b}1", Value: "y"}}
+
+	follower := newTestFollower([]storage.KeyValuePair{
+		storage.NewPrepareEntry("expired", commands, now.Add(-2*storage.TransactionTimeout)),
+		storage.NewPrepareEntry("healthy", commands, now),
+		{Command: storage.TxnPrepareCommand, TxID: "legacy", Commands: commands},
+		storage.NewPrepareEntry("committed", commands, now.Add(-2*storage.TransactionTimeout)),
+		{Command: storage.TxnCommitCommand, TxID: "committed"},
+		storage.NewPrepareEntry("aborted", commands, now),
+		{Command: storage.TxnAbortCommand, TxID: "aborted"},
+	})
+
+	expectedDecisions := map[string]string{
+		"committed": storage.TxnCommitCommand,
+		"aborted":   storage.TxnAbortCommand,
+	}
+	expectedUndecided := []string{"expired"}
+
+	decisions, undecided := follower.scanTransactionDecisions(now)
+
+	if !reflect.DeepEqual(decisions, expectedDecisions) {
+		t.Errorf("scanTransactionDecisions() 결정 %v, expected : %v", decisions, expectedDecisions)
+	}
+
+	if !reflect.DeepEqual(undecided, expectedUndecided) {
+		t.Errorf("scanTransactionDecisions() 기한이 지난 PREPARE %v, expected : %v", undecided, expectedUndecided)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"hash_interface/internal/cluster"
 	"hash_interface/internal/models"
 	"hash_interface/internal/models/response"
+	"hash_interface/internal/storage"
 	"hash_interface/tools"
 
 	"github.com/gorilla/mux"
@@ -318,8 +319,13 @@ func HandleAppendWal(res http.ResponseWriter, req *http.Request) {
 
 	keyValuePair := requestData.Data[0]
 	metaDataMap := extractMetaData(req)
+	metaDataMap[cluster.DurabilityField] = requestData.Durability
 	interruptChannel := make(chan error)
 
+	// 반영 결과가 필요한 엔트리(ex. INCRBY)는 요청한 팔로워에게 결과를 돌려준다
+	var applyResult string
+	metaDataMap[cluster.ApplyResultField] = &applyResult
+
 	eventDispatcher.DispatchAppendWal(
 		keyValuePair,
 		metaDataMap,
@@ -327,13 +333,34 @@ func HandleAppendWal(res http.ResponseWriter, req *http.Request) {
 	)
 
 	err := <-interruptChannel
+	if storage.IsReplicaQuorumError(err) {
+		responseError(res, http.StatusGatewayTimeout, err)
+		return
+	}
+
 	if err != nil {
 		responseError(res, http.StatusBadRequest, err)
 		return
 
 	}
 
-	responseOK(res, []byte{})
+	responseTemplate := response.SetResultTemplate{
+		Results: []response.RedisResult{
+			{Result: applyResult},
+		},
+	}
+
+	responseBody, err := responseTemplate.Marshal(
+		"AppendWal completed",
+		"Main URL",
+		configs.HTTP+configs.BaseURL,
+	)
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
 }
 
 func HandleHeartbeat(res http.ResponseWriter, req *http.Request) {
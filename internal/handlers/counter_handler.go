@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"hash_interface/configs"
+	"hash_interface/internal/models"
+	"hash_interface/internal/models/response"
+	"hash_interface/internal/storage"
+	"hash_interface/tools"
+
+	"github.com/gorilla/mux"
+)
+
+// @Summary Increment Counter of Key (INCRBY)
+// @Description ## Key 값을 delta 만큼 원자적으로 증가 (생략 시 1, 음수면 감소), 증가 후 값 반환
+// @Description WAL 과 데이터 로그에는 증가량이 기록되어, 모든 노드에 같은 순서로 반영된다
+// @Accept json
+// @Produce json
+// @Router /hash/counter/{key} [post]
+// @Param key path string true "Target Key"
+// @Param counter body models.CounterRequestContainer false "Delta"
+// @Success 200 {object} response.CounterResultTemplate
+// @Failure 400 {object} response.BasicTemplate "정수가 아닌 값"
+// @Failure 409 {object} response.BasicTemplate "분산 트랜잭션이 잠근 Key"
+// @Failure 421 {object} response.SlotRedirectTemplate "MOVED / ASK (slotOwner 헤더의 마스터가 담당 노드가 아닌 경우)"
+func HandleCounter(res http.ResponseWriter, req *http.Request) {
+
+	// 바디 없는 요청은 1 증가
+	requestedCounter := models.CounterRequestContainer{}
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&requestedCounter); err != nil && err != io.EOF {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	delta := int64(1)
+	if requestedCounter.Delta != nil {
+		delta = *requestedCounter.Delta
+	}
+
+	key := mux.Vars(req)["key"]
+	entry := storage.NewCounterEntry(key, delta)
+
+	if redirectIfMisrouted(res, req, key) {
+		return
+	}
+
+	durability, err := storage.ParseDurability(requestedCounter.Durability)
+	if err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := dispatchEntryWithResult(res, req, entry, durability)
+	if err != nil {
+		responseDispatchError(res, err)
+		return
+	}
+
+	newValue, err := strconv.ParseInt(result, 10, 64)
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseTemplate := response.CounterResultTemplate{
+		Key:   key,
+		Value: newValue,
+	}
+
+	curMsg := fmt.Sprintf(
+		"INCRBY %s %d completed Success : Handled in Server(IP : %s)",
+		key,
+		delta,
+		configs.CurrentIP,
+	)
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		tools.ErrorLogger.Println(err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
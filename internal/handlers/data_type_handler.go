@@ -0,0 +1,417 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/gorilla/mux"
+
+	"hash_interface/configs"
+	"hash_interface/internal/models"
+	"hash_interface/internal/models/response"
+	"hash_interface/internal/storage"
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+// @Summary Set Field of Hash (HSET)
+// @Accept json
+// @Produce json
+// @Router /hash/data/{key}/hash [post]
+// @Param key path string true "Target Key"
+// @Param hash body models.HashRequestContainer true "Field and Value"
+// @Success 200 {object} response.CommandResultTemplate "result : 새로 추가된 필드 수"
+// @Failure 400 {object} response.BasicTemplate "Hash 가 아닌 Key (WRONGTYPE)"
+// @Failure 421 {object} response.SlotRedirectTemplate "MOVED / ASK (slotOwner 헤더의 마스터가 담당 노드가 아닌 경우)"
+func HandleHashSet(res http.ResponseWriter, req *http.Request) {
+
+	requestedHash := models.HashRequestContainer{}
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&requestedHash); err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	entry := storage.NewHashEntry(
+		mux.Vars(req)["key"],
+		requestedHash.Field,
+		requestedHash.Value,
+	)
+
+	handleTypedWriteEntry(res, req, entry, requestedHash.Durability)
+}
+
+// @Summary Push Elements to List (LPUSH)
+// @Accept json
+// @Produce json
+// @Router /hash/data/{key}/list [post]
+// @Param key path string true "Target Key"
+// @Param list body models.ElementsRequestContainer true "Elements"
+// @Success 200 {object} response.CommandResultTemplate "result : 추가 후 리스트 길이"
+// @Failure 400 {object} response.BasicTemplate "List 가 아닌 Key (WRONGTYPE)"
+// @Failure 421 {object} response.SlotRedirectTemplate "MOVED / ASK (slotOwner 헤더의 마스터가 담당 노드가 아닌 경우)"
+func HandleListPush(res http.ResponseWriter, req *http.Request) {
+
+	requestedElements := models.ElementsRequestContainer{}
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&requestedElements); err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	entry := storage.NewElementsEntry(
+		storage.LPushCommand,
+		mux.Vars(req)["key"],
+		requestedElements.Values,
+	)
+
+	handleTypedWriteEntry(res, req, entry, requestedElements.Durability)
+}
+
+// @Summary Pop Element from List (RPOP)
+// @Accept json
+// @Produce json
+// @Router /hash/data/{key}/list/pop [post]
+// @Param key path string true "Target Key"
+// @Success 200 {object} response.CommandResultTemplate "result : 꺼낸 원소"
+// @Failure 404 {object} response.BasicTemplate "없는 Key 또는 빈 리스트"
+// @Failure 421 {object} response.SlotRedirectTemplate "MOVED / ASK (slotOwner 헤더의 마스터가 담당 노드가 아닌 경우)"
+func HandleListPop(res http.ResponseWriter, req *http.Request) {
+
+	// 바디는 durability 설정 시에만
+	requestedElements := models.ElementsRequestContainer{}
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&requestedElements); err != nil && err != io.EOF {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	entry := storage.KeyValuePair{
+		Command: storage.RPopCommand,
+		Key:     mux.Vars(req)["key"],
+	}
+
+	handleTypedWriteEntry(res, req, entry, requestedElements.Durability)
+}
+
+// @Summary Add Members to Set (SADD)
+// @Accept json
+// @Produce json
+// @Router /hash/data/{key}/set [post]
+// @Param key path string true "Target Key"
+// @Param set body models.ElementsRequestContainer true "Members"
+// @Success 200 {object} response.CommandResultTemplate "result : 새로 추가된 원소 수"
+// @Failure 400 {object} response.BasicTemplate "Set 이 아닌 Key (WRONGTYPE)"
+// @Failure 421 {object} response.SlotRedirectTemplate "MOVED / ASK (slotOwner 헤더의 마스터가 담당 노드가 아닌 경우)"
+func HandleSetAdd(res http.ResponseWriter, req *http.Request) {
+
+	requestedElements := models.ElementsRequestContainer{}
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&requestedElements); err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	entry := storage.NewElementsEntry(
+		storage.SAddCommand,
+		mux.Vars(req)["key"],
+		requestedElements.Values,
+	)
+
+	handleTypedWriteEntry(res, req, entry, requestedElements.Durability)
+}
+
+// handleTypedWriteEntry : 자료형 쓰기 엔트리를 WAL 에 기록, 반영 결과(레디스 응답)로 응답
+func handleTypedWriteEntry(
+	res http.ResponseWriter,
+	req *http.Request,
+	entry storage.KeyValuePair,
+	requestedDurability string,
+) {
+
+	durability, err := storage.ParseDurability(requestedDurability)
+	if err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := storage.ValidateEntry(entry); err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	if redirectIfMisrouted(res, req, storage.EntryKeys(entry)...) {
+		return
+	}
+
+	result, err := dispatchEntryWithResult(res, req, entry, durability)
+	if err != nil {
+		responseDispatchError(res, err)
+		return
+	}
+
+	responseTemplate := response.CommandResultTemplate{
+		Key:     entry.Key,
+		Command: entry.Command,
+		Result:  result,
+	}
+
+	curMsg := fmt.Sprintf(
+		"%s completed Success : Handled in Server(IP : %s)",
+		storage.FormatEntry(entry),
+		configs.CurrentIP,
+	)
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		tools.ErrorLogger.Println(err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
+
+// @Summary Get All Fields of Hash (HGETALL)
+// @Accept json
+// @Produce json
+// @Router /hash/data/{key}/hash [get]
+// @Param key path string true "Target Key"
+// @Success 200 {object} response.HashResultTemplate
+// @Failure 400 {object} response.BasicTemplate "Hash 가 아닌 Key (WRONGTYPE)"
+// @Failure 421 {object} response.SlotRedirectTemplate "MOVED / ASK (slotOwner 헤더의 마스터가 담당 노드가 아닌 경우)"
+func GetHashFromKey(res http.ResponseWriter, req *http.Request) {
+
+	if err := waitForIndexTime(req); err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	key := mux.Vars(req)["key"]
+
+	if redirectIfMisrouted(res, req, key) {
+		return
+	}
+
+	fields, redisClient, err := storage.GetHashAll(key)
+	if err != nil {
+		responseReadError(res, err)
+		return
+	}
+
+	responseTemplate := response.HashResultTemplate{
+		Key:         key,
+		Fields:      fields,
+		NodeAdrress: redisClient.Address,
+	}
+
+	curMsg := fmt.Sprintf(
+		"HGETALL %s completed Success : Handled in Server(IP : %s)",
+		key,
+		configs.CurrentIP,
+	)
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		tools.ErrorLogger.Println(err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
+
+// @Summary Get Field of Hash (HGET)
+// @Accept json
+// @Produce json
+// @Router /hash/data/{key}/hash/{field} [get]
+// @Param key path string true "Target Key"
+// @Param field path string true "Target Field"
+// @Success 200 {object} response.GetResultTemplate
+// @Failure 400 {object} response.BasicTemplate "Hash 가 아닌 Key (WRONGTYPE)"
+// @Failure 421 {object} response.SlotRedirectTemplate "MOVED / ASK (slotOwner 헤더의 마스터가 담당 노드가 아닌 경우)"
+func GetHashFieldFromKey(res http.ResponseWriter, req *http.Request) {
+
+	if err := waitForIndexTime(req); err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	params := mux.Vars(req)
+	key, field := params["key"], params["field"]
+
+	if redirectIfMisrouted(res, req, key) {
+		return
+	}
+
+	value, redisClient, err := storage.GetHashField(key, field)
+	exists := err == nil
+	if err == redis.ErrNil {
+		value = "nil(없음)"
+
+	} else if err != nil {
+		responseReadError(res, err)
+		return
+	}
+
+	curMsg := fmt.Sprintf(
+		"HGET %s %s completed Success : Handled in Server(IP : %s)",
+		key,
+		field,
+		configs.CurrentIP,
+	)
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseTemplate := response.GetResultTemplate{}
+	responseTemplate.Version = storage.GetKeyVersion(key)
+	responseTemplate.Exists = exists
+
+	responseBody, err := responseTemplate.Marshal(
+		value,
+		redisClient.Address,
+		curMsg, nextMsg, nextLink,
+	)
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
+
+// @Summary Get Range of List (LRANGE)
+// @Description ## start, stop 생략 시 리스트 전체 (0, -1), 음수는 끝에서부터
+// @Accept json
+// @Produce json
+// @Router /hash/data/{key}/list [get]
+// @Param key path string true "Target Key"
+// @Param start query int false "Start Index"
+// @Param stop query int false "Stop Index (inclusive)"
+// @Success 200 {object} response.ElementsResultTemplate
+// @Failure 400 {object} response.BasicTemplate "List 가 아닌 Key (WRONGTYPE)"
+// @Failure 421 {object} response.SlotRedirectTemplate "MOVED / ASK (slotOwner 헤더의 마스터가 담당 노드가 아닌 경우)"
+func GetListFromKey(res http.ResponseWriter, req *http.Request) {
+
+	if err := waitForIndexTime(req); err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	key := mux.Vars(req)["key"]
+
+	if redirectIfMisrouted(res, req, key) {
+		return
+	}
+
+	startString := req.URL.Query().Get("start")
+	stopString := req.URL.Query().Get("stop")
+	if startString == "" {
+		startString = "0"
+	}
+	if stopString == "" {
+		stopString = "-1"
+	}
+
+	start, startErr := strconv.ParseInt(startString, 10, 64)
+	stop, stopErr := strconv.ParseInt(stopString, 10, 64)
+	if startErr != nil || stopErr != nil {
+		responseError(res, http.StatusBadRequest, fmt.Errorf(msg.InvalidListRange, startString, stopString))
+		return
+	}
+
+	elements, redisClient, err := storage.GetListRange(key, start, stop)
+	if err != nil {
+		responseReadError(res, err)
+		return
+	}
+
+	responseElements(
+		res,
+		fmt.Sprintf("LRANGE %s %d %d", key, start, stop),
+		response.ElementsResultTemplate{
+			Key:         key,
+			Type:        storage.ListType,
+			Values:      elements,
+			NodeAdrress: redisClient.Address,
+		},
+	)
+}
+
+// @Summary Get Members of Set (SMEMBERS)
+// @Accept json
+// @Produce json
+// @Router /hash/data/{key}/set [get]
+// @Param key path string true "Target Key"
+// @Success 200 {object} response.ElementsResultTemplate
+// @Failure 400 {object} response.BasicTemplate "Set 이 아닌 Key (WRONGTYPE)"
+// @Failure 421 {object} response.SlotRedirectTemplate "MOVED / ASK (slotOwner 헤더의 마스터가 담당 노드가 아닌 경우)"
+func GetSetFromKey(res http.ResponseWriter, req *http.Request) {
+
+	if err := waitForIndexTime(req); err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	key := mux.Vars(req)["key"]
+
+	if redirectIfMisrouted(res, req, key) {
+		return
+	}
+
+	members, redisClient, err := storage.GetSetMembers(key)
+	if err != nil {
+		responseReadError(res, err)
+		return
+	}
+
+	responseElements(
+		res,
+		fmt.Sprintf("SMEMBERS %s", key),
+		response.ElementsResultTemplate{
+			Key:         key,
+			Type:        storage.SetType,
+			Values:      members,
+			NodeAdrress: redisClient.Address,
+		},
+	)
+}
+
+func responseElements(res http.ResponseWriter, command string, responseTemplate response.ElementsResultTemplate) {
+
+	curMsg := fmt.Sprintf(
+		"%s completed Success : Handled in Server(IP : %s)",
+		command,
+		configs.CurrentIP,
+	)
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		tools.ErrorLogger.Println(err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
+
+// responseReadError : 자료형이 맞지 않는 Key 는 400, 그 외 500
+func responseReadError(res http.ResponseWriter, err error) {
+
+	if storage.IsWrongTypeError(err) {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	responseError(res, http.StatusInternalServerError, err)
+}
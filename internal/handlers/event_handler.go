@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"hash_interface/configs"
+	"hash_interface/internal/models/response"
+	"hash_interface/internal/storage"
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+// @Summary Get Failover Event History
+// @Description ## 슬레이브 승격, 마스터 강등, 해쉬 슬롯 재분배, 노드 재시작 기록 (종류, 노드, 역할 변화, 옮겨진 슬롯 수, 투표 결과, 소요 시간, 결과)
+// @Accept json
+// @Produce json
+// @Router /events [get]
+// @Param since query string false "이 시각 이후의 이벤트만 (RFC3339 또는 Unix millisecond)"
+// @Success 200 {object} response.FailoverEventListTemplate
+// @Failure 400 {object} response.BasicTemplate "잘못된 since"
+func GetFailoverEvents(res http.ResponseWriter, req *http.Request) {
+
+	since, err := parseEventsSince(req.URL.Query().Get("since"))
+	if err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	events, err := storage.GetFailoverEvents(since)
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseTemplate := response.FailoverEventListTemplate{
+		Events: events,
+	}
+
+	curMsg := fmt.Sprintf(
+		"%d failover events : Handled in Server(IP : %s)",
+		len(events),
+		configs.CurrentIP,
+	)
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		tools.ErrorLogger.Println(err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
+
+// parseEventsSince : RFC3339 시각 또는 Unix millisecond, 빈 문자열이면 모든 이벤트
+func parseEventsSince(since string) (time.Time, error) {
+
+	if since == "" {
+		return time.Time{}, nil
+	}
+
+	if unixMillisecond, err := strconv.ParseInt(since, 10, 64); err == nil {
+		return time.Unix(0, unixMillisecond*int64(time.Millisecond)), nil
+	}
+
+	sinceTime, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(msg.InvalidEventsSince, since)
+	}
+
+	return sinceTime, nil
+}
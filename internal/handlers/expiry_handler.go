@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"hash_interface/configs"
+	"hash_interface/internal/models"
+	"hash_interface/internal/models/response"
+	"hash_interface/internal/storage"
+	"hash_interface/tools"
+
+	"github.com/gorilla/mux"
+)
+
+// @Summary Set Expiration of Key (EXPIRE)
+// @Description ## Key의 만료 시간 설정 (millisecond), 만료 시각은 WAL 에 기록되어 모든 노드에 같은 시각으로 반영
+// @Accept json
+// @Produce json
+// @Router /hash/data/{key}/expire [post]
+// @Param key path string true "Target Key"
+// @Param expire body models.ExpireRequestContainer true "TTL in millisecond"
+// @Success 200 {object} response.BasicTemplate
+// @Failure 400 {object} response.BasicTemplate "잘못된 TTL"
+// @Failure 404 {object} response.BasicTemplate "없는 Key"
+// @Failure 421 {object} response.SlotRedirectTemplate "MOVED / ASK (slotOwner 헤더의 마스터가 담당 노드가 아닌 경우)"
+func HandleExpire(res http.ResponseWriter, req *http.Request) {
+
+	requestedExpire := models.ExpireRequestContainer{}
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&requestedExpire); err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	entry := storage.KeyValuePair{
+		Command: storage.PExpireAtCommand,
+		Key:     mux.Vars(req)["key"],
+		TTL:     requestedExpire.TTL,
+	}
+
+	handleWriteEntry(res, req, entry, requestedExpire.Durability, storage.PExpireAtCommand)
+}
+
+// @Summary Remove Expiration of Key (PERSIST)
+// @Accept json
+// @Produce json
+// @Router /hash/data/{key}/persist [post]
+// @Param key path string true "Target Key"
+// @Success 200 {object} response.BasicTemplate
+// @Failure 404 {object} response.BasicTemplate "없는 Key"
+// @Failure 421 {object} response.SlotRedirectTemplate "MOVED / ASK (slotOwner 헤더의 마스터가 담당 노드가 아닌 경우)"
+func HandlePersist(res http.ResponseWriter, req *http.Request) {
+
+	entry := storage.KeyValuePair{
+		Command: storage.PersistCommand,
+		Key:     mux.Vars(req)["key"],
+	}
+
+	handleWriteEntry(res, req, entry, "", storage.PersistCommand)
+}
+
+// @Summary Get Remaining Time to Live of Key (PTTL)
+// @Description ## 남은 만료 시간 (millisecond), -1 : 만료 시간 없음, -2 : Key 없음
+// @Accept json
+// @Produce json
+// @Router /hash/data/{key}/ttl [get]
+// @Param key path string true "Target Key"
+// @Success 200 {object} response.TTLResultTemplate
+// @Failure 500 {object} response.BasicTemplate "서버 오류"
+// @Failure 421 {object} response.SlotRedirectTemplate "MOVED / ASK (slotOwner 헤더의 마스터가 담당 노드가 아닌 경우)"
+func GetTTLFromKey(res http.ResponseWriter, req *http.Request) {
+
+	if err := waitForIndexTime(req); err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	key := mux.Vars(req)["key"]
+
+	if redirectIfMisrouted(res, req, key) {
+		return
+	}
+
+	ttlInMillisecond, redisClient, err := storage.GetTTL(key)
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseTemplate := response.TTLResultTemplate{
+		Key:         key,
+		TTL:         ttlInMillisecond,
+		NodeAdrress: redisClient.Address,
+	}
+
+	curMsg := fmt.Sprintf(
+		"TTL %s completed Success : Handled in Server(IP : %s)",
+		key,
+		configs.CurrentIP,
+	)
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		tools.ErrorLogger.Println(err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
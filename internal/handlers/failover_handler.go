@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"hash_interface/configs"
+	"hash_interface/internal/models/response"
+	"hash_interface/internal/storage"
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+
+	"github.com/gorilla/mux"
+)
+
+// @Summary Promote Slave to Master (Manual Failover)
+// @Description ## 그룹에 대한 요청을 멈추고 슬레이브가 마스터를 따라잡은 뒤 역할 교체, 기존 마스터는 새로운 마스터의 슬레이브가 된다
+// @Accept json
+// @Produce json
+// @Router /clients/{address}/failover [post]
+// @Param address path string true "Slave Address to Promote"
+// @Success 200 {object} response.RedisListTemplate
+// @Failure 400 {object} response.BasicTemplate "슬레이브가 아니거나 점검 중인 노드"
+// @Failure 500 {object} response.BasicTemplate "따라잡기 타임아웃 등 승격 실패"
+func HandleManualFailover(res http.ResponseWriter, req *http.Request) {
+
+	slaveAddress := mux.Vars(req)["address"]
+
+	if _, err := storage.GetSlaveClientWithAddress(slaveAddress); err != nil {
+		responseError(res, http.StatusBadRequest, fmt.Errorf(msg.NotASlave, slaveAddress))
+		return
+	}
+
+	if storage.IsInMaintenance(slaveAddress) {
+		responseError(res, http.StatusBadRequest, fmt.Errorf(msg.NodeInMaintenance, slaveAddress))
+		return
+	}
+
+	change := storage.TopologyChange{
+		Address: slaveAddress,
+	}
+
+	if _, err := dispatchTopologyChange(res, req, storage.TopologyPromoteCommand, change); err != nil {
+		tools.ErrorLogger.Printf("HandleManualFailover() : 슬레이브(%s) 승격 실패 - %s", slaveAddress, err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	curMsg := fmt.Sprintf(
+		"Slave(%s) promoted to master : Handled in Server(IP : %s)",
+		slaveAddress,
+		configs.CurrentIP,
+	)
+
+	responseWithRedisList(res, curMsg)
+}
+
+// @Summary Start Maintenance of Node
+// @Description ## 점검 중인 노드는 자동 Failover (승격 / 해쉬 슬롯 재분배) 와 재시작 대상에서 제외
+// @Accept json
+// @Produce json
+// @Router /clients/{address}/maintenance [post]
+// @Param address path string true "Node Address"
+// @Success 200 {object} response.RedisListTemplate
+// @Failure 404 {object} response.BasicTemplate "등록되지 않은 노드"
+func StartMaintenance(res http.ResponseWriter, req *http.Request) {
+
+	handleMaintenance(res, req, true)
+}
+
+// @Summary Finish Maintenance of Node
+// @Accept json
+// @Produce json
+// @Router /clients/{address}/maintenance [delete]
+// @Param address path string true "Node Address"
+// @Success 200 {object} response.RedisListTemplate
+// @Failure 404 {object} response.BasicTemplate "등록되지 않은 노드"
+func FinishMaintenance(res http.ResponseWriter, req *http.Request) {
+
+	handleMaintenance(res, req, false)
+}
+
+func handleMaintenance(res http.ResponseWriter, req *http.Request, isMaintenance bool) {
+
+	address := mux.Vars(req)["address"]
+
+	if err := storage.SetMaintenance(address, isMaintenance); err != nil {
+		responseError(res, http.StatusNotFound, err)
+		return
+	}
+
+	curMsg := fmt.Sprintf(
+		"Maintenance of node(%s) : %t - Handled in Server(IP : %s)",
+		address,
+		isMaintenance,
+		configs.CurrentIP,
+	)
+
+	responseWithRedisList(res, curMsg)
+}
+
+// responseWithRedisList : 현재 마스터 / 슬레이브 / 점검 중인 노드 목록 응답
+func responseWithRedisList(res http.ResponseWriter, curMsg string) {
+
+	responseTemplate := response.RedisListTemplate{
+		Masters:     storage.GetMasterClients(),
+		Slaves:      storage.GetSlaveClients(),
+		Maintenance: storage.GetMaintenanceNodes(),
+	}
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		tools.ErrorLogger.Println(err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
@@ -10,73 +10,39 @@ import (
 	"encoding/json"
 	"net/http"
 
-	"github.com/gomodule/redigo/redis"
 	"github.com/gorilla/mux"
 )
 
-// CheckRedisNodeStatus
+// CheckRedisNodeStatus : 감시 중인 노드(마스터 / 슬레이브)에 모니터 서버의 감시용 연결로 PING
 func CheckRedisNodeStatus(res http.ResponseWriter, req *http.Request) {
 
 	pathVars := mux.Vars(req)
 	targetRedisAddress := pathVars["redis_address"]
-	checkResult := storage.MonitorServerResponse{
-		RedisNodeAddress: targetRedisAddress,
-		ErrorMsg:         "",
-		IsAlive:          true,
-	}
 
-	redisClient, err := storage.GetMasterWithAddress(targetRedisAddress)
+	isAlive, err := storage.PingWatchedNode(targetRedisAddress, storage.ConnTimeoutDuration)
 	if err != nil {
-		checkResult.IsAlive = false
-		checkResult.ErrorMsg = err.Error()
-
 		tools.InfoLogger.Printf(
 			"CheckRedisNodeStatus() : 레디스 %s 없음",
 			targetRedisAddress,
 		)
 
-		monitorResponseError(res, err)
-		return
-
-	}
-
-	tools.InfoLogger.Printf(
-		"CheckRedisNodeStatus() : 레디스 %s 발견",
-		targetRedisAddress,
-	)
-
-	// 연결인 안되어있는 경우 1.
-	// 레디스 컨테이너가 죽었다 살아난 경우, 기존 Connection = nil
-	if redisClient.Connection == nil {
-
-		err = fmt.Errorf(
-			"레디스(%s) Ping test 실패, connection nil",
-			targetRedisAddress,
-		)
-
-		tools.ErrorLogger.Printf(err.Error())
-
 		monitorResponseError(res, err)
 		return
 	}
 
-	result, err := redis.String(redisClient.Connection.Do("PING"))
-
-	// 연결인 안되어있는 경우 2.
-	// 레디스 컨테이너 죽어있는 경우
-	if err != nil {
-		err = fmt.Errorf(
+	// 레디스가 죽어있는 경우, 다음 PING 에서 다시 연결
+	if !isAlive {
+		tools.ErrorLogger.Printf(
 			"레디스(%s) Ping test 실패",
 			targetRedisAddress,
 		)
-
-		tools.ErrorLogger.Printf(err.Error())
-
-		monitorResponseError(res, err)
-		return
 	}
 
-	tools.InfoLogger.Println("CheckRedisNodeStatus() Result : ", result)
+	checkResult := storage.MonitorServerResponse{
+		RedisNodeAddress: targetRedisAddress,
+		ErrorMsg:         "",
+		IsAlive:          isAlive,
+	}
 
 	responseWithCurrentRedisList(res, checkResult, "CheckRedisNodeStatus")
 }
@@ -86,14 +52,9 @@ func UnregisterRedis(res http.ResponseWriter, req *http.Request) {
 	pathVars := mux.Vars(req)
 	targetRedisAddress := pathVars["redis_address"]
 
-	targetRedisClient := storage.RedisClient{
-		Address: targetRedisAddress,
-		Role:    storage.MasterRole, // 모니터 서버는 모든 레디스를 마스터로 관리
-	}
+	tools.InfoLogger.Printf("UnregisterRedis() : 레디스(%s) 삭제 시작", targetRedisAddress)
 
-	tools.InfoLogger.Printf("UnregisterRedis() : 레다스(%s) 삭제 시작")
-
-	targetRedisClient.RemoveFromList()
+	storage.UnwatchNode(targetRedisAddress)
 
 	responseBody := storage.MonitorServerResponse{
 		RedisNodeAddress: targetRedisAddress,
@@ -113,17 +74,7 @@ func RegisterNewRedis(res http.ResponseWriter, req *http.Request) {
 		ErrorMsg:         "",
 	}
 
-	var err error
-
-	var newRedisClient storage.RedisClient
-
-	newRedisClient.Connection, err = redis.Dial(
-		"tcp",
-		targetRedisAddress,
-		redis.DialConnectTimeout(storage.ConnTimeoutDuration),
-	)
-
-	if err != nil {
+	if err := storage.WatchNode(targetRedisAddress); err != nil {
 		tools.ErrorLogger.Printf(
 			"registerNewRedis() : 새로운 레디스 노드 (%s) 추가 실패 - %s",
 			targetRedisAddress,
@@ -135,14 +86,10 @@ func RegisterNewRedis(res http.ResponseWriter, req *http.Request) {
 
 	}
 
-	// 연결 성공시
-	newRedisClient.Role = storage.MasterRole
-	newRedisClient.Address = targetRedisAddress
-	storage.AppendMaster(newRedisClient)
-
 	responseWithCurrentRedisList(res, responseBody, "RegisterNewRedis")
 }
 
+// ShowCurrentRedisList : 감시 중인 노드들의 PING 응답 시간, 마지막 응답 시각, 연속 실패 수
 func ShowCurrentRedisList(res http.ResponseWriter, req *http.Request) {
 
 	responseBody := storage.MonitorServerResponse{}
@@ -150,13 +97,11 @@ func ShowCurrentRedisList(res http.ResponseWriter, req *http.Request) {
 	responseWithCurrentRedisList(res, responseBody, "ShowCurrentRedisList")
 }
 
-// responseWithCurrentRedisList : 모니터 서버는 모든 레디스 노드들을 마스터 노드로 관리
+// responseWithCurrentRedisList : 모니터 서버는 마스터 / 슬레이브 구분 없이 감시 중인 노드 목록으로 응답
 //
 func responseWithCurrentRedisList(res http.ResponseWriter, checkResult storage.MonitorServerResponse, handleFuncName string) {
 
-	checkResult.Data = response.RedisListTemplate{
-		Masters: storage.GetMasterClients(),
-	}
+	checkResult.Data = storage.GetWatchedNodes()
 
 	responseBody, err := json.Marshal(checkResult)
 	if err != nil {
@@ -193,3 +138,182 @@ func monitorResponseError(res http.ResponseWriter, err error) {
 	res.WriteHeader(http.StatusInternalServerError)
 	fmt.Fprint(res, string(responseBody))
 }
+
+// HandleMonitorGossip : 다른 모니터 서버의 관찰 결과 수신
+func HandleMonitorGossip(res http.ResponseWriter, req *http.Request) {
+
+	gossip := storage.MonitorGossip{}
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&gossip); err != nil {
+		monitorResponseError(res, err)
+		return
+	}
+
+	if err := storage.ReceiveMonitorGossip(gossip); err != nil {
+		monitorResponseError(res, err)
+		return
+	}
+
+	responseBody, err := json.Marshal(storage.MonitorServerResponse{})
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
+
+// ShowMonitorStatus : 모니터 서버들이 합의한 모든 노드의 상태 (up / sdown / odown)
+func ShowMonitorStatus(res http.ResponseWriter, req *http.Request) {
+
+	statuses, err := storage.GetMonitorStatus()
+	if err != nil {
+		monitorResponseError(res, err)
+		return
+	}
+
+	responseBody, err := json.Marshal(storage.MonitorServerResponse{
+		IsAlive: true,
+		Data:    statuses,
+	})
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
+
+// @Summary Receive Node State Change from Monitor Server
+// @Description ## 모니터 서버들이 정족수 이상 죽었다고 판단(odown)하거나 다시 살아난(up) 노드 알림, odown 이면 바로 Failover
+// @Description ## 상태가 바뀌지 않아도 주기적으로 모든 노드 상태(sdown 포함)를 다시 알림, 오래된 상태는 생존 투표에 세지 않는다
+// @Accept json
+// @Produce json
+// @Router /monitor/events [post]
+// @Param event body storage.MonitorEvent true "Node State Change"
+// @Success 200 {object} response.BasicTemplate
+// @Failure 400 {object} response.BasicTemplate "지원하지 않는 상태"
+func HandleMonitorEvent(res http.ResponseWriter, req *http.Request) {
+
+	event := storage.MonitorEvent{}
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&event); err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := storage.ApplyMonitorEvent(event); err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	responseTemplate := response.BasicTemplate{}
+
+	curMsg := fmt.Sprintf(
+		"Node(%s) state %s from monitor(%s) applied : Handled in Server(IP : %s)",
+		event.Address,
+		event.State,
+		event.Monitor,
+		configs.CurrentIP,
+	)
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
+
+// @Summary Register Monitor Server
+// @Description ## 모니터 서버 등록, Raft 로그에 기록되어 모든 인터페이스 서버의 모니터 목록과 정족수가 같아진다
+// @Accept json
+// @Produce json
+// @Router /monitors/{monitor_address} [post]
+// @Param monitor_address path string true "Monitor Server Address (host:port)"
+// @Success 200 {object} response.MonitorListTemplate
+// @Failure 400 {object} response.BasicTemplate "잘못된 모니터 서버 주소"
+func HandleRegisterMonitor(res http.ResponseWriter, req *http.Request) {
+
+	handleMonitorRegistry(res, req, storage.MonitorRegisterCommand)
+}
+
+// @Summary Deregister Monitor Server
+// @Description ## 모니터 서버 해제 (모니터 서버 종료 시), 등록과 같이 Raft 로그에 기록
+// @Accept json
+// @Produce json
+// @Router /monitors/{monitor_address} [delete]
+// @Param monitor_address path string true "Monitor Server Address (host:port)"
+// @Success 200 {object} response.MonitorListTemplate
+// @Failure 400 {object} response.BasicTemplate "잘못된 모니터 서버 주소"
+func HandleDeregisterMonitor(res http.ResponseWriter, req *http.Request) {
+
+	handleMonitorRegistry(res, req, storage.MonitorDeregisterCommand)
+}
+
+// handleMonitorRegistry : 모니터 서버 등록 / 해제 엔트리를 WAL 에 기록, 반영 후 모니터 목록 응답
+func handleMonitorRegistry(res http.ResponseWriter, req *http.Request, command string) {
+
+	entry := storage.KeyValuePair{
+		Command: command,
+		Key:     mux.Vars(req)["monitor_address"],
+	}
+
+	if err := storage.ValidateEntry(entry); err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := dispatchEntry(res, req, entry, storage.DurabilityNone); err != nil {
+		responseDispatchError(res, err)
+		return
+	}
+
+	curMsg := fmt.Sprintf(
+		"%s %s completed Success : Handled in Server(IP : %s)",
+		command,
+		entry.Key,
+		configs.CurrentIP,
+	)
+
+	responseMonitorList(res, curMsg)
+}
+
+// @Summary Get Registered Monitor Servers
+// @Description ## 등록된 모니터 서버 목록과 생존 투표 수 (모니터 서버 + 호스트), 정족수
+// @Accept json
+// @Produce json
+// @Router /monitors [get]
+// @Success 200 {object} response.MonitorListTemplate
+func GetMonitorList(res http.ResponseWriter, req *http.Request) {
+
+	// Key 읽기가 아니므로 Index Time 을 기다리지 않는다
+	// (모니터 서버의 refreshPeers 는 Index Time 없이 조회, 등록 변경은 다음 조회 때 반영)
+	curMsg := fmt.Sprintf(
+		"Monitor list : Handled in Server(IP : %s)",
+		configs.CurrentIP,
+	)
+
+	responseMonitorList(res, curMsg)
+}
+
+func responseMonitorList(res http.ResponseWriter, curMsg string) {
+
+	responseTemplate := response.MonitorListTemplate{
+		MonitorRegistry: storage.GetMonitorRegistry(),
+	}
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		tools.ErrorLogger.Println(err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"hash_interface/configs"
+	"hash_interface/internal/models"
+	"hash_interface/internal/models/response"
+	"hash_interface/internal/storage"
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// @Summary Set Multiple Keys Atomically (MSET)
+// @Description ## 여러 (Key, Value) 를 하나의 WAL 엔트리, 하나의 트랜잭션으로 저장
+// @Description 모든 Key는 같은 해쉬 슬롯이어야 한다 (Hash Tag 사용 ex. {user}:1, {user}:2), 아니면 CROSSSLOT 에러
+// @Accept json
+// @Produce json
+// @Router /hash/mset [post]
+// @Param newSetData body models.DataRequestContainer true "(Key, Value) pairs in the same hash slot"
+// @Success 200 {object} response.BasicTemplate
+// @Failure 400 {object} response.BasicTemplate "CROSSSLOT / TRYAGAIN"
+// @Failure 504 {object} response.BasicTemplate "레플리카 확인 실패"
+// @Failure 421 {object} response.SlotRedirectTemplate "MOVED / ASK (slotOwner 헤더의 마스터가 담당 노드가 아닌 경우)"
+func HandleMSet(res http.ResponseWriter, req *http.Request) {
+
+	requestedData := models.DataRequestContainer{}
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&requestedData); err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	handleWriteEntry(
+		res,
+		req,
+		storage.NewMSetEntry(requestedData.Data),
+		requestedData.Durability,
+		storage.MSetCommand,
+	)
+}
+
+// @Summary Execute Commands in One Transaction (MULTI/EXEC)
+// @Description ## 같은 해쉬 슬롯의 SET / DEL 명령들을 하나의 WAL 엔트리, 하나의 Redis 트랜잭션으로 실행
+// @Description 다른 해쉬 슬롯의 Key가 섞여 있으면 CROSSSLOT 에러
+// @Accept json
+// @Produce json
+// @Router /hash/transaction [post]
+// @Param transaction body models.TransactionRequestContainer true "Commands in the same hash slot"
+// @Success 200 {object} response.BasicTemplate
+// @Failure 400 {object} response.BasicTemplate "CROSSSLOT / TRYAGAIN / 지원하지 않는 명령"
+// @Failure 504 {object} response.BasicTemplate "레플리카 확인 실패"
+// @Failure 421 {object} response.SlotRedirectTemplate "MOVED / ASK (slotOwner 헤더의 마스터가 담당 노드가 아닌 경우)"
+func HandleTransaction(res http.ResponseWriter, req *http.Request) {
+
+	requestedTransaction := models.TransactionRequestContainer{}
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&requestedTransaction); err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	entry := storage.KeyValuePair{
+		Command:  storage.ExecCommand,
+		Commands: requestedTransaction.Commands,
+	}
+
+	handleWriteEntry(
+		res,
+		req,
+		entry,
+		requestedTransaction.Durability,
+		storage.ExecCommand,
+	)
+}
+
+// @Summary Execute Commands across Masters (Two-Phase Commit)
+// @Description ## 여러 마스터(해쉬 슬롯)에 걸친 SET / DEL 명령들을 분산 트랜잭션(2PC)으로 실행
+// @Description 1) TXN_PREPARE : 모든 노드가 키를 잠그고 담당 마스터 확인
+// @Description    "condition" : "cas", "expected_version" 이 있는 명령은 Key 버전이 같은지 확인 (WATCH), 다르면 409
+// @Description 2) TXN_COMMIT / TXN_ABORT : 결정을 WAL 에 기록, 마스터 별 하나의 트랜잭션(MULTI/EXEC)으로 반영
+// @Description 코디네이터나 리더가 죽으면, 새 리더가 WAL 의 결정대로 마무리 (결정 없이 기한이 지나면 ABORT)
+// @Accept json
+// @Produce json
+// @Router /hash/transaction/distributed [post]
+// @Param transaction body models.TransactionRequestContainer true "Commands across hash slots"
+// @Success 200 {object} response.TransactionResultTemplate
+// @Failure 400 {object} response.BasicTemplate "PREPARE 실패 (TRYAGAIN / 지원하지 않는 명령)"
+// @Failure 409 {object} response.BasicTemplate "다른 분산 트랜잭션이 잠근 키 / 버전 불일치"
+// @Failure 500 {object} response.BasicTemplate "COMMIT 결정 후 반영 실패 (리더가 재시도)"
+// @Failure 504 {object} response.BasicTemplate "레플리카 확인 실패"
+func HandleDistributedTransaction(res http.ResponseWriter, req *http.Request) {
+
+	requestedTransaction := models.TransactionRequestContainer{}
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&requestedTransaction); err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	durability, err := storage.ParseDurability(requestedTransaction.Durability)
+	if err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	txID := fmt.Sprintf("%s-%d", configs.CurrentIP, time.Now().UnixNano())
+
+	prepareEntry := storage.NewPrepareEntry(txID, requestedTransaction.Commands, time.Now())
+
+	if err := storage.ValidateEntry(prepareEntry); err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	// 1단계 : PREPARE
+	err = dispatchEntry(res, req, prepareEntry, storage.DurabilityNone)
+	if err != nil {
+
+		// PREPARE 실패 시 ABORT, 기록에 실패해도 리더가 마무리
+		abortEntry := storage.KeyValuePair{
+			Command: storage.TxnAbortCommand,
+			TxID:    txID,
+		}
+		if abortErr := dispatchEntry(res, req, abortEntry, storage.DurabilityNone); abortErr != nil {
+			tools.ErrorLogger.Printf(
+				msg.TransactionRecoveryFail,
+				txID,
+				storage.TxnAbortCommand,
+				abortErr.Error(),
+			)
+		}
+
+		if storage.IsKeyLockedError(err) || storage.IsConditionFailedError(err) {
+			responseError(res, http.StatusConflict, err)
+			return
+		}
+
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	// 2단계 : COMMIT
+	commitEntry := storage.KeyValuePair{
+		Command: storage.TxnCommitCommand,
+		TxID:    txID,
+	}
+
+	err = dispatchEntry(res, req, commitEntry, durability)
+
+	// 모든 마스터에 반영되었지만, 요청한 수준의 레플리카 확인을 받지 못한 경우
+	if storage.IsReplicaQuorumError(err) {
+		responseError(res, http.StatusGatewayTimeout, err)
+		return
+	}
+
+	// COMMIT 결정은 기록되었으므로, 반영되지 못한 마스터는 리더가 재시도
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseTemplate := response.TransactionResultTemplate{
+		TxID:     txID,
+		Decision: storage.TxnCommitCommand,
+	}
+
+	curMsg := fmt.Sprintf(
+		"분산 트랜잭션(%s) COMMIT 완료 - 명령 %d개 : Handled in Server(IP : %s)",
+		txID,
+		len(requestedTransaction.Commands),
+		configs.CurrentIP,
+	)
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		tools.ErrorLogger.Println(err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
+
+// @Summary Get Values of Multiple Keys (MGET)
+// @Description ## 같은 해쉬 슬롯의 여러 Key 값을 한 번에 가져오기
+// @Description 다른 해쉬 슬롯의 Key가 섞여 있으면 CROSSSLOT 에러
+// @Accept json
+// @Produce json
+// @Router /hash/mget [get]
+// @Param key query []string true "Target Keys (ex. ?key={user}:1&key={user}:2)" collectionFormat(multi)
+// @Success 200 {object} response.MultiGetResultTemplate
+// @Failure 400 {object} response.BasicTemplate "CROSSSLOT / TRYAGAIN"
+// @Failure 500 {object} response.BasicTemplate "서버 오류"
+// @Failure 421 {object} response.SlotRedirectTemplate "MOVED / ASK (slotOwner 헤더의 마스터가 담당 노드가 아닌 경우)"
+func GetValuesFromKeys(res http.ResponseWriter, req *http.Request) {
+
+	if err := waitForIndexTime(req); err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	keys := req.URL.Query()["key"]
+
+	if len(keys) > 0 && redirectIfMisrouted(res, req, keys...) {
+		return
+	}
+
+	// Key들의 해쉬 슬롯을 담당하는 레디스 획득 (슬롯 이동 중이면 Key 위치에 따라)
+	redisClient, release, err := storage.GetRedisClientWithKeys(keys)
+	if storage.IsCrossSlotError(err) || storage.IsTryAgainError(err) || len(keys) == 0 {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	mgetArgs := make([]interface{}, len(keys))
+	for i, eachKey := range keys {
+		mgetArgs[i] = eachKey
+	}
+
+	// 레디스에 요청 명령 실행
+	values, err := redis.Values(redisClient.Connection.Do("MGET", mgetArgs...))
+	release()
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseTemplate := response.MultiGetResultTemplate{
+		Results:     make([]response.MultiGetResult, len(keys)),
+		NodeAdrress: redisClient.Address,
+	}
+
+	for i, eachKey := range keys {
+
+		value, err := redis.String(values[i], nil)
+		exists := err == nil
+		if err == redis.ErrNil {
+			value = "nil(없음)"
+
+		} else if err != nil {
+			responseError(res, http.StatusInternalServerError, err)
+			return
+		}
+
+		responseTemplate.Results[i] = response.MultiGetResult{
+			Key:    eachKey,
+			Value:  value,
+			Exists: exists,
+		}
+	}
+
+	curMsg := fmt.Sprintf(
+		"MGET %d keys completed Success : Handled in Server(IP : %s)",
+		len(keys),
+		configs.CurrentIP,
+	)
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		tools.ErrorLogger.Println(err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"hash_interface/configs"
+	"hash_interface/internal/models/response"
+	"hash_interface/internal/storage"
+	"hash_interface/tools"
+)
+
+// @Summary Get Replication Status
+// @Description ## 마스터-슬레이브 그룹 별 복제 현황 (INFO replication 의 마스터 / 슬레이브 offset, 복제 지연, 연결 상태)
+// @Description Native 복제 모드(REPLICATION_MODE=native)에서만 주기적으로 확인, Replay 복제 모드에서는 비어있다
+// @Accept json
+// @Produce json
+// @Router /replication [get]
+// @Success 200 {object} response.ReplicationStatusTemplate
+// @Failure 500 {object} response.BasicTemplate "서버 오류"
+func GetReplicationStatus(res http.ResponseWriter, req *http.Request) {
+
+	responseTemplate := response.ReplicationStatusTemplate{
+		Mode:        storage.GetReplicationMode(),
+		Replication: storage.GetReplicationStatus(),
+	}
+
+	curMsg := fmt.Sprintf(
+		"%d replication groups (mode : %s) : Handled in Server(IP : %s)",
+		len(responseTemplate.Replication),
+		responseTemplate.Mode,
+		configs.CurrentIP,
+	)
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		tools.ErrorLogger.Println(err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
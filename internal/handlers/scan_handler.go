@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"hash_interface/configs"
+	"hash_interface/internal/models/response"
+	"hash_interface/internal/storage"
+	"hash_interface/tools"
+)
+
+// @Summary Scan Keys across All Masters (SCAN)
+// @Description ## 모든 마스터의 Key 중 prefix 로 시작하는 Key 목록, cursor 로 이어서 조회
+// @Description 처음 요청은 cursor 생략 (또는 "0"), 응답의 cursor 가 "0" 이면 끝
+// @Description Redis SCAN 과 같이 같은 Key 가 여러 번 반환될 수 있다
+// @Accept json
+// @Produce json
+// @Router /hash/keys [get]
+// @Param prefix query string false "Key Prefix"
+// @Param cursor query string false "Cursor from previous response"
+// @Param count query int false "Number of keys per request (default 10, max 1000)"
+// @Success 200 {object} response.ScanResultTemplate
+// @Failure 400 {object} response.BasicTemplate "잘못되었거나 만료된 cursor"
+// @Failure 500 {object} response.BasicTemplate "서버 오류"
+func GetKeys(res http.ResponseWriter, req *http.Request) {
+
+	if err := waitForIndexTime(req); err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	query := req.URL.Query()
+	prefix := query.Get("prefix")
+	cursor := query.Get("cursor")
+
+	count := storage.DefaultScanCount
+	if countString := query.Get("count"); countString != "" {
+		parsedCount, err := strconv.Atoi(countString)
+		if err != nil {
+			responseError(res, http.StatusBadRequest, err)
+			return
+		}
+		count = parsedCount
+	}
+
+	keys, nextCursor, err := storage.ScanKeys(prefix, cursor, count)
+	if storage.IsScanCursorError(err) {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	if err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseTemplate := response.ScanResultTemplate{
+		Keys:   keys,
+		Cursor: nextCursor,
+	}
+
+	curMsg := fmt.Sprintf(
+		"SCAN %d keys (prefix : %s) completed Success : Handled in Server(IP : %s)",
+		len(keys),
+		prefix,
+		configs.CurrentIP,
+	)
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		tools.ErrorLogger.Println(err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
@@ -0,0 +1,293 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"hash_interface/configs"
+	"hash_interface/internal/models"
+	"hash_interface/internal/models/response"
+	"hash_interface/internal/storage"
+	"hash_interface/tools"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// SlotOwnerHeader : 클라이언트가 캐시한 슬롯 구성 기준, 요청한 키를 담당한다고 알고 있는 마스터 주소
+	// 헤더가 있으면 담당 노드가 다를 때 421 (MOVED / ASK) 로 응답, 없으면 기존처럼 담당 노드로 대신 처리
+	//
+	SlotOwnerHeader = "slotOwner"
+
+	// SlotEpochHeader : 응답 시점의 해쉬 슬롯 구성 버전, 캐시한 버전과 다르면 GET /slots 로 다시 받는다
+	SlotEpochHeader = "slotEpoch"
+)
+
+// @Summary Get Hash Slot Map
+// @Description ## 해쉬 슬롯 범위 별 담당 마스터/레플리카 (CLUSTER SLOTS) 와 노드 별 담당 범위 (CLUSTER NODES)
+// @Description 담당 마스터가 바뀔 때마다 증가하는 구성 버전(epoch) 포함, 클라이언트 캐시용
+// @Accept json
+// @Produce json
+// @Router /slots [get]
+// @Success 200 {object} response.SlotMapTemplate
+func GetSlotMap(res http.ResponseWriter, req *http.Request) {
+
+	// 버전을 먼저 읽는다, 그 사이 구성이 바뀌면 클라이언트는 다음 응답의 버전을 보고 다시 받는다
+	epoch := storage.GetSlotMapEpoch()
+
+	responseTemplate := response.SlotMapTemplate{
+		Epoch:  epoch,
+		Ranges: storage.GetSlotRanges(),
+		Nodes:  storage.GetNodeSlots(),
+	}
+
+	curMsg := fmt.Sprintf(
+		"현재 해쉬 슬롯 현황 (범위 : %d개, 버전 : %d)",
+		len(responseTemplate.Ranges),
+		epoch,
+	)
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		tools.ErrorLogger.Println(err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	setSlotEpochHeader(res, epoch)
+	responseOK(res, responseBody)
+}
+
+// @Summary Get Hash Slot of Key
+// @Description ## Key 의 해쉬 슬롯과 담당 마스터/레플리카, 슬롯 이동 중이면 이동 목표 마스터
+// @Accept json
+// @Produce json
+// @Router /slots/key/{key} [get]
+// @Param key path string true "Target Key"
+// @Success 200 {object} response.KeySlotTemplate
+func GetKeySlot(res http.ResponseWriter, req *http.Request) {
+
+	key := mux.Vars(req)["key"]
+
+	responseTemplate := response.KeySlotTemplate{
+		KeySlot: storage.GetKeySlot(key),
+	}
+
+	curMsg := fmt.Sprintf(
+		"Key(%s)의 해쉬 슬롯 : %d",
+		key,
+		responseTemplate.Slot,
+	)
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		tools.ErrorLogger.Println(err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
+
+// @Summary Get Slot Rebalance Plan (Dry-run)
+// @Description ## 모든 해쉬 슬롯(16384)을 마스터 가중치에 비례하도록 최소 이동으로 재분배하는 계획
+// @Description 가중치 생략 시 모든 마스터 1, 예) ?weights=172.17.0.2:8000=2,172.17.0.3:8001=1
+// @Accept json
+// @Produce json
+// @Router /slots/rebalance/plan [get]
+// @Param weights query string false "마스터 주소=가중치 목록 (콤마 구분)"
+// @Success 200 {object} response.RebalancePlanTemplate
+// @Failure 400 {object} response.BasicTemplate "가중치 오류"
+func GetRebalancePlan(res http.ResponseWriter, req *http.Request) {
+
+	weights, err := parseWeights(req.URL.Query().Get("weights"))
+	if err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	plan, err := storage.PlanRebalance(weights)
+	if err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	responseTemplate := response.RebalancePlanTemplate{
+		Plan: plan,
+	}
+
+	curMsg := fmt.Sprintf(
+		"해쉬 슬롯 재분배 계획 (이동 슬롯 : %d, 할당 슬롯 : %d)",
+		plan.MovedSlots,
+		plan.AssignedSlots,
+	)
+	nextMsg := "Apply the plan with POST"
+	nextLink := configs.HTTP + configs.BaseURL + "/slots/rebalance"
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		tools.ErrorLogger.Println(err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
+
+// @Summary Apply Slot Rebalance Plan
+// @Description ## 재분배 계획을 세운 뒤, 슬롯 단위 Live Migration 으로 적용
+// @Description 이동 중인 슬롯의 요청은 키 위치에 따라 기존/새로운 마스터가 처리
+// @Accept json
+// @Produce json
+// @Router /slots/rebalance [post]
+// @Param weights body models.RebalanceRequestContainer false "마스터 주소 -> 가중치"
+// @Success 200 {object} response.RebalancePlanTemplate
+// @Failure 400 {object} response.BasicTemplate "가중치 오류"
+// @Failure 500 {object} response.BasicTemplate "서버 오류"
+func ApplyRebalance(res http.ResponseWriter, req *http.Request) {
+
+	var rebalanceRequest models.RebalanceRequestContainer
+
+	// 바디 생략 시 모든 마스터 가중치 1
+	if req.ContentLength != 0 {
+		decoder := json.NewDecoder(req.Body)
+		if err := decoder.Decode(&rebalanceRequest); err != nil {
+			responseError(res, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	// 가중치 오류는 이동 전에 확인
+	if _, err := storage.PlanRebalance(rebalanceRequest.Weights); err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	change := storage.TopologyChange{
+		Weights: rebalanceRequest.Weights,
+	}
+
+	// 모든 인터페이스 서버가 같은 계획으로 재분배하도록 WAL 에 기록
+	applyResult, err := dispatchTopologyChange(res, req, storage.TopologyRebalanceCommand, change)
+	if err != nil {
+		tools.ErrorLogger.Printf("ApplyRebalance() : 재분배 에러 - %s", err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	plan := storage.RebalancePlan{}
+	if err := json.Unmarshal([]byte(applyResult), &plan); err != nil {
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseTemplate := response.RebalancePlanTemplate{
+		Plan: plan,
+	}
+
+	curMsg := fmt.Sprintf(
+		"해쉬 슬롯 재분배 완료 (이동 슬롯 : %d, 할당 슬롯 : %d)",
+		plan.MovedSlots,
+		plan.AssignedSlots,
+	)
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(curMsg, nextMsg, nextLink)
+	if err != nil {
+		tools.ErrorLogger.Println(err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+}
+
+// redirectIfMisrouted : 클라이언트가 알려준 담당 마스터(SlotOwnerHeader)가 @keys 의 실제 담당 노드와 다르면
+// 421 (MOVED / ASK) 로 응답하고 true 반환, 응답 헤더에 현재 해쉬 슬롯 구성 버전 설정
+//
+func redirectIfMisrouted(res http.ResponseWriter, req *http.Request, keys ...string) bool {
+
+	setSlotEpochHeader(res, storage.GetSlotMapEpoch())
+
+	expectedAddress := req.Header.Get(SlotOwnerHeader)
+	if expectedAddress == "" {
+		return false
+	}
+
+	redirect, isRedirected, err := storage.CheckSlotOwner(keys, expectedAddress)
+	if err != nil {
+		if storage.IsCrossSlotError(err) || storage.IsTryAgainError(err) {
+			responseError(res, http.StatusBadRequest, err)
+		} else {
+			responseError(res, http.StatusInternalServerError, err)
+		}
+		return true
+	}
+
+	if isRedirected == false {
+		return false
+	}
+
+	responseRedirect(res, redirect)
+	return true
+}
+
+func responseRedirect(res http.ResponseWriter, redirect storage.SlotRedirect) {
+
+	responseTemplate := response.SlotRedirectTemplate{
+		Redirect: redirect,
+	}
+
+	nextMsg := "Refresh the slot map"
+	nextLink := configs.HTTP + configs.BaseURL + "/slots"
+
+	responseBody, err := responseTemplate.Marshal(redirect.String(), nextMsg, nextLink)
+	if err != nil {
+		tools.ErrorLogger.Println(err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	setSlotEpochHeader(res, redirect.Epoch)
+	res.Header().Set(configs.ContentType, configs.JsonContent)
+	res.WriteHeader(http.StatusMisdirectedRequest)
+	fmt.Fprint(res, string(responseBody))
+}
+
+func setSlotEpochHeader(res http.ResponseWriter, epoch uint64) {
+	res.Header().Set(SlotEpochHeader, strconv.FormatUint(epoch, 10))
+}
+
+// parseWeights : "주소=가중치,주소=가중치" 형식의 쿼리 파싱
+func parseWeights(query string) (map[string]float64, error) {
+
+	weights := make(map[string]float64)
+	if query == "" {
+		return weights, nil
+	}
+
+	for _, eachPair := range strings.Split(query, ",") {
+
+		separatorIdx := strings.LastIndex(eachPair, "=")
+		if separatorIdx < 0 {
+			return nil, fmt.Errorf("가중치 형식 오류(%s) - 주소=가중치", eachPair)
+		}
+
+		weight, err := strconv.ParseFloat(eachPair[separatorIdx+1:], 64)
+		if err != nil {
+			return nil, fmt.Errorf("가중치 형식 오류(%s) - %s", eachPair, err.Error())
+		}
+
+		weights[eachPair[:separatorIdx]] = weight
+	}
+
+	return weights, nil
+}
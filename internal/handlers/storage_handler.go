@@ -5,19 +5,34 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"hash_interface/configs"
 	"hash_interface/internal/cluster"
-	"hash_interface/internal/hash"
 	"hash_interface/internal/models"
 	"hash_interface/internal/models/response"
 	"hash_interface/internal/storage"
+	msg "hash_interface/internal/storage/message"
 	"hash_interface/tools"
 
 	"github.com/gomodule/redigo/redis"
 	"github.com/gorilla/mux"
 )
 
+// @Summary Set Key Value (Conditional Write)
+// @Description ## (Key, Value) 저장, 여러 개면 Key 마다 따로 처리 (원자적으로 저장하려면 /hash/mset)
+// @Description 조건부 쓰기 : "condition" 필드 - "nx"(없을 때만) / "xx"(있을 때만) / "cas"("expected_value", "expected_version" 과 같을 때만)
+// @Description 조건은 리더의 커밋 순서대로 반영될 때 확인되며, 맞지 않으면 409
+// @Description 만료 시간 : "ttl" 필드 (millisecond), 생략 시 만료 없음
+// @Accept json
+// @Produce json
+// @Router /hash/data [post]
+// @Param newSetData body models.DataRequestContainer true "(Key, Value) pairs"
+// @Success 200 {object} response.BasicTemplate
+// @Failure 400 {object} response.BasicTemplate "잘못된 요청"
+// @Failure 409 {object} response.BasicTemplate "조건 불일치 / 분산 트랜잭션이 잠근 키"
+// @Failure 504 {object} response.BasicTemplate "레플리카 확인 실패"
+// @Failure 421 {object} response.SlotRedirectTemplate "MOVED / ASK (slotOwner 헤더의 마스터가 담당 노드가 아닌 경우)"
 func HandleUpdateKeyValue(res http.ResponseWriter, req *http.Request) {
 
 	stateNode := cluster.StateNode
@@ -42,8 +57,6 @@ func HandleUpdateKeyValue(res http.ResponseWriter, req *http.Request) {
 
 	// 1. 요청이 리더로부터 왔는지 유저에게 왔는지 확인
 
-	eventDispatcher := cluster.EventDispatcher
-
 	requestedData := models.DataRequestContainer{}
 	decoder := json.NewDecoder(req.Body)
 	err := decoder.Decode(&requestedData)
@@ -52,13 +65,188 @@ func HandleUpdateKeyValue(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	keyValuePair := requestedData.Data[0]
+	if len(requestedData.Data) == 0 {
+		responseError(res, http.StatusBadRequest, fmt.Errorf(msg.EmptyKeys))
+		return
+	}
+
+	// 여러 (key, value) 는 Key 마다 하나의 SET 엔트리로 처리 (슬롯이 달라도 된다)
+	handleWriteEntries(res, req, requestedData.Data, requestedData.Durability, "SET")
+}
+
+// handleWriteEntry : @entry 를 하나의 WAL 엔트리로 클러스터에 전달하고, 커밋 결과를 응답
+//  - 실행할 수 없는 엔트리(CROSSSLOT 등)는 WAL 에 기록하기 전 거절
+//
+func handleWriteEntry(
+	res http.ResponseWriter,
+	req *http.Request,
+	entry storage.KeyValuePair,
+	requestedDurability, commandName string,
+) {
+
+	handleWriteEntries(res, req, []storage.KeyValuePair{entry}, requestedDurability, commandName)
+}
+
+// handleWriteEntries : @entries 를 각각의 WAL 엔트리로 순서대로 클러스터에 전달하고, 커밋 결과를 응답
+//  - 하나라도 실행할 수 없거나 다른 노드가 담당하면 어떤 엔트리도 WAL 에 기록하기 전 거절
+//  - 커밋 도중 실패하면 이미 커밋된 엔트리는 그대로 남는다 (원자적 처리는 MSET / EXEC)
+//
+func handleWriteEntries(
+	res http.ResponseWriter,
+	req *http.Request,
+	entries []storage.KeyValuePair,
+	requestedDurability, commandName string,
+) {
+
+	durability, err := storage.ParseDurability(requestedDurability)
+	if err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
+	for _, eachEntry := range entries {
+		if err := storage.ValidateEntry(eachEntry); err != nil {
+			responseError(res, http.StatusBadRequest, err)
+			return
+		}
+
+		if redirectIfMisrouted(res, req, storage.EntryKeys(eachEntry)...) {
+			return
+		}
+	}
+
+	for _, eachEntry := range entries {
+
+		// TTL 은 만료 시각으로 바꿔 WAL 에 기록
+		eachEntry = storage.StampExpiry(eachEntry, time.Now())
+
+		err = dispatchEntry(res, req, eachEntry, durability)
+		if err != nil {
+			responseDispatchError(res, err)
+			return
+		}
+	}
+
+	responseTemplate := response.BasicTemplate{}
+	curMsg := fmt.Sprintf(
+		"%s completed Success : Handled in Server(IP : %s)",
+		commandName,
+		configs.CurrentIP,
+	)
+	nextMsg := "Main URL"
+	nextLink := configs.HTTP + configs.BaseURL
+
+	responseBody, err := responseTemplate.Marshal(
+		curMsg,
+		nextMsg,
+		nextLink,
+	)
+	if err != nil {
+		tools.ErrorLogger.Println(err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	responseOK(res, responseBody)
+
+}
+
+// responseDispatchError : dispatchEntry 에러를 종류에 맞는 상태 코드로 응답
+func responseDispatchError(res http.ResponseWriter, err error) {
+
+	// 커밋은 되었지만, 요청한 수준의 레플리카 확인을 받지 못한 경우
+	if storage.IsReplicaQuorumError(err) {
+		responseError(res, http.StatusGatewayTimeout, err)
+		return
+	}
+
+	// 분산 트랜잭션이 잠근 키, 조건부 쓰기의 조건 불일치, 반영할 수 없는 구성 변경
+	if storage.IsKeyLockedError(err) || storage.IsConditionFailedError(err) || storage.IsTopologyRejectedError(err) {
+		responseError(res, http.StatusConflict, err)
+		return
+	}
+
+	if storage.IsNoSuchKeyError(err) {
+		responseError(res, http.StatusNotFound, err)
+		return
+	}
+
+	responseError(res, http.StatusBadRequest, err)
+}
+
+// dispatchEntry : @entry 를 리더(자신 또는 다른 노드)의 WAL 에 기록, 커밋될 때까지 대기
+// 성공 시 응답 헤더에 현재 Index Time 설정
+//
+func dispatchEntry(
+	res http.ResponseWriter,
+	req *http.Request,
+	entry storage.KeyValuePair,
+	durability storage.Durability,
+) error {
+
+	_, err := dispatchEntryWithResult(res, req, entry, durability)
+
+	return err
+}
+
+// dispatchEntryWithResult : dispatchEntry 와 같지만, 반영 결과(ex. INCRBY 의 새로운 값)도 반환
+// 반영 결과는 storage.RequiresApplyResult 인 엔트리만 채워진다
+//
+func dispatchEntryWithResult(
+	res http.ResponseWriter,
+	req *http.Request,
+	entry storage.KeyValuePair,
+	durability storage.Durability,
+) (string, error) {
+
+	applyResult, err := commitEntry(entry, extractMetaData(req), durability)
+	if err != nil && !storage.IsReplicaQuorumError(err) {
+		return "", err
+	}
+
+	indexTime := fmt.Sprintf(
+		"%d",
+		cluster.StateNode.GetIndexTime(true),
+	)
+
+	res.Header().Set(
+		cluster.IndexTimeHeader,
+		indexTime,
+	)
+
+	return applyResult, err
+}
+
+// DispatchTopologyEntry : HTTP 요청 없이 (자동 Failover) 구성 변경 엔트리를 WAL 에 기록, 반영 결과 반환
+// storage.SetTopologyDispatcher 로 설정
+//
+func DispatchTopologyEntry(entry storage.KeyValuePair) (string, error) {
+
+	if err := storage.ValidateEntry(entry); err != nil {
+		return "", err
+	}
+
+	return commitEntry(entry, make(map[string]interface{}), storage.DurabilityNone)
+}
+
+// commitEntry : @entry 를 리더(자신 또는 다른 노드)의 WAL 에 기록, 커밋되어 반영될 때까지 대기
+func commitEntry(
+	entry storage.KeyValuePair,
+	metaDataMap map[string]interface{},
+	durability storage.Durability,
+) (string, error) {
+
+	stateNode := cluster.StateNode
+	eventDispatcher := cluster.EventDispatcher
 
 	// interruptChannel은 커널이 인터럽트를 발생하여 IO가 끝난 것을 알려주듯
 	// State Machine의 로직이 끝나는 것을 알림받는 채널
 	//
 	interruptChannel := make(chan error)
-	metaDataMap := extractMetaData(req)
+	metaDataMap[cluster.DurabilityField] = string(durability)
+
+	var applyResult string
+	metaDataMap[cluster.ApplyResultField] = &applyResult
 
 	// 리더가 없는 경우 리더가 생길 때 까지 Busy Waiting
 	// 1. Cold start : 제일 처음 시작했을 때, Follower로 등록되어있을 떄 요청이 온 경우
@@ -74,7 +262,7 @@ func HandleUpdateKeyValue(res http.ResponseWriter, req *http.Request) {
 	if stateNode.IsMyselfLeader() {
 
 		eventDispatcher.DispatchAppendWal(
-			keyValuePair,
+			entry,
 			metaDataMap,
 			&interruptChannel,
 		)
@@ -82,51 +270,40 @@ func HandleUpdateKeyValue(res http.ResponseWriter, req *http.Request) {
 	} else {
 
 		eventDispatcher.DispatchPassToLeader(
-			keyValuePair.Key,
-			keyValuePair.Value,
+			entry,
+			metaDataMap,
 			&interruptChannel,
 		)
 
 	}
 
-	err = <-interruptChannel
+	err := <-interruptChannel
 
-	if err != nil {
-		responseError(res, http.StatusBadRequest, err)
-		return
-	}
-
-	indexTime := fmt.Sprintf(
-		"%d",
-		stateNode.GetIndexTime(true),
-	)
+	return applyResult, err
+}
 
-	res.Header().Set(
-		cluster.IndexTimeHeader,
-		indexTime,
-	)
+// dispatchTopologyChange : 클러스터 구성 변경 엔트리를 WAL 에 기록, 모든 인터페이스 서버에 반영된 결과 반환
+// 변경 ID 는 요청받은 서버 주소와 시각 (재시작 후 같은 변경을 두 번 반영하지 않도록)
+//
+func dispatchTopologyChange(
+	res http.ResponseWriter,
+	req *http.Request,
+	command string,
+	change storage.TopologyChange,
+) (string, error) {
 
-	responseTemplate := response.BasicTemplate{}
-	curMsg := fmt.Sprintf(
-		"SET completed Success : Handled in Server(IP : %s)",
-		configs.CurrentIP,
-	)
-	nextMsg := "Main URL"
-	nextLink := configs.HTTP + configs.BaseURL
+	changeID := fmt.Sprintf("%s-%d", configs.CurrentIP, time.Now().UnixNano())
 
-	responseBody, err := responseTemplate.Marshal(
-		curMsg,
-		nextMsg,
-		nextLink,
-	)
+	entry, err := storage.NewTopologyEntry(command, changeID, change)
 	if err != nil {
-		tools.ErrorLogger.Println(err.Error())
-		responseError(res, http.StatusInternalServerError, err)
-		return
+		return "", err
 	}
 
-	responseOK(res, responseBody)
+	if err := storage.ValidateEntry(entry); err != nil {
+		return "", err
+	}
 
+	return dispatchEntryWithResult(res, req, entry, storage.DurabilityNone)
 }
 
 func extractMetaData(req *http.Request) map[string]interface{} {
@@ -208,58 +385,62 @@ func SetKeyValue(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	durability, err := storage.ParseDurability(DataRequestContainer.Durability)
+	if err != nil {
+		responseError(res, http.StatusBadRequest, err)
+		return
+	}
+
 	var responseTemplate response.SetResultTemplate
 	responseTemplate.Results = make(
 		[]response.RedisResult,
 		len(DataRequestContainer.Data),
 	)
 
-	// 각 Set 요청 Key, Value
-	for i, eachKeyValue := range DataRequestContainer.Data {
-
-		key := eachKeyValue.Key
-		value := eachKeyValue.Value
-		hashSlotIndex := hash.GetHashSlotIndex(key)
+	// 각 엔트리 (SET / MSET / EXEC)
+	for i, eachEntry := range DataRequestContainer.Data {
 
 		tools.InfoLogger.Printf(
-			"SET Key : %s, Value : %s - 해쉬 슬롯 : %d",
-			key,
-			value,
-			hashSlotIndex,
+			"%s Key : %s, Value : %s - 묶인 명령 : %d개",
+			storage.EntryCommand(eachEntry),
+			eachEntry.Key,
+			eachEntry.Value,
+			len(eachEntry.Commands),
 		)
 
-		// Key의 해쉬 슬롯을 담당하는 레디스 획득
-		// Get Redis Node which handles this hash slot
-		redisClient, err := storage.GetRedisClient(hashSlotIndex)
-		if err != nil {
-			responseError(res, http.StatusInternalServerError, err)
+		// 엔트리의 해쉬 슬롯을 담당하는 레디스에서 실행 (MSET / EXEC 는 하나의 트랜잭션)
+		redisClient, result, err := storage.ExecuteEntry(eachEntry, durability)
+
+		// 슬레이브 전파 시 요청한 수준의 레플리카 확인을 받지 못한 경우
+		if storage.IsReplicaQuorumError(err) {
+			responseError(res, http.StatusGatewayTimeout, err)
 			return
 		}
 
-		// 레디스에 요청 명령 실행
-		_, err = redis.String(redisClient.Connection.Do("SET", key, value))
-		if err != nil {
-			responseError(res, http.StatusInternalServerError, err)
+		if storage.IsCrossSlotError(err) || storage.IsTryAgainError(err) || storage.IsWrongTypeError(err) {
+			responseError(res, http.StatusBadRequest, err)
+			return
+		}
+
+		// 분산 트랜잭션이 잠근 키, 조건부 쓰기의 조건 불일치, 반영할 수 없는 구성 변경
+		// (4xx 는 모든 서버에서 같은 결과이므로 Follower 는 반영된 엔트리로 보고 넘어간다)
+		if storage.IsKeyLockedError(err) || storage.IsConditionFailedError(err) || storage.IsTopologyRejectedError(err) {
+			responseError(res, http.StatusConflict, err)
+			return
+		}
+
+		if storage.IsNoSuchKeyError(err) {
+			responseError(res, http.StatusNotFound, err)
 			return
 		}
 
-		// 변경사항 데이터 로그 기록
-		err = redisClient.RecordModificationLog("SET", key, value)
 		if err != nil {
 			responseError(res, http.StatusInternalServerError, err)
 			return
 		}
 
-		// 슬레이브에게 전파
-		redisClient.ReplicateToSlave("SET", key, value)
-
 		responseTemplate.Results[i].NodeAdrress = redisClient.Address
-		responseTemplate.Results[i].Result = fmt.Sprintf(
-			"%s %s %s",
-			"SET",
-			key,
-			value,
-		)
+		responseTemplate.Results[i].Result = result
 	}
 
 	curMsg := fmt.Sprintf(
@@ -290,6 +471,7 @@ func SetKeyValue(res http.ResponseWriter, req *http.Request) {
 // @Param key path string true "Target Key"
 // @Success 200 {object} response.GetResultTemplate
 // @Failure 500 {object} response.BasicTemplate "서버 오류"
+// @Failure 421 {object} response.SlotRedirectTemplate "MOVED / ASK (slotOwner 헤더의 마스터가 담당 노드가 아닌 경우)"
 func GetValueFromKey(res http.ResponseWriter, req *http.Request) {
 
 	// To check if load balancing(Round-robin) works
@@ -298,41 +480,20 @@ func GetValueFromKey(res http.ResponseWriter, req *http.Request) {
 	// 	configs.CurrentIP,
 	// )
 
-	reqIdxTimeString := req.Header.Get(cluster.IndexTimeHeader)
-	reqIdxTime, err := strconv.ParseUint(
-		reqIdxTimeString,
-		10,
-		64,
-	)
-
-	if err != nil {
-		err = fmt.Errorf(
-			"Request Header에 Index Time을 올바르게 설정해주세요",
-		)
+	if err := waitForIndexTime(req); err != nil {
 		responseError(res, http.StatusBadRequest, err)
 		return
 	}
 
-	stateNode := cluster.StateNode
-
-	// !!밸런서가 리더에게 알맞은 Index Time을 알아서 물어보고 요청을 날린다고 가정!!!
-
-	// 요청의 Index Time은 잘못된 것이 아니므로,
-	// 현재 IndexTime이 뒤쳐져 있을 경우
-	// (업데이트를 뒤에서 - heartbeat로 할테니깐)
-	// Busy Waiting을 하자
-	for reqIdxTime > stateNode.GetIndexTime(true) {
-	}
-
-	// Commit Index와 Index Time이 별개이기 때문에
-	// 원래는 Commit Index와 Request Index Tim이 같아질때까지 기다려야한다!!
-
 	params := mux.Vars(req)
 	key := params["key"]
-	hashSlotIndex := hash.GetHashSlotIndex(key)
 
-	// Key의 해쉬 슬롯을 담당하는 레디스 획득
-	redisClient, err := storage.GetRedisClient(hashSlotIndex)
+	if redirectIfMisrouted(res, req, key) {
+		return
+	}
+
+	// Key의 해쉬 슬롯을 담당하는 레디스 획득 (슬롯 이동 중이면 Key 위치에 따라)
+	redisClient, release, err := storage.GetRedisClientWithKey(key)
 	if err != nil {
 		responseError(res, http.StatusInternalServerError, err)
 		return
@@ -340,9 +501,17 @@ func GetValueFromKey(res http.ResponseWriter, req *http.Request) {
 
 	// 레디스에 요청 명령 실행
 	redisResponse, err := redis.String(redisClient.Connection.Do("GET", key))
+	release()
+	exists := err == nil
 	if err == redis.ErrNil {
 		redisResponse = "nil(없음)"
 
+	} else if storage.IsWrongTypeError(err) {
+		// Hash / List / Set Key 는 자료형 별 URI 로 조회
+		keyType, _ := storage.GetKeyType(key)
+		responseError(res, http.StatusBadRequest, fmt.Errorf(msg.NotStringValue, key, keyType))
+		return
+
 	} else if err != nil {
 		responseError(res, http.StatusInternalServerError, err)
 		return
@@ -359,6 +528,8 @@ func GetValueFromKey(res http.ResponseWriter, req *http.Request) {
 	responseTemplate := response.GetResultTemplate{}
 	responseTemplate.Result = redisResponse
 	responseTemplate.NodeAdrress = redisClient.Address
+	responseTemplate.Version = storage.GetKeyVersion(key)
+	responseTemplate.Exists = exists
 
 	responseBody, err := responseTemplate.Marshal(
 		redisResponse,
@@ -373,9 +544,43 @@ func GetValueFromKey(res http.ResponseWriter, req *http.Request) {
 	responseOK(res, responseBody)
 }
 
+// waitForIndexTime : 요청 헤더의 Index Time 까지 현재 노드가 따라잡을 때까지 대기
+func waitForIndexTime(req *http.Request) error {
+
+	reqIdxTimeString := req.Header.Get(cluster.IndexTimeHeader)
+	reqIdxTime, err := strconv.ParseUint(
+		reqIdxTimeString,
+		10,
+		64,
+	)
+
+	if err != nil {
+		return fmt.Errorf(
+			"Request Header에 Index Time을 올바르게 설정해주세요",
+		)
+	}
+
+	stateNode := cluster.StateNode
+
+	// !!밸런서가 리더에게 알맞은 Index Time을 알아서 물어보고 요청을 날린다고 가정!!!
+
+	// 요청의 Index Time은 잘못된 것이 아니므로,
+	// 현재 IndexTime이 뒤쳐져 있을 경우
+	// (업데이트를 뒤에서 - heartbeat로 할테니깐)
+	// Busy Waiting을 하자
+	for reqIdxTime > stateNode.GetIndexTime(true) {
+	}
+
+	// Commit Index와 Index Time이 별개이기 때문에
+	// 원래는 Commit Index와 Request Index Tim이 같아질때까지 기다려야한다!!
+
+	return nil
+}
+
 // @Summary Add New Master/Slave Redis Clients
 // @Description **Slave 추가 시,** 반드시 요청 바디에 **"master_address" 필드에 타겟 노드 주소 설정**
 // @Description Master, Slave 운용하고 싶지 않은 경우, 모두 Master로 등록
+// @Description 노드 추가는 Raft 로그에 기록되어 모든 인터페이스 서버에 반영된 뒤 응답
 // @Accept json
 // @Produce json
 // @Router /clients [post]
@@ -402,13 +607,13 @@ func AddNewStorage(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	change := storage.TopologyChange{
+		Address: newClientRequest.Address,
+	}
+	command := storage.TopologyAddMasterCommand
+
 	switch newClientRequest.Role {
 	case storage.MasterRole:
-		err := storage.AddNewMaster(newClientRequest.Address)
-		if err != nil {
-			responseError(res, http.StatusInternalServerError, err)
-			return
-		}
 
 	case storage.SlaveRole:
 
@@ -423,8 +628,7 @@ func AddNewStorage(res http.ResponseWriter, req *http.Request) {
 			return
 		}
 
-		targetMaster, err := storage.GetMasterWithAddress(newClientRequest.MasterAddress)
-		if err != nil {
+		if _, err := storage.GetMasterWithAddress(newClientRequest.MasterAddress); err != nil {
 			tools.ErrorLogger.Printf(
 				"AddNewStorage() : 슬레이브 추가 에러 - %s",
 				err.Error(),
@@ -433,15 +637,8 @@ func AddNewStorage(res http.ResponseWriter, req *http.Request) {
 			return
 		}
 
-		err = storage.AddNewSlave(newClientRequest.Address, *targetMaster)
-		if err != nil {
-			tools.ErrorLogger.Printf(
-				"AddNewStorage() : 슬레이브 추가 에러 - %s",
-				err.Error(),
-			)
-			responseError(res, http.StatusInternalServerError, err)
-			return
-		}
+		change.MasterAddress = newClientRequest.MasterAddress
+		command = storage.TopologyAddSlaveCommand
 
 	default:
 		err := fmt.Errorf("AddNewStorage() : 지원하지 않는 %s role", newClientRequest.Role)
@@ -450,9 +647,21 @@ func AddNewStorage(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	// 모든 인터페이스 서버가 같은 순서로 노드를 추가하도록 WAL 에 기록
+	if _, err := dispatchTopologyChange(res, req, command, change); err != nil {
+		tools.ErrorLogger.Printf(
+			"AddNewStorage() : 레디스(%s) 추가 에러 - %s",
+			newClientRequest.Address,
+			err.Error(),
+		)
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
 	responseTemplate := response.RedisListTemplate{
-		Masters: storage.GetMasterClients(),
-		Slaves:  storage.GetSlaveClients(),
+		Masters:     storage.GetMasterClients(),
+		Slaves:      storage.GetSlaveClients(),
+		Maintenance: storage.GetMaintenanceNodes(),
 	}
 
 	curMsg := fmt.Sprintf(
@@ -473,6 +682,46 @@ func AddNewStorage(res http.ResponseWriter, req *http.Request) {
 	responseOK(res, responseBody)
 }
 
+// @Summary Remove Master/Slave Redis Client
+// @Description **마스터 제거 시,** 해쉬 슬롯을 다른 마스터들에게 Live Migration 으로 옮긴 뒤 제거
+// @Description 슬레이브는 슬레이브가 없는 다른 마스터에게 옮기고, 그런 마스터가 없으면 함께 제거
+// @Accept json
+// @Produce json
+// @Router /clients/{address} [delete]
+// @Param address path string true "Node Address to Remove"
+// @Success 200 {object} response.RedisListTemplate
+// @Failure 404 {object} response.BasicTemplate "등록되지 않은 노드"
+// @Failure 500 {object} response.BasicTemplate "마지막 마스터이거나 슬롯 이동 실패"
+func RemoveStorage(res http.ResponseWriter, req *http.Request) {
+
+	address := mux.Vars(req)["address"]
+
+	_, masterErr := storage.GetMasterWithAddress(address)
+	_, slaveErr := storage.GetSlaveClientWithAddress(address)
+	if masterErr != nil && slaveErr != nil {
+		responseError(res, http.StatusNotFound, fmt.Errorf(msg.NodeNotRegistered, address))
+		return
+	}
+
+	change := storage.TopologyChange{
+		Address: address,
+	}
+
+	if _, err := dispatchTopologyChange(res, req, storage.TopologyRemoveNodeCommand, change); err != nil {
+		tools.ErrorLogger.Printf("RemoveStorage() : 레디스(%s) 제거 실패 - %s", address, err.Error())
+		responseError(res, http.StatusInternalServerError, err)
+		return
+	}
+
+	curMsg := fmt.Sprintf(
+		"레디스(%s) 제거 성공 : Handled in Server(IP : %s)",
+		address,
+		configs.CurrentIP,
+	)
+
+	responseWithRedisList(res, curMsg)
+}
+
 // @Summary Get Currently Registered Master/Slave Redis Clients
 // @Accept json
 // @Produce json
@@ -485,8 +734,9 @@ func GetStorageInfo(res http.ResponseWriter, req *http.Request) {
 	//tools.InfoLogger.Printf("Interface server(IP : %s) Processing...\n", configs.CurrentIP)
 
 	responseTemplate := response.RedisListTemplate{
-		Masters: storage.GetMasterClients(),
-		Slaves:  storage.GetSlaveClients(),
+		Masters:     storage.GetMasterClients(),
+		Slaves:      storage.GetSlaveClients(),
+		Maintenance: storage.GetMaintenanceNodes(),
 	}
 
 	curMsg := fmt.Sprintf(
@@ -1,6 +1,10 @@
 package hash
 
-import "github.com/howeyc/crc16"
+import (
+	"strings"
+
+	"github.com/howeyc/crc16"
+)
 
 /* CRC key = 16384 = 2^14
  * In polynomial : x^14
@@ -15,17 +19,36 @@ var checkSumTable *crc16.Table
 
 func init() {
 	if checkSumTable == nil {
-		// CRC16-CCITT 를 이용하여 Table을 만든다.
-		checkSumTable = crc16.MakeTable(crc16.CCITT)
+		// Redis Cluster 와 같은 CRC16-XMODEM (다항식 0x1021, 비트 반전 없음, 초기값 0)
+		checkSumTable = crc16.CCITTFalseTable
 	}
 }
 
 // GetHashSlotIndex gets the index of Hash Slots
 // By using CRC16 with @data and Modulo 16384 (Like Redis Cluster)
+// Key에 Hash Tag({...})가 있으면 Tag 안의 문자열만 해쉬한다
 func GetHashSlotIndex(data string) uint16 {
 
 	// Redis는 CRC16 의 Modulo 16384를 사용한다.
-	hashSlotIndex := crc16.Checksum([]byte(data), checkSumTable) % HashSlotsNumber
+	hashSlotIndex := crc16.Checksum([]byte(hashTag(data)), checkSumTable) % HashSlotsNumber
 
 	return hashSlotIndex
 }
+
+// hashTag : Key에서 해쉬할 부분 (Redis Cluster Hash Tag 규칙)
+// 첫 '{' 와 그 이후 첫 '}' 사이의 문자열이 비어있지 않으면 그 문자열, 아니면 Key 전체
+//  ex) "user:{42}:profile" -> "42", "foo{}{bar}" -> "foo{}{bar}", "foo{{bar}}" -> "{bar"
+func hashTag(key string) string {
+
+	tagStart := strings.IndexByte(key, '{')
+	if tagStart < 0 {
+		return key
+	}
+
+	tagLength := strings.IndexByte(key[tagStart+1:], '}')
+	if tagLength <= 0 {
+		return key
+	}
+
+	return key[tagStart+1 : tagStart+1+tagLength]
+}
@@ -0,0 +1,49 @@
+package hash
+
+import "testing"
+
+// Redis Cluster (CLUSTER KEYSLOT) 결과와 비교
+func TestGetHashSlotIndexMatchesRedisCluster(t *testing.T) {
+
+	fixtures := map[string]uint16{
+		"123456789": 12739,
+		"foo":       12182,
+		"bar":       5061,
+		"hello":     866,
+		"somekey":   11058,
+		"{foo}bar":  12182,
+		"bar{foo}":  12182,
+		"a{bar}b":   5061,
+	}
+
+	for key, expectedSlot := range fixtures {
+		if slot := GetHashSlotIndex(key); slot != expectedSlot {
+			t.Errorf("GetHashSlotIndex(%q) = %d, Redis Cluster : %d", key, slot, expectedSlot)
+		}
+	}
+}
+
+func TestHashTag(t *testing.T) {
+
+	fixtures := map[string]string{
+		"user:{42}:profile": "42",
+		"user:{42}:cart":    "42",
+		"{user1000}.follow": "user1000",
+		"foo{}{bar}":        "foo{}{bar}",
+		"foo{{bar}}zap":     "{bar",
+		"foo{bar}{zap}":     "bar",
+		"foo{bar":           "foo{bar",
+		"foo}bar{":          "foo}bar{",
+		"nohashtag":         "nohashtag",
+	}
+
+	for key, expectedTag := range fixtures {
+		if tag := hashTag(key); tag != expectedTag {
+			t.Errorf("hashTag(%q) = %q, expected : %q", key, tag, expectedTag)
+		}
+	}
+
+	if GetHashSlotIndex("user:{42}:profile") != GetHashSlotIndex("user:{42}:cart") {
+		t.Errorf("Keys with same hash tag must be mapped to same slot")
+	}
+}
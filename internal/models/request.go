@@ -6,6 +6,9 @@ import (
 
 type DataRequestContainer struct {
 	Data []storage.KeyValuePair `json:"data"`
+
+	// Durability : 응답 전 복제 확인 수준 ("none"(default) / "replica-ack" / "all-replicas")
+	Durability string `json:"durability,omitempty"`
 }
 
 type WalMsgContainer struct {
@@ -33,3 +36,49 @@ func (client NewClientRequestContainer) IsEmpty() bool {
 
 	return false
 }
+
+type RebalanceRequestContainer struct {
+	// Weights : 마스터 주소 -> 가중치 (생략된 마스터는 1, 0은 모든 슬롯을 내어줌)
+	Weights map[string]float64 `json:"weights"`
+}
+
+type TransactionRequestContainer struct {
+	// Commands : 하나의 트랜잭션(MULTI/EXEC)으로 실행할 명령들, 모든 키는 같은 해쉬 슬롯
+	//  ex) [ { command : "SET", key : "{user}:1", value : "a" }, { command : "DEL", key : "{user}:2" } ]
+	Commands []storage.KeyValuePair `json:"commands"`
+
+	// Durability : 응답 전 복제 확인 수준 ("none"(default) / "replica-ack" / "all-replicas")
+	Durability string `json:"durability,omitempty"`
+}
+
+type CounterRequestContainer struct {
+	// Delta : 증가량 (생략 시 1, 음수면 감소)
+	Delta *int64 `json:"delta,omitempty"`
+
+	// Durability : 응답 전 복제 확인 수준 ("none"(default) / "replica-ack" / "all-replicas")
+	Durability string `json:"durability,omitempty"`
+}
+
+type HashRequestContainer struct {
+	Field string `json:"field"`
+	Value string `json:"value"`
+
+	// Durability : 응답 전 복제 확인 수준 ("none"(default) / "replica-ack" / "all-replicas")
+	Durability string `json:"durability,omitempty"`
+}
+
+type ElementsRequestContainer struct {
+	// Values : LPUSH / SADD 할 원소들
+	Values []string `json:"values"`
+
+	// Durability : 응답 전 복제 확인 수준 ("none"(default) / "replica-ack" / "all-replicas")
+	Durability string `json:"durability,omitempty"`
+}
+
+type ExpireRequestContainer struct {
+	// TTL : 만료 시간 (millisecond)
+	TTL int64 `json:"ttl"`
+
+	// Durability : 응답 전 복제 확인 수준 ("none"(default) / "replica-ack" / "all-replicas")
+	Durability string `json:"durability,omitempty"`
+}
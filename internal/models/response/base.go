@@ -22,6 +22,9 @@ type NextLink struct {
 type RedisListTemplate struct {
 	Masters []storage.RedisClient `json:"masters"`
 	Slaves  []storage.RedisClient `json:"slaves"`
+
+	// Maintenance : 점검 중인 노드 주소들 (자동 Failover / 재시작 제외)
+	Maintenance []string `json:"maintenance,omitempty"`
 	BasicTemplate
 }
 
@@ -77,3 +80,64 @@ func (template ClusterNodeListTemplate) Marshal(
 
 	return encodedTemplate, nil
 }
+
+// MonitorListTemplate : 등록된 모니터 서버 목록과 생존 투표 정족수
+type MonitorListTemplate struct {
+	storage.MonitorRegistry
+	BasicTemplate
+}
+
+func (template MonitorListTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
+
+// FailoverEventListTemplate : Failover 이벤트 기록 (시작 시각 순)
+type FailoverEventListTemplate struct {
+	Events []storage.FailoverEvent `json:"events"`
+	BasicTemplate
+}
+
+func (template FailoverEventListTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
+
+// ReplicationStatusTemplate : 복제 방식과 그룹 별 복제 현황 (마스터 주소 순)
+type ReplicationStatusTemplate struct {
+	Mode        storage.ReplicationMode   `json:"mode"`
+	Replication []storage.ReplicationInfo `json:"replication"`
+	BasicTemplate
+}
+
+func (template ReplicationStatusTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
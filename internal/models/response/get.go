@@ -6,6 +6,12 @@ import (
 
 type GetResultTemplate struct {
 	RedisResult
+
+	// Version : Key의 현재 버전 (조건부 쓰기 cas 의 expected_version 으로 사용)
+	Version uint64 `json:"version"`
+
+	// Exists : Key (HGET 은 필드) 가 있는지, 없으면 Result 는 표시용 문자열
+	Exists bool `json:"exists"`
 	BasicTemplate
 }
 
@@ -26,3 +32,143 @@ func (template GetResultTemplate) Marshal(
 
 	return encodedTemplate, nil
 }
+
+// MultiGetResult : MGET 의 Key 하나의 결과
+type MultiGetResult struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+
+	// Exists : Key 가 있는지, 없으면 Value 는 표시용 문자열
+	Exists bool `json:"exists"`
+}
+
+type MultiGetResultTemplate struct {
+	Results     []MultiGetResult `json:"results"`
+	NodeAdrress string           `json:"handled_node"`
+	BasicTemplate
+}
+
+func (template MultiGetResultTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
+
+type TTLResultTemplate struct {
+	Key string `json:"key"`
+
+	// TTL : 남은 만료 시간 (millisecond), -1 : 만료 시간 없음, -2 : Key 없음
+	TTL         int64  `json:"ttl"`
+	NodeAdrress string `json:"handled_node"`
+	BasicTemplate
+}
+
+func (template TTLResultTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
+
+type CounterResultTemplate struct {
+	Key string `json:"key"`
+
+	// Value : 증가 후 값
+	Value int64 `json:"value"`
+	BasicTemplate
+}
+
+func (template CounterResultTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
+
+type HashResultTemplate struct {
+	Key         string            `json:"key"`
+	Fields      map[string]string `json:"fields"`
+	NodeAdrress string            `json:"handled_node"`
+	BasicTemplate
+}
+
+func (template HashResultTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
+
+// ElementsResultTemplate : 리스트 (LRANGE) / 집합 (SMEMBERS) 원소들
+type ElementsResultTemplate struct {
+	Key         string   `json:"key"`
+	Type        string   `json:"type"`
+	Values      []string `json:"values"`
+	NodeAdrress string   `json:"handled_node"`
+	BasicTemplate
+}
+
+func (template ElementsResultTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
+
+type ScanResultTemplate struct {
+	Keys []string `json:"keys"`
+
+	// Cursor : 다음 요청에 전달할 커서, "0" 이면 끝
+	Cursor string `json:"cursor"`
+	BasicTemplate
+}
+
+func (template ScanResultTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
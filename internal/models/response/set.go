@@ -27,3 +27,45 @@ func (template SetResultTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]b
 
 	return encodedTemplate, nil
 }
+
+type TransactionResultTemplate struct {
+	TxID     string `json:"tx_id"`
+	Decision string `json:"decision"`
+	BasicTemplate
+}
+
+func (template TransactionResultTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
+
+// CommandResultTemplate : 자료형 쓰기 명령 (HSET / LPUSH / RPOP / SADD) 의 레디스 응답
+type CommandResultTemplate struct {
+	Key     string `json:"key"`
+	Command string `json:"command"`
+	Result  string `json:"result"`
+	BasicTemplate
+}
+
+func (template CommandResultTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
@@ -0,0 +1,84 @@
+package response
+
+import (
+	"encoding/json"
+	"hash_interface/internal/storage"
+)
+
+type RebalancePlanTemplate struct {
+	Plan storage.RebalancePlan `json:"plan"`
+	BasicTemplate
+}
+
+func (template RebalancePlanTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
+
+type SlotMapTemplate struct {
+	Epoch  uint64              `json:"epoch"`
+	Ranges []storage.SlotRange `json:"ranges"`
+	Nodes  []storage.NodeSlots `json:"nodes"`
+	BasicTemplate
+}
+
+func (template SlotMapTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
+
+type KeySlotTemplate struct {
+	storage.KeySlot
+	BasicTemplate
+}
+
+func (template KeySlotTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
+
+type SlotRedirectTemplate struct {
+	Redirect storage.SlotRedirect `json:"redirect"`
+	BasicTemplate
+}
+
+func (template SlotRedirectTemplate) Marshal(curMsg, nextMsg, nextLink string) ([]byte, error) {
+
+	template.Message = curMsg
+	template.NextLink.Message = nextMsg
+	template.NextLink.Href = nextLink
+
+	encodedTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodedTemplate, nil
+}
@@ -14,9 +14,17 @@ func SetUpMonitorRouter(router *mux.Router) {
 	// 모니터링 중인 레디스 클라이언트 삭제
 	router.PathPrefix("/connect/{redis_address}").HandlerFunc(handlers.UnregisterRedis).Methods(http.MethodDelete)
 
-	// 모니터링 중인 레디스 클라이언트 Alive 테스트
-	router.HandleFunc("/{redis_address}", handlers.CheckRedisNodeStatus).Methods(http.MethodGet)
+	// 다른 모니터 서버의 관찰 결과 수신 (Gossip)
+	router.HandleFunc("/gossip", handlers.HandleMonitorGossip).Methods(http.MethodPost)
+
+	// 모니터 서버들이 합의한 모든 노드의 상태 (up / sdown / odown)
+	// {redis_address} 보다 먼저 등록해야 한다
+	router.HandleFunc("/status", handlers.ShowMonitorStatus).Methods(http.MethodGet)
 
-	// 현재 등록된 모니터링 중인 레디스 클라이언트 출력
+	// 감시 중인 레디스 노드들 (PING 응답 시간, 마지막 응답 시각, 연속 실패 수)
+	// {redis_address} 보다 먼저 등록해야 한다
 	router.HandleFunc("/nodes", handlers.ShowCurrentRedisList).Methods(http.MethodGet)
+
+	// 모니터링 중인 레디스 클라이언트 Alive 테스트
+	router.HandleFunc("/{redis_address}", handlers.CheckRedisNodeStatus).Methods(http.MethodGet)
 }
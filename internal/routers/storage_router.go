@@ -14,6 +14,62 @@ func SetUpInterfaceRouter(router *mux.Router) {
 
 	router.HandleFunc("/clients", handlers.GetStorageInfo).Methods(http.MethodGet)
 
+	/* @DELETE
+	 * Remove master (drain hash slots to other masters) or slave
+	 * Request URI : http://~/clients/{address}
+	 */
+	router.HandleFunc("/clients/{address}", handlers.RemoveStorage).Methods(http.MethodDelete)
+
+	/* @POST
+	 * Manual Failover : promote slave to master of its group
+	 * Request URI : http://~/clients/{slave_address}/failover
+	 */
+	router.HandleFunc("/clients/{address}/failover", handlers.HandleManualFailover).Methods(http.MethodPost)
+
+	/* @POST, @DELETE
+	 * Start / Finish maintenance of node (excluded from automatic failover & restart)
+	 * Request URI : http://~/clients/{address}/maintenance
+	 */
+	router.HandleFunc("/clients/{address}/maintenance", handlers.StartMaintenance).Methods(http.MethodPost)
+	router.HandleFunc("/clients/{address}/maintenance", handlers.FinishMaintenance).Methods(http.MethodDelete)
+
+	/* @POST
+	 * Node State pushed from Monitor Servers (odown / up changes, periodic refresh)
+	 * Request URI : http://~/monitor/events
+	 * Request Data format : { monitor : , address : , state : "odown" | "up", down_votes : , monitors : , quorum : }
+	 */
+	router.HandleFunc("/monitor/events", handlers.HandleMonitorEvent).Methods(http.MethodPost)
+
+	/* @GET
+	 * Registered Monitor Servers & Vote Quorum
+	 * Request URI : http://~/monitors
+	 */
+	router.HandleFunc("/monitors", handlers.GetMonitorList).Methods(http.MethodGet)
+
+	/* @POST
+	 * Register Monitor Server (Raft log entry)
+	 * Request URI : http://~/monitors/{monitor_address}
+	 */
+	router.HandleFunc("/monitors/{monitor_address}", handlers.HandleRegisterMonitor).Methods(http.MethodPost)
+
+	/* @DELETE
+	 * Deregister Monitor Server (Raft log entry)
+	 * Request URI : http://~/monitors/{monitor_address}
+	 */
+	router.HandleFunc("/monitors/{monitor_address}", handlers.HandleDeregisterMonitor).Methods(http.MethodDelete)
+
+	/* @GET
+	 * Failover Event History (promotion / demotion / slot_redistribution / node_restart)
+	 * Request URI : http://~/events?since=2021-01-01T00:00:00Z (or Unix millisecond)
+	 */
+	router.HandleFunc("/events", handlers.GetFailoverEvents).Methods(http.MethodGet)
+
+	/* @GET
+	 * Replication Status of master-slave groups (offsets, lag, link status in native mode)
+	 * Request URI : http://~/replication
+	 */
+	router.HandleFunc("/replication", handlers.GetReplicationStatus).Methods(http.MethodGet)
+
 	/* @POST
 	 * Set Value
 	 * Request URI : http://~/hash/data
@@ -23,6 +79,8 @@ func SetUpInterfaceRouter(router *mux.Router) {
 				{ key : , value : }, ... ,
 			]
 		}
+	 * Conditional Write : { key : , value : , condition : "nx" | "xx" | "cas", expected_value : , expected_version : }
+	 * Expiration : { key : , value : , ttl : millisecond }
 	*/
 	router.HandleFunc("/hash/data", handlers.HandleUpdateKeyValue).Methods(http.MethodPost)
 
@@ -32,6 +90,156 @@ func SetUpInterfaceRouter(router *mux.Router) {
 	 */
 	router.HandleFunc("/hash/data/{key}", handlers.GetValueFromKey).Methods(http.MethodGet)
 
+	/* @POST
+	 * Set Expiration of Key
+	 * Request URI : http://~/hash/data/key/expire
+	 * Request Data format : { ttl : millisecond }
+	 */
+	router.HandleFunc("/hash/data/{key}/expire", handlers.HandleExpire).Methods(http.MethodPost)
+
+	/* @POST
+	 * Remove Expiration of Key
+	 * Request URI : http://~/hash/data/key/persist
+	 */
+	router.HandleFunc("/hash/data/{key}/persist", handlers.HandlePersist).Methods(http.MethodPost)
+
+	/* @GET
+	 * Get Remaining Time to Live of Key (millisecond)
+	 * Request URI : http://~/hash/data/key/ttl
+	 */
+	router.HandleFunc("/hash/data/{key}/ttl", handlers.GetTTLFromKey).Methods(http.MethodGet)
+
+	/* @POST
+	 * Set Field of Hash (HSET)
+	 * Request URI : http://~/hash/data/key/hash
+	 * Request Data format : { field : , value : }
+	 */
+	router.HandleFunc("/hash/data/{key}/hash", handlers.HandleHashSet).Methods(http.MethodPost)
+
+	/* @GET
+	 * Get All Fields of Hash (HGETALL)
+	 * Request URI : http://~/hash/data/key/hash
+	 */
+	router.HandleFunc("/hash/data/{key}/hash", handlers.GetHashFromKey).Methods(http.MethodGet)
+
+	/* @GET
+	 * Get Field of Hash (HGET)
+	 * Request URI : http://~/hash/data/key/hash/field
+	 */
+	router.HandleFunc("/hash/data/{key}/hash/{field}", handlers.GetHashFieldFromKey).Methods(http.MethodGet)
+
+	/* @POST
+	 * Push Elements to List (LPUSH)
+	 * Request URI : http://~/hash/data/key/list
+	 * Request Data format : { values : [ , ... ] }
+	 */
+	router.HandleFunc("/hash/data/{key}/list", handlers.HandleListPush).Methods(http.MethodPost)
+
+	/* @POST
+	 * Pop Element from List (RPOP)
+	 * Request URI : http://~/hash/data/key/list/pop
+	 */
+	router.HandleFunc("/hash/data/{key}/list/pop", handlers.HandleListPop).Methods(http.MethodPost)
+
+	/* @GET
+	 * Get Range of List (LRANGE)
+	 * Request URI : http://~/hash/data/key/list?start=0&stop=-1
+	 */
+	router.HandleFunc("/hash/data/{key}/list", handlers.GetListFromKey).Methods(http.MethodGet)
+
+	/* @POST
+	 * Add Members to Set (SADD)
+	 * Request URI : http://~/hash/data/key/set
+	 * Request Data format : { values : [ , ... ] }
+	 */
+	router.HandleFunc("/hash/data/{key}/set", handlers.HandleSetAdd).Methods(http.MethodPost)
+
+	/* @GET
+	 * Get Members of Set (SMEMBERS)
+	 * Request URI : http://~/hash/data/key/set
+	 */
+	router.HandleFunc("/hash/data/{key}/set", handlers.GetSetFromKey).Methods(http.MethodGet)
+
+	/* @POST
+	 * Increment Counter of Key (INCRBY), returns new value
+	 * Request URI : http://~/hash/counter/key
+	 * Request Data format : { delta : integer (default 1, negative to decrement) }
+	 */
+	router.HandleFunc("/hash/counter/{key}", handlers.HandleCounter).Methods(http.MethodPost)
+
+	/* @GET
+	 * Scan Keys across All Masters
+	 * Request URI : http://~/hash/keys?prefix=user:&cursor=0&count=10
+	 */
+	router.HandleFunc("/hash/keys", handlers.GetKeys).Methods(http.MethodGet)
+
+	/* @POST
+	 * Set Multiple Values Atomically (same hash slot)
+	 * Request URI : http://~/hash/mset
+	 * Request Data format : {
+			data : [
+				{ key : "{tag}a", value : },
+				{ key : "{tag}b", value : }, ... ,
+			]
+		}
+	*/
+	router.HandleFunc("/hash/mset", handlers.HandleMSet).Methods(http.MethodPost)
+
+	/* @GET
+	 * Get Values From Keys (same hash slot)
+	 * Request URI : http://~/hash/mget?key=key1&key=key2
+	 */
+	router.HandleFunc("/hash/mget", handlers.GetValuesFromKeys).Methods(http.MethodGet)
+
+	/* @POST
+	 * Execute Commands in One Transaction (same hash slot)
+	 * Request URI : http://~/hash/transaction
+	 * Request Data format : {
+			commands : [
+				{ command : "SET", key : , value : },
+				{ command : "DEL", key : }, ... ,
+			]
+		}
+	*/
+	router.HandleFunc("/hash/transaction", handlers.HandleTransaction).Methods(http.MethodPost)
+
+	/* @POST
+	 * Execute Commands across Masters (Two-Phase Commit)
+	 * Request URI : http://~/hash/transaction/distributed
+	 * Request Data format : {
+			commands : [
+				{ command : "SET", key : , value : },
+				{ command : "DEL", key : }, ... ,
+			]
+		}
+	*/
+	router.HandleFunc("/hash/transaction/distributed", handlers.HandleDistributedTransaction).Methods(http.MethodPost)
+
+	/* @GET
+	 * Dry-run Slot Rebalance Plan
+	 * Request URI : http://~/slots/rebalance/plan?weights=address=weight,...
+	 */
+	router.HandleFunc("/slots/rebalance/plan", handlers.GetRebalancePlan).Methods(http.MethodGet)
+
+	/* @POST
+	 * Apply Slot Rebalance
+	 * Request URI : http://~/slots/rebalance
+	 * Request Data format : { weights : { address : weight, ... } }
+	 */
+	router.HandleFunc("/slots/rebalance", handlers.ApplyRebalance).Methods(http.MethodPost)
+
+	/* @GET
+	 * Hash Slot Map (ranges, nodes)
+	 * Request URI : http://~/slots
+	 */
+	router.HandleFunc("/slots", handlers.GetSlotMap).Methods(http.MethodGet)
+
+	/* @GET
+	 * Hash Slot of Key
+	 * Request URI : http://~/slots/key/{key}
+	 */
+	router.HandleFunc("/slots/key/{key}", handlers.GetKeySlot).Methods(http.MethodGet)
+
 	/* @DELETE
 	 * DELETE Value From Key
 	 * Request URI : http://~/hash/data/key
@@ -0,0 +1,126 @@
+package routers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"hash_interface/internal/handlers"
+	"hash_interface/internal/models/response"
+	"hash_interface/internal/storage"
+	"hash_interface/tools"
+)
+
+func TestMain(m *testing.M) {
+
+	tools.InfoLogger = log.New(ioutil.Discard, "", 0)
+	tools.ErrorLogger = log.New(ioutil.Discard, "", 0)
+
+	os.Exit(m.Run())
+}
+
+// 모니터 서버의 refreshPeers 와 같은 방식 (Index Time 헤더 없이) 으로 모니터 서버 목록 조회
+func TestFetchMonitorRegistry(t *testing.T) {
+
+	router := mux.NewRouter()
+	SetUpInterfaceRouter(router.PathPrefix("/api/v1").Subrouter())
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	registry, err := storage.FetchMonitorRegistry(strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatalf("FetchMonitorRegistry() 에러 : %s", err.Error())
+	}
+
+	expected := storage.GetMonitorRegistry()
+
+	if registry.TotalVotes != expected.TotalVotes || registry.Quorum != expected.Quorum {
+		t.Errorf(
+			"FetchMonitorRegistry() = (votes %d, quorum %d), expected : (votes %d, quorum %d)",
+			registry.TotalVotes,
+			registry.Quorum,
+			expected.TotalVotes,
+			expected.Quorum,
+		)
+	}
+
+	if len(registry.Monitors) != len(expected.Monitors) {
+		t.Errorf("FetchMonitorRegistry() monitors %v, expected : %v", registry.Monitors, expected.Monitors)
+	}
+}
+
+// Replay 복제 모드에서는 INFO replication 을 확인하지 않으므로 복제 현황이 비어있다
+func TestGetReplicationStatus(t *testing.T) {
+
+	router := mux.NewRouter()
+	SetUpInterfaceRouter(router.PathPrefix("/api/v1").Subrouter())
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/api/v1/replication")
+	if err != nil {
+		t.Fatalf("GET /replication 에러 : %s", err.Error())
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("GET /replication 상태 코드 %d, expected : %d", res.StatusCode, http.StatusOK)
+	}
+
+	var status response.ReplicationStatusTemplate
+	if err := json.NewDecoder(res.Body).Decode(&status); err != nil {
+		t.Fatalf("GET /replication 응답 에러 : %s", err.Error())
+	}
+
+	if status.Mode != storage.ReplayReplication || len(status.Replication) != 0 {
+		t.Errorf(
+			"GET /replication = (mode %s, groups %d), expected : (mode %s, groups 0)",
+			status.Mode,
+			len(status.Replication),
+			storage.ReplayReplication,
+		)
+	}
+}
+
+// Key 의 해쉬 슬롯 조회는 /slots 아래 다른 경로 (ex. /slots/rebalance) 와 겹치지 않는다
+func TestKeySlotRoute(t *testing.T) {
+
+	router := mux.NewRouter()
+	SetUpInterfaceRouter(router.PathPrefix("/api/v1").Subrouter())
+
+	fixtures := []struct {
+		name      string
+		path      string
+		isKeySlot bool
+	}{
+		{name: "Key 조회", path: "/api/v1/slots/key/foo", isKeySlot: true},
+		{name: "rebalance 라는 Key 조회", path: "/api/v1/slots/key/rebalance", isKeySlot: true},
+		{name: "재분배 (POST 전용)", path: "/api/v1/slots/rebalance", isKeySlot: false},
+		{name: "재분배 계획", path: "/api/v1/slots/rebalance/plan", isKeySlot: false},
+	}
+
+	keySlotHandler := reflect.ValueOf(handlers.GetKeySlot).Pointer()
+
+	for _, fixture := range fixtures {
+
+		req := httptest.NewRequest(http.MethodGet, fixture.path, nil)
+
+		match := mux.RouteMatch{}
+		isMatched := router.Match(req, &match) && match.Handler != nil
+
+		isKeySlot := isMatched && reflect.ValueOf(match.Handler).Pointer() == keySlotHandler
+		if isKeySlot != fixture.isKeySlot {
+			t.Errorf("%s : GET %s 가 GetKeySlot 으로 연결 %v, expected : %v", fixture.name, fixture.path, isKeySlot, fixture.isKeySlot)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"fmt"
+	"sync"
+
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+// failoverRequests : Raft 로그로 Failover 를 요청 중인 마스터 주소
+// 요청을 처리하는 동안 죽은 마스터를 여러 번 발견해도 한 번만 요청한다
+//
+var failoverRequests = make(map[string]bool)
+var failoverRequestsMutex = &sync.Mutex{}
+
+// requestFailover : @address 마스터의 Failover 를 별도의 고루틴에서 요청, 이미 요청 중이면 false
+// 구성 변경 엔트리는 커밋 순서대로 반영되므로, 반영 중인 엔트리 (ex. handleIfDead 를 부른 쓰기) 가 기다리지 않도록 비동기로 요청
+//
+func requestFailover(address string, failover func()) bool {
+
+	failoverRequestsMutex.Lock()
+	defer failoverRequestsMutex.Unlock()
+
+	if failoverRequests[address] {
+		return false
+	}
+	failoverRequests[address] = true
+
+	go func() {
+		defer func() {
+			failoverRequestsMutex.Lock()
+			delete(failoverRequests, address)
+			failoverRequestsMutex.Unlock()
+		}()
+
+		failover()
+	}()
+
+	return true
+}
+
+// failover : 과반수가 죽었다고 판단한 마스터의 Failover 를 구성 변경 엔트리로 요청
+//  1. 슬레이브가 살아있으면 승격 (TOPOLOGY_PROMOTE), 죽어있으면 해쉬 슬롯 재분배 (TOPOLOGY_REBALANCE)
+//  2. 승격 후 죽은 기존 마스터는 재시작 (NodeRestarter : docker / process / none)
+//  3. 재시작이 성공하면 새로운 마스터의 슬레이브로 추가 (TOPOLOGY_ADD_SLAVE)
+//
+func (deadMaster RedisClient) failover(slaveClient RedisClient, masterVotes VoteTally) {
+
+	// 마스터 - 슬레이브 모두 죽은 경우
+	// 해쉬 슬롯 재분배 후, 마스터-슬레이브의 모든 데이터 및 설정 삭제
+	if slaveVotes, _ := slaveClient.voteAlive(); slaveVotes.isDown() {
+		dispatchFailoverChange(TopologyRebalanceCommand, TopologyChange{Address: deadMaster.Address, Failover: true})
+		return
+	}
+
+	promoteChange := TopologyChange{Address: slaveClient.Address, Failover: true}
+	if err := dispatchFailoverChange(TopologyPromoteCommand, promoteChange); err != nil {
+		return
+	}
+
+	//tools.InfoLogger.Printf(msg.PromotionSuccess, deadMaster.Address)
+
+	restartEvent := newFailoverEvent(NodeRestartEvent, deadMaster.Address, "", "")
+	restartEvent.Votes = &masterVotes
+
+	err := nodeRestarter.RestartNode(deadMaster.Address)
+	recordFailoverEvent(restartEvent, err)
+
+	// 노드 재시작이 성공한 경우에만 새로운 마스터의 슬레이브로 추가
+	if err != nil {
+		tools.ErrorLogger.Println(err)
+		return
+	}
+
+	demotionEvent := newFailoverEvent(DemotionEvent, deadMaster.Address, MasterRole, SlaveRole)
+	demotionChange := TopologyChange{Address: deadMaster.Address, MasterAddress: slaveClient.Address}
+	recordFailoverEvent(demotionEvent, dispatchFailoverChange(TopologyAddSlaveCommand, demotionChange))
+}
+
+// dispatchFailoverChange : 자동 Failover 구성 변경 엔트리를 Raft 로그에 기록, 반영될 때까지 대기
+// 모든 인터페이스 서버가 같은 죽은 노드를 발견하므로, 변경 ID 는 반영한 구성 변경 수 기준
+// (같은 구성에서 요청한 같은 변경은 한 번만 반영, 이미 바뀐 구성에서 요청한 변경은 모든 서버에서 거절)
+//
+func dispatchFailoverChange(command string, change TopologyChange) error {
+
+	if topologyDispatcher == nil {
+		return fmt.Errorf(msg.TopologyDispatcherNotSet)
+	}
+
+	changeID := fmt.Sprintf("failover-%s-%s-%d", command, change.Address, getTopologyVersion())
+
+	entry, err := NewTopologyEntry(command, changeID, change)
+	if err == nil {
+		_, err = topologyDispatcher(entry)
+	}
+
+	if err != nil {
+		tools.ErrorLogger.Printf(msg.FailoverDispatchFail, change.Address, command, err.Error())
+	}
+
+	return err
+}
+
+// promoteDeadMaster : 죽은 마스터의 @slaveAddress 슬레이브를 새로운 마스터로 승격 (TOPOLOGY_PROMOTE 자동 Failover 반영)
+// 계획된 Failover 와 달리 죽은 마스터를 따라잡기를 기다리지 않는다
+//
+func promoteDeadMaster(slaveAddress string) error {
+
+	masterClient, isSlave := slaveMasterMap[slaveAddress]
+	if isSlave == false {
+		return fmt.Errorf(msg.NotASlave, slaveAddress)
+	}
+
+	groupMutex := redisMutexMap[masterClient.Address]
+	groupMutex.Lock()
+	defer groupMutex.Unlock()
+
+	// Lock 을 기다리는 동안 그룹이 바뀌었을 수 있다
+	masterClient, isSlave = slaveMasterMap[slaveAddress]
+	if isSlave == false {
+		return fmt.Errorf(msg.NotASlave, slaveAddress)
+	}
+
+	if isMigratingWith(masterClient.Address) {
+		return fmt.Errorf(msg.FailoverDuringMigration, masterClient.Address)
+	}
+
+	slaveClient := masterSlaveMap[masterClient.Address]
+
+	return slaveClient.promoteToMaster()
+}
+
+// redistributeDeadMaster : 슬레이브도 죽은 @masterAddress 마스터의 해쉬 슬롯을 다른 마스터들에게 재분배 (TOPOLOGY_REBALANCE 자동 Failover 반영)
+func redistributeDeadMaster(masterAddress string) error {
+
+	masterClient, err := GetMasterWithAddress(masterAddress)
+	if err != nil {
+		return err
+	}
+	deadMaster := *masterClient
+
+	groupMutex := redisMutexMap[deadMaster.Address]
+	groupMutex.Lock()
+	defer groupMutex.Unlock()
+
+	return hashSlot.distributeFrom(&deadMaster)
+}
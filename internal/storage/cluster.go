@@ -24,7 +24,9 @@ func GetRedisClient(hashSlotIndex uint16) (RedisClient, error) {
 	defer redisMutexMap[tempClient.Address].Unlock()
 
 	start := time.Now()
-	defer tools.InfoLogger.Printf(msg.FunctionExecutionTime, time.Since(start))
+	defer func() {
+		tools.InfoLogger.Printf(msg.FunctionExecutionTime, time.Since(start))
+	}()
 
 	// 반환 전, redisClient의 생존여부 확인/처리
 	if err := tempClient.handleIfDead(); err != nil {
@@ -132,20 +134,17 @@ func AddNewMaster(newMasterAddress string) error {
 	}
 
 	// 모니터 서버에도 등록 요청
-	if _, err := monitorClient.ask(*newMaster, NewConnect); err != nil {
+	if _, err := currentMonitorClient().ask(*newMaster, NewConnect); err != nil {
 		tools.ErrorLogger.Printf(msg.MonitorRegisterFail)
 		return err
 	}
 
-	if ok := hashSlot.distributeTo(newMaster); ok != true {
-		return fmt.Errorf(msg.DistributeToFail, newMaster.Address)
-	}
-
 	if err := newMaster.createDataLogFile(); err != nil {
 		return err
 	}
 
-	if err := newMaster.reshardData(); err != nil {
+	// 슬롯 단위로 키를 옮긴 후 소유권 변경 (이동 중에도 요청 처리 가능)
+	if _, err := hashSlot.rebalance(nil); err != nil {
 		tools.ErrorLogger.Printf(msg.TakeDataFail, err.Error())
 		return err
 	}
@@ -169,7 +168,7 @@ func AddNewSlave(newSlaveAddress string, targetMaster RedisClient) error {
 	}
 
 	// 모니터 서버에도 등록 요청
-	if _, err := monitorClient.ask(*newSlave, NewConnect); err != nil {
+	if _, err := currentMonitorClient().ask(*newSlave, NewConnect); err != nil {
 		tools.ErrorLogger.Printf(msg.MonitorRegisterFail)
 		return err
 	}
@@ -0,0 +1,383 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gomodule/redigo/redis"
+
+	"hash_interface/internal/hash"
+	msg "hash_interface/internal/storage/message"
+)
+
+const (
+	SetCommand  = "SET"
+	DelCommand  = "DEL"
+	MSetCommand = "MSET"
+	ExecCommand = "EXEC"
+)
+
+// CheckSameSlot : @keys 가 모두 같은 해쉬 슬롯인지 확인, 해쉬 슬롯 반환
+// 다른 슬롯이 섞여 있으면 CROSSSLOT 에러 (Hash Tag 로 같은 슬롯에 묶을 수 있다)
+//
+func CheckSameSlot(keys []string) (uint16, error) {
+
+	if len(keys) == 0 {
+		return 0, fmt.Errorf(msg.EmptyKeys)
+	}
+
+	hashSlotIndex := hash.GetHashSlotIndex(keys[0])
+
+	for _, eachKey := range keys[1:] {
+		if hash.GetHashSlotIndex(eachKey) != hashSlotIndex {
+			return 0, fmt.Errorf(msg.CrossSlot)
+		}
+	}
+
+	return hashSlotIndex, nil
+}
+
+// IsCrossSlotError : 요청한 키들의 해쉬 슬롯이 달라 거절된 에러인지
+// 에러는 클러스터 노드 간 HTTP 응답 메세지로 전달되므로 메세지로 판단한다
+//
+func IsCrossSlotError(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), msg.CrossSlotMarker)
+}
+
+// IsTryAgainError : 슬롯 이동 중 키들이 나뉘어 있거나, 마스터 Failover 중이라 거절된 에러인지
+func IsTryAgainError(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), msg.SlotMigrationTryAgainMarker)
+}
+
+// NewMSetEntry : (key, value) 들을 하나의 MSET 엔트리로 묶는다
+func NewMSetEntry(keyValuePairs []KeyValuePair) KeyValuePair {
+
+	commands := make([]KeyValuePair, len(keyValuePairs))
+	for i, eachKeyValue := range keyValuePairs {
+		// 조건부 쓰기 등 다른 필드는 그대로 두어 ValidateEntry 에서 거절되도록
+		eachKeyValue.Command = SetCommand
+		commands[i] = eachKeyValue
+	}
+
+	return KeyValuePair{
+		Command:  MSetCommand,
+		Commands: commands,
+	}
+}
+
+// ValidateEntry : WAL 에 기록하기 전, 엔트리가 실행 가능한지 확인
+//  - SET : 지원하는 조건부 쓰기인지
+//  - HSET / LPUSH / SADD : 필드 / 원소가 있는지
+//  - MSET / EXEC : 지원하는 명령(SET / DEL)인지, 모든 키가 같은 해쉬 슬롯인지
+//  - TXN_PREPARE : 지원하는 명령(SET / DEL)인지 (여러 해쉬 슬롯 가능, 버전 비교만 허용)
+//  - MONITOR_REGISTER / MONITOR_DEREGISTER : 모니터 서버 주소가 host:port 인지
+//  - TOPOLOGY_* : 변경 ID 와 필요한 노드 주소가 있는지
+//
+func ValidateEntry(entry KeyValuePair) error {
+
+	switch entry.Command {
+	case "", SetCommand:
+		if entry.TTL < 0 {
+			return fmt.Errorf(msg.InvalidTTL, entry.Key, entry.TTL)
+		}
+		return validateCondition(entry)
+
+	case PExpireAtCommand:
+		// WAL 기록 전에는 TTL, 기록 후에는 ExpireAt
+		if entry.TTL <= 0 && entry.ExpireAt <= 0 {
+			return fmt.Errorf(msg.InvalidTTL, entry.Key, entry.TTL)
+		}
+		return nil
+
+	case PersistCommand:
+		return nil
+
+	case IncrByCommand:
+		if _, err := strconv.ParseInt(entry.Value, 10, 64); err != nil {
+			return fmt.Errorf(msg.InvalidDelta, entry.Key, entry.Value)
+		}
+		return nil
+
+	case HSetCommand, LPushCommand, RPopCommand, SAddCommand:
+		return validateTypedEntry(entry)
+
+	case TxnCommitCommand, TxnAbortCommand:
+		if entry.TxID == "" {
+			return fmt.Errorf(msg.TransactionIDMissing)
+		}
+		return nil
+
+	case TxnPrepareCommand:
+		if entry.TxID == "" {
+			return fmt.Errorf(msg.TransactionIDMissing)
+		}
+		if len(entry.Commands) == 0 {
+			return fmt.Errorf(msg.EmptyKeys)
+		}
+
+		// 분산 트랜잭션은 여러 해쉬 슬롯 (여러 마스터) 에 걸칠 수 있다
+		// 버전 비교(cas + expected_version)는 PREPARE 반영 시 확인한다
+		return validateCommands(withoutVersionChecks(entry.Commands))
+
+	case MonitorRegisterCommand, MonitorDeregisterCommand:
+		return validateMonitorEntry(entry)
+
+	case TopologyAddMasterCommand, TopologyAddSlaveCommand, TopologyRemoveNodeCommand,
+		TopologyPromoteCommand, TopologyRebalanceCommand:
+		return validateTopologyEntry(entry)
+
+	case MSetCommand, ExecCommand:
+		if err := validateCommands(entry.Commands); err != nil {
+			return err
+		}
+
+		_, err := CheckSameSlot(keysOf(entry.Commands))
+		return err
+	}
+
+	return fmt.Errorf(msg.UnsupportedTransactionCommand, entry.Command)
+}
+
+// validateCommands : 트랜잭션에 묶인 명령들이 지원하는 명령(SET / DEL)인지
+// 조건부 쓰기는 단일 SET 에서만 지원한다
+//
+func validateCommands(commands []KeyValuePair) error {
+
+	for _, eachCommand := range commands {
+
+		switch strings.ToUpper(eachCommand.Command) {
+		case "", SetCommand, DelCommand:
+		default:
+			return fmt.Errorf(msg.UnsupportedTransactionCommand, eachCommand.Command)
+		}
+
+		if eachCommand.Condition != "" {
+			return fmt.Errorf(msg.ConditionInTransaction, eachCommand.Key)
+		}
+
+		if eachCommand.TTL != 0 || eachCommand.ExpireAt != 0 {
+			return fmt.Errorf(msg.TTLInTransaction, eachCommand.Key)
+		}
+	}
+
+	return nil
+}
+
+// ExecuteEntry : WAL 엔트리 하나를 Key Value Store에 반영, 처리한 마스터와 반영 결과 반환
+//  - SET : 단일 Key SET (조건부 쓰기는 조건이 맞지 않으면 CONFLICT 에러)
+//  - INCRBY : Key 값 증가, 결과는 증가 후 값
+//  - HSET / LPUSH / RPOP / SADD : 자료형 쓰기, 결과는 레디스 응답
+//  - PEXPIREAT / PERSIST : Key 만료 시간 설정 / 제거
+//  - MSET / EXEC : 묶인 명령들을 담당 마스터에서 하나의 트랜잭션(MULTI/EXEC)으로 실행
+//  - TXN_PREPARE / TXN_COMMIT / TXN_ABORT : 여러 마스터에 걸친 분산 트랜잭션(2PC) 단계
+//  - MONITOR_REGISTER / MONITOR_DEREGISTER : 모니터 서버 목록 변경 (레디스에는 반영하지 않음)
+//  - TOPOLOGY_* : 노드 추가 / 제거, 승격, 해쉬 슬롯 재분배, 결과는 엔트리 (재분배는 RebalancePlan JSON)
+//
+func ExecuteEntry(entry KeyValuePair, durability Durability) (RedisClient, string, error) {
+
+	if err := ValidateEntry(entry); err != nil {
+		return RedisClient{}, "", err
+	}
+
+	if entry.Command == IncrByCommand {
+		return executeCounter(entry, durability)
+	}
+
+	if IsTypedCommand(entry.Command) {
+		return executeTypedEntry(entry, durability)
+	}
+
+	if IsTopologyCommand(entry.Command) {
+		return executeTopologyEntry(entry)
+	}
+
+	redisClient, err := executeEntry(entry, durability)
+
+	return redisClient, FormatEntry(entry), err
+}
+
+// executeEntry : 반영 결과가 엔트리 자신인 명령 반영
+func executeEntry(entry KeyValuePair, durability Durability) (RedisClient, error) {
+
+	switch entry.Command {
+	case TxnPrepareCommand, TxnCommitCommand, TxnAbortCommand:
+		return RedisClient{}, executeTwoPhaseEntry(entry, durability)
+
+	case MonitorRegisterCommand, MonitorDeregisterCommand:
+		return RedisClient{}, executeMonitorEntry(entry)
+
+	case MSetCommand, ExecCommand:
+		if err := checkKeysUnlocked(keysOf(entry.Commands)); err != nil {
+			return RedisClient{}, err
+		}
+		return executeTransaction(entry.Commands, durability)
+	}
+
+	// 분산 트랜잭션이 잠근 키는 결정이 날 때까지 변경 불가
+	if err := checkKeysUnlocked([]string{entry.Key}); err != nil {
+		return RedisClient{}, err
+	}
+
+	if entry.Command == PExpireAtCommand || entry.Command == PersistCommand {
+		return executeExpiry(entry, durability)
+	}
+
+	// Key의 해쉬 슬롯을 담당하는 레디스 획득 (슬롯 이동 중이면 Key 위치에 따라)
+	redisClient, release, err := GetRedisClientWithKey(entry.Key)
+	if err != nil {
+		return RedisClient{}, err
+	}
+
+	// 레디스에 요청 명령 실행 (조건부 쓰기는 조건 확인 후)
+	err = redisClient.setWithCondition(entry)
+	if err != nil {
+		release()
+		return redisClient, err
+	}
+
+	// 변경사항 데이터 로그 기록 (만료 시각은 별도 PEXPIREAT 로그)
+	err = redisClient.RecordModificationLog(SetCommand, entry.Key, entry.Value)
+	if err == nil && entry.ExpireAt > 0 {
+		err = redisClient.RecordModificationLog(PExpireAtCommand, entry.Key, strconv.FormatInt(entry.ExpireAt, 10))
+	}
+	release()
+	if err != nil {
+		return redisClient, err
+	}
+
+	if entry.ExpireAt == 0 {
+		// 슬레이브에게 전파 (durability 설정 시 레플리카 확인까지 대기)
+		err = redisClient.ReplicateToSlaveWithAck(SetCommand, entry.Key, entry.Value, durability)
+		return redisClient, err
+	}
+
+	// 만료 시각까지 전파한 뒤 레플리카 확인
+	redisClient.ReplicateToSlave(SetCommand, entry.Key, entry.Value)
+	err = redisClient.ReplicateToSlaveWithAck(
+		PExpireAtCommand,
+		entry.Key,
+		strconv.FormatInt(entry.ExpireAt, 10),
+		durability,
+	)
+
+	return redisClient, err
+}
+
+// executeTransaction : 같은 해쉬 슬롯의 @commands 를 MULTI/EXEC 로 실행
+// 실행 전 에러(큐잉 실패)는 Redis 가 트랜잭션 전체를 취소한다
+//
+func executeTransaction(commands []KeyValuePair, durability Durability) (RedisClient, error) {
+
+	redisClient, release, err := GetRedisClientWithKeys(keysOf(commands))
+	if err != nil {
+		return RedisClient{}, err
+	}
+
+	if err := runTransaction(redisClient.Connection, commands); err != nil {
+		release()
+		return redisClient, fmt.Errorf(msg.TransactionFail, redisClient.Address, err.Error())
+	}
+
+	bumpKeyVersions(redisClient, keysOf(commands))
+
+	// 변경사항 데이터 로그 기록
+	for _, eachCommand := range commands {
+		err := redisClient.RecordModificationLog(
+			EntryCommand(eachCommand),
+			eachCommand.Key,
+			eachCommand.Value,
+		)
+		if err != nil {
+			release()
+			return redisClient, err
+		}
+	}
+
+	release()
+
+	// 슬레이브에게 전파 (durability 설정 시 레플리카 확인까지 대기)
+	err = redisClient.ReplicateTransactionToSlave(commands, durability)
+
+	return redisClient, err
+}
+
+// runTransaction : @connection 에 MULTI, 명령들, EXEC 전송
+func runTransaction(connection redis.Conn, commands []KeyValuePair) error {
+
+	if err := connection.Send("MULTI"); err != nil {
+		return err
+	}
+
+	for _, eachCommand := range commands {
+
+		command := EntryCommand(eachCommand)
+
+		err := connection.Send(command, commandArgs(command, eachCommand.Key, eachCommand.Value)...)
+		if err != nil {
+			connection.Do("DISCARD")
+			return err
+		}
+	}
+
+	_, err := redis.Values(connection.Do("EXEC"))
+
+	return err
+}
+
+// EntryCommand : 엔트리(또는 트랜잭션에 묶인 명령)의 명령 이름, 생략 시 SET
+func EntryCommand(entry KeyValuePair) string {
+
+	if entry.Command == "" {
+		return SetCommand
+	}
+
+	return strings.ToUpper(entry.Command)
+}
+
+// FormatEntry : 응답용 엔트리 문자열
+//  ex) "SET foo bar", "MSET {a}1 x {a}2 y", "EXEC SET {a}1 x; DEL {a}2"
+//
+func FormatEntry(entry KeyValuePair) string {
+
+	command := EntryCommand(entry)
+
+	switch command {
+	case MSetCommand:
+		args := make([]string, 0, 2*len(entry.Commands))
+		for _, eachCommand := range entry.Commands {
+			args = append(args, eachCommand.Key, eachCommand.Value)
+		}
+		return fmt.Sprintf("%s %s", command, strings.Join(args, " "))
+
+	case ExecCommand:
+		commands := make([]string, len(entry.Commands))
+		for i, eachCommand := range entry.Commands {
+			commands[i] = FormatEntry(eachCommand)
+		}
+		return fmt.Sprintf("%s %s", command, strings.Join(commands, "; "))
+
+	case DelCommand, PersistCommand, MonitorRegisterCommand, MonitorDeregisterCommand:
+		return fmt.Sprintf("%s %s", command, entry.Key)
+
+	case PExpireAtCommand:
+		return fmt.Sprintf("%s %s %d", command, entry.Key, entry.ExpireAt)
+
+	case IncrByCommand:
+		return fmt.Sprintf("%s %s %s", command, entry.Key, entry.Value)
+
+	case HSetCommand:
+		return fmt.Sprintf("%s %s %s %s", command, entry.Key, entry.Field, entry.Value)
+
+	case LPushCommand, SAddCommand:
+		return fmt.Sprintf("%s %s %s", command, entry.Key, strings.Join(entry.Values, " "))
+
+	case RPopCommand:
+		return fmt.Sprintf("%s %s", command, entry.Key)
+
+	case TxnPrepareCommand, TxnCommitCommand, TxnAbortCommand:
+		return fmt.Sprintf("%s %s", command, entry.TxID)
+	}
+
+	return fmt.Sprintf("%s %s %s", command, entry.Key, entry.Value)
+}
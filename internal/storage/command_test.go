@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"fmt"
+	"testing"
+
+	"hash_interface/internal/hash"
+	msg "hash_interface/internal/storage/message"
+)
+
+func TestValidateMultiKeyEntry(t *testing.T) {
+
+	if hash.GetHashSlotIndex("foo") == hash.GetHashSlotIndex("bar") {
+		t.Fatalf("foo, bar 가 같은 해쉬 슬롯입니다")
+	}
+
+	fixtures := []struct {
+		name        string
+		entry       KeyValuePair
+		expectedErr string
+	}{
+		{
+			name: "Hash Tag 로 같은 슬롯인 MSET",
+			entry: NewMSetEntry([]KeyValuePair{
+				{Key: "{user}.name", Value: "foo"},
+				{Key: "{user}.mail", Value: "bar"},
+			}),
+		},
+		{
+			name: "SET / DEL 트랜잭션",
+			entry: KeyValuePair{Command: ExecCommand, Commands: []KeyValuePair{
+				{Key: "{user}.name", Value: "foo"},
+				{Key: "{user}.mail", Command: "del"},
+			}},
+		},
+		{
+			name: "다른 슬롯이 섞인 MSET",
+			entry: NewMSetEntry([]KeyValuePair{
+				{Key: "foo", Value: "1"},
+				{Key: "bar", Value: "2"},
+			}),
+			expectedErr: msg.CrossSlot,
+		},
+		{
+			name:        "Key 없는 MSET",
+			entry:       NewMSetEntry(nil),
+			expectedErr: msg.EmptyKeys,
+		},
+		{
+			name: "조건부 쓰기가 묶인 MSET",
+			entry: NewMSetEntry([]KeyValuePair{
+				{Key: "{user}.name", Value: "foo", Condition: ConditionIfAbsent},
+			}),
+			expectedErr: fmt.Sprintf(msg.ConditionInTransaction, "{user}.name"),
+		},
+		{
+			name: "TTL 이 묶인 MSET",
+			entry: NewMSetEntry([]KeyValuePair{
+				{Key: "{user}.name", Value: "foo", TTL: 1000},
+			}),
+			expectedErr: fmt.Sprintf(msg.TTLInTransaction, "{user}.name"),
+		},
+		{
+			name: "지원하지 않는 명령이 묶인 트랜잭션",
+			entry: KeyValuePair{Command: ExecCommand, Commands: []KeyValuePair{
+				{Key: "{user}.name", Command: "GETSET", Value: "foo"},
+			}},
+			expectedErr: fmt.Sprintf(msg.UnsupportedTransactionCommand, "GETSET"),
+		},
+	}
+
+	for _, fixture := range fixtures {
+
+		err := ValidateEntry(fixture.entry)
+
+		if fixture.expectedErr == "" {
+			if err != nil {
+				t.Errorf("%s : ValidateEntry() 에러 %s", fixture.name, err.Error())
+			}
+			continue
+		}
+
+		if err == nil || err.Error() != fixture.expectedErr {
+			t.Errorf("%s : ValidateEntry() 에러 %v, expected : %s", fixture.name, err, fixture.expectedErr)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gomodule/redigo/redis"
+
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+// 조건부 쓰기 (KeyValuePair.Condition)
+// 조건은 WAL 에 함께 기록되어, 커밋 순서대로 반영(apply)될 때 확인한다
+//
+const (
+	// ConditionIfAbsent : Key가 없을 때만 SET (SET NX)
+	ConditionIfAbsent = "nx"
+
+	// ConditionIfPresent : Key가 있을 때만 SET (SET XX)
+	ConditionIfPresent = "xx"
+
+	// ConditionCompareAndSwap : 현재 값이 expected_value, 버전이 expected_version 과 같을 때만 SET
+	ConditionCompareAndSwap = "cas"
+)
+
+// KeyVersionCommand : Key 의 버전 기록, Value 에 버전 (데이터 로그 전용, Redis 에는 실행하지 않는다)
+// Key 와 같은 데이터 로그에 남겨, 재시작 / Failover / 슬롯 이동 후에도 같은 버전으로 CAS 를 확인한다
+//
+const KeyVersionCommand = "VERSION"
+
+// KeyVersionMap : Key -> 데이터 로그에 기록된 마지막 버전 (삭제된 Key 포함)
+type KeyVersionMap map[string]uint64
+
+// compareAndSwapScript : GET 과 SET 사이에 다른 쓰기가 끼어들지 않도록 Lua 스크립트로 실행
+// ARGV[3] 이 있으면 만료 시간 (PX, millisecond)
+var compareAndSwapScript = redis.NewScript(1, `
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return false
+end
+if ARGV[3] then
+	return redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+end
+return redis.call("SET", KEYS[1], ARGV[2])
+`)
+
+// keyVersions : Key -> 버전, 이 노드에서 Key에 반영된 쓰기(SET / DEL) 횟수
+// 모든 노드가 같은 순서로 WAL 을 반영하므로 노드 간 같은 값을 가진다 (쓰기가 없던 Key는 0)
+// 쓰기마다 Key 를 담당하는 마스터 / 슬레이브의 데이터 로그에도 기록, 시작 시 RestoreKeyVersions 로 복원
+//
+var keyVersions = make(map[string]uint64)
+var keyVersionsMutex = &sync.Mutex{}
+
+// GetKeyVersion : @key 의 현재 버전
+func GetKeyVersion(key string) uint64 {
+
+	keyVersionsMutex.Lock()
+	defer keyVersionsMutex.Unlock()
+
+	return keyVersions[key]
+}
+
+// bumpKeyVersions : @redisClient 에서 @keys 에 쓰기가 반영된 후 호출
+func bumpKeyVersions(redisClient RedisClient, keys []string) {
+
+	keyVersionsMutex.Lock()
+	defer keyVersionsMutex.Unlock()
+
+	for _, eachKey := range keys {
+		keyVersions[eachKey]++
+		redisClient.recordKeyVersion(eachKey, keyVersions[eachKey])
+	}
+}
+
+// recordKeyVersion : @key 의 버전을 자신과 슬레이브의 데이터 로그에 기록
+func (redisClient RedisClient) recordKeyVersion(key string, version uint64) {
+
+	value := strconv.FormatUint(version, 10)
+
+	if err := redisClient.RecordModificationLog(KeyVersionCommand, key, value); err != nil {
+		tools.ErrorLogger.Printf(msg.RecordKeyVersionFail, key, redisClient.Address, err.Error())
+	}
+
+	if slaveClient, err := redisClient.getSlave(); err == nil {
+		slaveClient.RecordModificationLog(KeyVersionCommand, key, value)
+	}
+}
+
+// RestoreKeyVersions : 모든 노드의 데이터 로그에 기록된 Key 버전 복원, 복원한 Key 수 반환
+// 인터페이스 서버 시작 시 (요청 처리 전) 실행, 노드마다 기록이 다르면 가장 큰 버전
+//
+func RestoreKeyVersions() (int, error) {
+
+	versions := make(KeyVersionMap)
+
+	for _, eachAddress := range GetNodeAddresses() {
+		nodeClient := RedisClient{Address: eachAddress}
+		if err := nodeClient.getLatestDataFromLog(make(HashToDataMap), nil, versions); err != nil {
+			return 0, err
+		}
+	}
+
+	keyVersionsMutex.Lock()
+	defer keyVersionsMutex.Unlock()
+
+	for eachKey, eachVersion := range versions {
+		if eachVersion > keyVersions[eachKey] {
+			keyVersions[eachKey] = eachVersion
+		}
+	}
+
+	return len(versions), nil
+}
+
+// IsConditionFailedError : 조건부 쓰기의 조건이 맞지 않아 거절된 에러인지
+func IsConditionFailedError(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), msg.ConditionFailedMarker)
+}
+
+// RequiresApplyResult : 커밋 뒤 반영(apply) 결과까지 요청자에게 알려야 하는 엔트리인지
+//  - 조건부 쓰기 : 조건 확인은 반영 시점에 이뤄진다
+//  - 분산 트랜잭션 단계 : 코디네이터가 PREPARE 결과로 결정
+//  - PEXPIREAT / PERSIST : 없는 Key 확인은 반영 시점에 이뤄진다
+//  - INCRBY : 증가 후 값
+//  - HSET / LPUSH / RPOP / SADD : 레디스 응답 (ex. RPOP 으로 꺼낸 원소)
+//  - MONITOR_REGISTER / MONITOR_DEREGISTER : 응답 전에 모니터 목록에 반영
+//  - TOPOLOGY_* : 노드 연결 실패 등은 반영 시점에 알 수 있다
+//
+func RequiresApplyResult(entry KeyValuePair) bool {
+
+	switch entry.Command {
+	case PExpireAtCommand, PersistCommand, IncrByCommand:
+		return true
+	case MonitorRegisterCommand, MonitorDeregisterCommand:
+		return true
+	}
+
+	if IsTypedCommand(entry.Command) || IsTopologyCommand(entry.Command) {
+		return true
+	}
+
+	return entry.Condition != "" || IsTwoPhaseCommand(entry.Command)
+}
+
+// validateCondition : 지원하는 조건인지, CAS 는 기대 값/버전이 있는지
+func validateCondition(entry KeyValuePair) error {
+
+	switch entry.Condition {
+	case "", ConditionIfAbsent, ConditionIfPresent:
+		return nil
+
+	case ConditionCompareAndSwap:
+		if entry.ExpectedValue == nil && entry.ExpectedVersion == nil {
+			return fmt.Errorf(msg.CompareAndSwapWithoutExpectation, entry.Key)
+		}
+		return nil
+	}
+
+	return fmt.Errorf(msg.UnsupportedCondition, entry.Condition)
+}
+
+// setWithCondition : @entry 의 조건을 확인하며 SET, 조건이 맞지 않으면 CONFLICT 에러
+func (redisClient RedisClient) setWithCondition(entry KeyValuePair) error {
+
+	// 버전 확인과 SET 사이에 버전이 바뀌지 않도록
+	keyVersionsMutex.Lock()
+	defer keyVersionsMutex.Unlock()
+
+	var reply interface{}
+	var err error
+
+	// 만료 시각이 있으면 SET ... PX (남은 시간)
+	setArgs := []interface{}{entry.Key, entry.Value}
+	if entry.ExpireAt > 0 {
+		setArgs = append(setArgs, "PX", remainingMillisecond(entry.ExpireAt))
+	}
+
+	switch entry.Condition {
+	case ConditionIfAbsent:
+		reply, err = redisClient.Connection.Do(SetCommand, append(setArgs, "NX")...)
+
+	case ConditionIfPresent:
+		reply, err = redisClient.Connection.Do(SetCommand, append(setArgs, "XX")...)
+
+	case ConditionCompareAndSwap:
+		currentVersion := keyVersions[entry.Key]
+		if entry.ExpectedVersion != nil && *entry.ExpectedVersion != currentVersion {
+			return fmt.Errorf(
+				msg.VersionMismatch,
+				entry.Key,
+				*entry.ExpectedVersion,
+				currentVersion,
+			)
+		}
+
+		if entry.ExpectedValue != nil {
+			scriptArgs := []interface{}{entry.Key, *entry.ExpectedValue, entry.Value}
+			if entry.ExpireAt > 0 {
+				scriptArgs = append(scriptArgs, remainingMillisecond(entry.ExpireAt))
+			}
+			reply, err = compareAndSwapScript.Do(redisClient.Connection, scriptArgs...)
+		} else {
+			reply, err = redisClient.Connection.Do(SetCommand, setArgs...)
+		}
+
+	default:
+		reply, err = redisClient.Connection.Do(SetCommand, setArgs...)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// NX / XX / CAS 조건이 맞지 않으면 nil 응답
+	if reply == nil {
+		return fmt.Errorf(msg.ConditionFailed, entry.Key, entry.Condition)
+	}
+
+	keyVersions[entry.Key]++
+	redisClient.recordKeyVersion(entry.Key, keyVersions[entry.Key])
+
+	return nil
+}
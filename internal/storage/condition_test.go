@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"hash_interface/internal/hash"
+	msg "hash_interface/internal/storage/message"
+)
+
+func TestValidateCondition(t *testing.T) {
+
+	expectedValue := "before"
+	expectedVersion := uint64(1)
+
+	fixtures := []struct {
+		name        string
+		entry       KeyValuePair
+		expectedErr string
+	}{
+		{
+			name:  "조건 없음",
+			entry: KeyValuePair{Key: "key", Value: "value"},
+		},
+		{
+			name:  "없을 때만 (nx)",
+			entry: KeyValuePair{Key: "key", Value: "value", Condition: ConditionIfAbsent},
+		},
+		{
+			name:  "있을 때만 (xx)",
+			entry: KeyValuePair{Key: "key", Value: "value", Condition: ConditionIfPresent},
+		},
+		{
+			name:  "값 비교 (cas)",
+			entry: KeyValuePair{Key: "key", Value: "value", Condition: ConditionCompareAndSwap, ExpectedValue: &expectedValue},
+		},
+		{
+			name:  "버전 비교 (cas)",
+			entry: KeyValuePair{Key: "key", Value: "value", Condition: ConditionCompareAndSwap, ExpectedVersion: &expectedVersion},
+		},
+		{
+			name:        "비교할 값 / 버전 없는 cas",
+			entry:       KeyValuePair{Key: "key", Value: "value", Condition: ConditionCompareAndSwap},
+			expectedErr: fmt.Sprintf(msg.CompareAndSwapWithoutExpectation, "key"),
+		},
+		{
+			name:        "지원하지 않는 조건",
+			entry:       KeyValuePair{Key: "key", Value: "value", Condition: "unknown"},
+			expectedErr: fmt.Sprintf(msg.UnsupportedCondition, "unknown"),
+		},
+	}
+
+	for _, fixture := range fixtures {
+
+		err := ValidateEntry(fixture.entry)
+
+		if fixture.expectedErr == "" {
+			if err != nil {
+				t.Errorf("%s : ValidateEntry() 에러 %s", fixture.name, err.Error())
+			}
+			continue
+		}
+
+		if err == nil || err.Error() != fixture.expectedErr {
+			t.Errorf("%s : ValidateEntry() 에러 %v, expected : %s", fixture.name, err, fixture.expectedErr)
+		}
+	}
+}
+
+// 재시작 / Failover 후에도 데이터 로그의 VERSION 기록으로 같은 버전 (삭제된 Key 포함)
+func TestCollectLatestDataRestoresKeyVersions(t *testing.T) {
+
+	dataLog := newDataLog(
+		newLogFormat(SetCommand, "foo", "1"),
+		newLogFormat(KeyVersionCommand, "foo", "1"),
+		newLogFormat(SetCommand, "foo", "2"),
+		newLogFormat(KeyVersionCommand, "foo", "2"),
+		newLogFormat(SetCommand, "bar", "1"),
+		newLogFormat(KeyVersionCommand, "bar", "1"),
+		newLogFormat(DelCommand, "bar", ""),
+		newLogFormat(KeyVersionCommand, "bar", "2"),
+		newLogFormat(IncrByCommand, "counter", "5"),
+		newLogFormat(KeyVersionCommand, "counter", "7"),
+	)
+
+	expectedVersions := KeyVersionMap{"foo": 2, "bar": 2, "counter": 7}
+
+	dataContainer := make(HashToDataMap)
+	versionContainer := make(KeyVersionMap)
+
+	err := readDataLog(strings.NewReader(dataLog), "test", collectLatestData(dataContainer, make(KeyExpireMap), versionContainer))
+	if err != nil {
+		t.Fatalf("readDataLog() 에러 : %s", err.Error())
+	}
+
+	for key, expectedVersion := range expectedVersions {
+		if version := versionContainer[key]; version != expectedVersion {
+			t.Errorf("%s 버전 %d, expected : %d", key, version, expectedVersion)
+		}
+	}
+
+	if _, isSet := dataContainer[hash.GetHashSlotIndex("bar")]["bar"]; isSet {
+		t.Errorf("삭제된 Key(bar) 가 데이터에 남아 있습니다")
+	}
+
+	// 압축 후에도 버전 (삭제된 Key 포함) 이 남는다
+	directory, err := ioutil.TempDir("", "datalog")
+	if err != nil {
+		t.Fatalf("임시 디렉토리 생성 에러 : %s", err.Error())
+	}
+	defer os.RemoveAll(directory)
+
+	dataLogPath := filepath.Join(directory, "version.log")
+	if err := ioutil.WriteFile(dataLogPath, []byte(dataLog), 0666); err != nil {
+		t.Fatalf("데이터 로그 파일 생성 에러 : %s", err.Error())
+	}
+
+	compacted := &bytes.Buffer{}
+	if err := writeCompactedDataLog(compacted, dataLogPath, int64(len(dataLog))); err != nil {
+		t.Fatalf("writeCompactedDataLog() 에러 : %s", err.Error())
+	}
+
+	compactedVersions := make(KeyVersionMap)
+	err = readDataLog(compacted, "compacted", collectLatestData(make(HashToDataMap), make(KeyExpireMap), compactedVersions))
+	if err != nil {
+		t.Fatalf("압축된 데이터 로그 readDataLog() 에러 : %s", err.Error())
+	}
+
+	for key, expectedVersion := range expectedVersions {
+		if version := compactedVersions[key]; version != expectedVersion {
+			t.Errorf("압축 후 %s 버전 %d, expected : %d", key, version, expectedVersion)
+		}
+	}
+}
+
+func TestCollectLatestDataRejectsBrokenVersion(t *testing.T) {
+
+	dataLog := newDataLog(newLogFormat(KeyVersionCommand, "foo", "not-a-number"))
+
+	err := readDataLog(strings.NewReader(dataLog), "test", collectLatestData(make(HashToDataMap), nil, make(KeyVersionMap)))
+	expectedErr := fmt.Sprintf(msg.ParseKeyVersionError, "foo", "not-a-number")
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("readDataLog() 잘못된 버전 에러 %v, expected : %s", err, expectedErr)
+	}
+}
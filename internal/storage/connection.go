@@ -54,7 +54,7 @@ func NodeConnectionSetup(addressList []string, connectOption ConnectOption) erro
 		}
 
 		if newRedisClient.isAlreadyExist() {
-			return fmt.Errorf(msg.ClientAlreadyExist)
+			return fmt.Errorf(msg.ClientAlreadyExist, eachNodeAddress)
 		}
 
 		newRedisClient.Connection, err = redis.Dial(
@@ -100,6 +100,13 @@ func NodeConnectionSetup(addressList []string, connectOption ConnectOption) erro
 			redisMutexMap[targetMasterClient.Address] = &sync.Mutex{}
 			initMasterSlaveMaps(targetMasterClient, newRedisClient)
 
+			// Native 복제 모드 : Redis가 직접 마스터의 데이터를 복제
+			if replicationMode == NativeReplication {
+				if err := newRedisClient.replicaOf(targetMasterClient); err != nil {
+					return err
+				}
+			}
+
 			// tools.InfoLogger.Printf(
 			// 	msg.SlaveMappedToMaster,
 			// 	newRedisClient.Address,
@@ -207,7 +214,7 @@ func (slaveClient *RedisClient) connectToMaster(masterClient *RedisClient) error
 	}
 
 	// 모니터링 하는 레디스에서 제거
-	if _, err := monitorClient.ask(*slaveClient, EndConnect); err != nil {
+	if _, err := currentMonitorClient().ask(*slaveClient, EndConnect); err != nil {
 		tools.ErrorLogger.Printf("모니터 서버에 %s 제거 요청 실패!", slaveClient.Address)
 		return err
 	}
@@ -215,7 +222,7 @@ func (slaveClient *RedisClient) connectToMaster(masterClient *RedisClient) error
 	tools.ErrorLogger.Printf("모니터 서버에 %s 제거 요청 성공!", slaveClient.Address)
 
 	// 모니터 서버에게 연결 확인 요청
-	if _, err := monitorClient.ask(*slaveClient, NewConnect); err != nil {
+	if _, err := currentMonitorClient().ask(*slaveClient, NewConnect); err != nil {
 		tools.ErrorLogger.Printf("모니터 서버에 %s 등록 요청 실패!", slaveClient.Address)
 		return err
 	}
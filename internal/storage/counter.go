@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"strconv"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// IncrByCommand : Key 값을 Value(증가량, 정수) 만큼 증가
+// WAL 과 데이터 로그에는 최종 값이 아닌 증가량이 기록된다
+//
+const IncrByCommand = "INCRBY"
+
+// NewCounterEntry : @key 를 @delta 만큼 증가시키는 엔트리
+func NewCounterEntry(key string, delta int64) KeyValuePair {
+	return KeyValuePair{
+		Command: IncrByCommand,
+		Key:     key,
+		Value:   strconv.FormatInt(delta, 10),
+	}
+}
+
+// executeCounter : INCRBY 엔트리 반영, 증가 후 값 반환
+// 정수가 아닌 값이 저장된 Key는 Redis 에러
+//
+func executeCounter(entry KeyValuePair, durability Durability) (RedisClient, string, error) {
+
+	// 분산 트랜잭션이 잠근 키는 결정이 날 때까지 변경 불가
+	if err := checkKeysUnlocked([]string{entry.Key}); err != nil {
+		return RedisClient{}, "", err
+	}
+
+	// Key의 해쉬 슬롯을 담당하는 레디스 획득 (슬롯 이동 중이면 Key 위치에 따라)
+	redisClient, release, err := GetRedisClientWithKey(entry.Key)
+	if err != nil {
+		return RedisClient{}, "", err
+	}
+
+	newValue, err := redis.Int64(redisClient.Connection.Do(IncrByCommand, entry.Key, entry.Value))
+	if err != nil {
+		release()
+		return redisClient, "", err
+	}
+
+	bumpKeyVersions(redisClient, []string{entry.Key})
+
+	// 변경사항 데이터 로그 기록 (증가량)
+	err = redisClient.RecordModificationLog(IncrByCommand, entry.Key, entry.Value)
+	release()
+	if err != nil {
+		return redisClient, "", err
+	}
+
+	result := strconv.FormatInt(newValue, 10)
+
+	// 슬레이브에게 증가량 전파 (durability 설정 시 레플리카 확인까지 대기)
+	err = redisClient.ReplicateToSlaveWithAck(IncrByCommand, entry.Key, entry.Value, durability)
+
+	return redisClient, result, err
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"hash_interface/internal/hash"
+)
+
+// 슬롯 이동으로 옮겨진 (RESTORE) Key 의 INCRBY 는 재생 / 압축 후에도 RESTORE 다음에 실행된다
+func TestIncrByAfterRestore(t *testing.T) {
+
+	dump := "ZHVtcA=="
+
+	dataLog := newDataLog(
+		newLogFormat(RestoreCommand, "counter", dump),
+		newLogFormat(IncrByCommand, "counter", "5"),
+		newLogFormat(IncrByCommand, "counter", "-2"),
+	)
+
+	// 재생
+	dataContainer := make(HashToDataMap)
+	err := readDataLog(strings.NewReader(dataLog), "test", collectLatestData(dataContainer, nil, nil))
+	if err != nil {
+		t.Fatalf("readDataLog() 에러 : %s", err.Error())
+	}
+
+	expectedSteps := []logFormat{
+		newLogFormat(DelCommand, "counter", ""),
+		newLogFormat(RestoreCommand, "counter", dump),
+		newLogFormat(IncrByCommand, "counter", "5"),
+		newLogFormat(IncrByCommand, "counter", "-2"),
+	}
+
+	value := dataContainer[hash.GetHashSlotIndex("counter")]["counter"]
+	if value == nil {
+		t.Fatalf("RESTORE 된 Key(counter) 가 데이터에 없습니다")
+	}
+
+	if steps := value.replaySteps("counter"); !reflect.DeepEqual(steps, expectedSteps) {
+		t.Errorf("replaySteps() = %v, expected : %v", steps, expectedSteps)
+	}
+
+	// 압축 후 다시 재생해도 같은 명령들
+	compactedLog := newDataLog(compactTestDataLog(t, dataLog)...)
+
+	compactedContainer := make(HashToDataMap)
+	err = readDataLog(strings.NewReader(compactedLog), "compacted", collectLatestData(compactedContainer, nil, nil))
+	if err != nil {
+		t.Fatalf("압축한 데이터 로그 readDataLog() 에러 : %s", err.Error())
+	}
+
+	compactedValue := compactedContainer[hash.GetHashSlotIndex("counter")]["counter"]
+	if compactedValue == nil {
+		t.Fatalf("압축 후 RESTORE 된 Key(counter) 가 데이터에 없습니다")
+	}
+
+	if steps := compactedValue.replaySteps("counter"); !reflect.DeepEqual(steps, expectedSteps) {
+		t.Errorf("압축 후 replaySteps() = %v, expected : %v", steps, expectedSteps)
+	}
+}
@@ -0,0 +1,349 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"hash_interface/internal/hash"
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+// 데이터 로그 파일 형식 (버전 2)
+//  - 첫 줄 : 헤더 "HASHLOG 2"
+//  - 레코드 한 줄 : (해쉬값, 명령, base64(Key), base64(Value), CRC32)
+//    Key / Value 는 base64 로 기록해 공백, 줄바꿈, 바이너리 값도 그대로 복구된다
+//    빈 Key / Value 는 "-", CRC32 는 앞의 네 필드 문자열의 체크섬 (IEEE, 16진수 8자리)
+//  ex) HASHLOG 2
+//      12539 SET Zm9v YmFy 8c736521
+//
+// 버전 1 (헤더 없음, "%d %s %s %s") 파일은 MigrateDataLogFile 로 변환해야 읽을 수 있다
+//
+const (
+	DataLogVersion = 2
+
+	dataLogHeaderPrefix = "HASHLOG"
+	dataLogHeaderFormat = dataLogHeaderPrefix + " %d"
+
+	// dataLogRecordFormat : 순서대로 (해쉬값, 명령, base64(Key), base64(Value))
+	dataLogRecordFormat = "%d %s %s %s"
+	dataLogEmptyField   = "-"
+)
+
+const (
+	/* constants for "index" of Data Log each record */
+	hashIndexWord = iota
+	commandWord
+	keyWord
+	valueWord
+	checksumWord
+
+	dataLogRecordWords
+)
+
+// dataLogRecord : 데이터 로그 레코드 하나
+type dataLogRecord struct {
+	logFormat
+	hashIndex uint16
+}
+
+// encodeDataLogRecord : 데이터 로그 파일에 기록할 레코드 한 줄 (줄바꿈 제외)
+func encodeDataLogRecord(hashIndex uint16, command, key, value string) string {
+
+	record := fmt.Sprintf(
+		dataLogRecordFormat,
+		hashIndex,
+		command,
+		encodeDataLogField(key),
+		encodeDataLogField(value),
+	)
+
+	return fmt.Sprintf("%s %08x", record, crc32.ChecksumIEEE([]byte(record)))
+}
+
+// decodeDataLogRecord : encodeDataLogRecord 의 역, 체크섬이 맞지 않으면 에러
+func decodeDataLogRecord(line string) (dataLogRecord, error) {
+
+	words := strings.Split(line, " ")
+	if len(words) != dataLogRecordWords {
+		return dataLogRecord{}, fmt.Errorf(msg.DataLogRecordMalformed, line)
+	}
+
+	record := strings.Join(words[:checksumWord], " ")
+	checksum, err := strconv.ParseUint(words[checksumWord], 16, 32)
+	if err != nil || uint32(checksum) != crc32.ChecksumIEEE([]byte(record)) {
+		return dataLogRecord{}, fmt.Errorf(msg.DataLogChecksumMismatch, line)
+	}
+
+	hashIndexIn64, err := strconv.ParseUint(words[hashIndexWord], 10, 16)
+	if err != nil {
+		return dataLogRecord{}, fmt.Errorf(msg.ParseHashIndexStringError)
+	}
+
+	key, err := decodeDataLogField(words[keyWord])
+	if err != nil {
+		return dataLogRecord{}, fmt.Errorf(msg.DataLogRecordMalformed, line)
+	}
+
+	value, err := decodeDataLogField(words[valueWord])
+	if err != nil {
+		return dataLogRecord{}, fmt.Errorf(msg.DataLogRecordMalformed, line)
+	}
+
+	return dataLogRecord{
+		logFormat: newLogFormat(words[commandWord], key, value),
+		hashIndex: uint16(hashIndexIn64),
+	}, nil
+}
+
+// decodeLegacyDataLogRecord : 버전 1 레코드 ("%d %s %s %s") 해석
+// 공백으로 나누므로, Value 의 연속된 공백은 하나로 합쳐진다
+//
+func decodeLegacyDataLogRecord(line string) (dataLogRecord, error) {
+
+	words := strings.Fields(line)
+	if len(words) <= keyWord {
+		return dataLogRecord{}, fmt.Errorf(msg.DataLogRecordMalformed, line)
+	}
+
+	hashIndexIn64, err := strconv.ParseUint(words[hashIndexWord], 10, 16)
+	if err != nil {
+		return dataLogRecord{}, fmt.Errorf(msg.ParseHashIndexStringError)
+	}
+
+	value := ""
+	if len(words) > valueWord {
+		value = strings.Join(words[valueWord:], " ")
+	}
+
+	return dataLogRecord{
+		logFormat: newLogFormat(words[commandWord], words[keyWord], value),
+		hashIndex: uint16(hashIndexIn64),
+	}, nil
+}
+
+func encodeDataLogField(field string) string {
+
+	if field == "" {
+		return dataLogEmptyField
+	}
+
+	return base64.StdEncoding.EncodeToString([]byte(field))
+}
+
+func decodeDataLogField(field string) (string, error) {
+
+	if field == dataLogEmptyField {
+		return "", nil
+	}
+
+	decodedField, err := base64.StdEncoding.DecodeString(field)
+
+	return string(decodedField), err
+}
+
+// readDataLogFile : @filePath 데이터 로그 파일의 레코드들을 순서대로 @handle 에 전달
+// 마지막 레코드가 깨진 경우 (기록 도중 종료) 는 무시, 중간 레코드가 깨진 경우는 에러
+//
+func readDataLogFile(filePath string, handle func(dataLogRecord) error) error {
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf(msg.DataLogOpenError, filePath, err.Error())
+	}
+	defer file.Close()
+
+	return readDataLog(file, filePath, handle)
+}
+
+// readDataLog : @dataLog 의 레코드들을 순서대로 @handle 에 전달 (@filePath 는 에러 메세지 용)
+func readDataLog(dataLog io.Reader, filePath string, handle func(dataLogRecord) error) error {
+
+	reader := bufio.NewReader(dataLog)
+
+	header, err := readDataLogLine(reader)
+	if err == io.EOF {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf(msg.FileScannerError, err.Error())
+	}
+
+	if version, isHeader := parseDataLogHeader(header); !isHeader || version != DataLogVersion {
+		return fmt.Errorf(msg.UnsupportedDataLogVersion, filePath, header)
+	}
+
+	var brokenRecordErr error
+
+	for lineNumber := 2; ; lineNumber++ {
+
+		line, err := readDataLogLine(reader)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf(msg.FileScannerError, err.Error())
+		}
+
+		// 깨진 레코드 뒤에 레코드가 더 있으면 파일 중간이 손상된 것
+		if brokenRecordErr != nil {
+			return brokenRecordErr
+		}
+
+		record, err := decodeDataLogRecord(line)
+		if err != nil {
+			brokenRecordErr = fmt.Errorf(msg.DataLogCorrupted, filePath, lineNumber, err.Error())
+			continue
+		}
+
+		if err := handle(record); err != nil {
+			return err
+		}
+	}
+
+	if brokenRecordErr != nil {
+		tools.ErrorLogger.Printf(msg.DataLogTornRecord, filePath, brokenRecordErr.Error())
+	}
+
+	return nil
+}
+
+// readDataLogLine : 줄바꿈 전까지 한 줄 (길이 제한 없음), 줄바꿈 없이 끝난 마지막 줄도 반환
+func readDataLogLine(reader *bufio.Reader) (string, error) {
+
+	line, err := reader.ReadString('\n')
+	if err == io.EOF && line != "" {
+		return line, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(line, "\n"), nil
+}
+
+// parseDataLogHeader : 헤더 줄이면 버전 반환
+func parseDataLogHeader(line string) (int, bool) {
+
+	if !strings.HasPrefix(line, dataLogHeaderPrefix+" ") {
+		return 0, false
+	}
+
+	version, err := strconv.Atoi(strings.TrimPrefix(line, dataLogHeaderPrefix+" "))
+	if err != nil {
+		return 0, false
+	}
+
+	return version, true
+}
+
+// checkDataLogHeader : 이어서 기록할 @filePath 파일이 현재 형식인지 확인, 빈 파일이면 @isEmpty
+// 이전 형식 파일에 현재 형식 레코드를 이어 쓰면 파일 전체를 읽을 수 없게 되므로 에러
+//
+func checkDataLogHeader(filePath string) (isEmpty bool, err error) {
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false, fmt.Errorf(msg.DataLogOpenError, filePath, err.Error())
+	}
+	defer file.Close()
+
+	header, err := readDataLogLine(bufio.NewReader(file))
+	if err == io.EOF {
+		return true, nil
+	} else if err != nil {
+		return false, fmt.Errorf(msg.FileScannerError, err.Error())
+	}
+
+	if version, isHeader := parseDataLogHeader(header); !isHeader || version != DataLogVersion {
+		return false, fmt.Errorf(msg.UnsupportedDataLogVersion, filePath, header)
+	}
+
+	return false, nil
+}
+
+// MigrateDataLogFile : 버전 1 데이터 로그 파일을 현재 형식으로 변환, 변환한 레코드 수 반환
+// 이미 현재 버전인 파일은 그대로 둔다 (@isMigrated false)
+// 해쉬값은 기록된 값 대신 현재 해쉬 함수(CRC16-XMODEM, Hash Tag)로 다시 계산한다
+// 원본은 "<파일명>.v1" 로 남기고, 변환된 파일을 임시 파일에 쓴 뒤 교체한다
+// 인터페이스 서버가 실행 중이지 않을 때 사용해야 한다
+//
+func MigrateDataLogFile(filePath string) (migratedRecords int, isMigrated bool, err error) {
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return 0, false, fmt.Errorf(msg.DataLogOpenError, filePath, err.Error())
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	records := []string{}
+	for {
+		line, err := readDataLogLine(reader)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return 0, false, fmt.Errorf(msg.FileScannerError, err.Error())
+		}
+
+		if len(records) == 0 {
+			if version, isHeader := parseDataLogHeader(line); isHeader {
+				if version == DataLogVersion {
+					return 0, false, nil
+				}
+				return 0, false, fmt.Errorf(msg.UnsupportedDataLogVersion, filePath, line)
+			}
+		}
+
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		record, err := decodeLegacyDataLogRecord(line)
+		if err != nil {
+			return 0, false, err
+		}
+
+		hashIndex := hash.GetHashSlotIndex(record.Key)
+		records = append(records, encodeDataLogRecord(hashIndex, record.Command, record.Key, record.Value))
+	}
+
+	migratingPath := filePath + ".migrating"
+	migratedFile, err := os.OpenFile(migratingPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
+	if err != nil {
+		return 0, false, err
+	}
+
+	writer := bufio.NewWriter(migratedFile)
+	fmt.Fprintf(writer, dataLogHeaderFormat+"\n", DataLogVersion)
+	for _, eachRecord := range records {
+		fmt.Fprintln(writer, eachRecord)
+	}
+
+	if err := writer.Flush(); err != nil {
+		migratedFile.Close()
+		return 0, false, err
+	}
+	if err := migratedFile.Sync(); err != nil {
+		migratedFile.Close()
+		return 0, false, err
+	}
+	if err := migratedFile.Close(); err != nil {
+		return 0, false, err
+	}
+
+	if err := os.Rename(filePath, filePath+".v1"); err != nil {
+		return 0, false, err
+	}
+
+	if err := os.Rename(migratingPath, filePath); err != nil {
+		return 0, false, err
+	}
+
+	return len(records), true, nil
+}
@@ -0,0 +1,245 @@
+package storage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"hash_interface/internal/hash"
+)
+
+func TestDataLogRecordRoundTrip(t *testing.T) {
+
+	fixtures := []logFormat{
+		newLogFormat("SET", "foo", "bar"),
+		newLogFormat("SET", "key with space", "value  with   spaces"),
+		newLogFormat("SET", "line", "first\nsecond\r\n"),
+		newLogFormat("SET", "binary", string([]byte{0x00, 0xff, 0x20, 0x0a})),
+		newLogFormat("DEL", "foo", ""),
+		newLogFormat("SET", "", ""),
+	}
+
+	for _, fixture := range fixtures {
+
+		hashIndex := hash.GetHashSlotIndex(fixture.Key)
+		line := encodeDataLogRecord(hashIndex, fixture.Command, fixture.Key, fixture.Value)
+
+		if strings.Contains(line, "\n") {
+			t.Errorf("encodeDataLogRecord(%q, %q) 에 줄바꿈이 있습니다 : %q", fixture.Key, fixture.Value, line)
+		}
+
+		record, err := decodeDataLogRecord(line)
+		if err != nil {
+			t.Errorf("decodeDataLogRecord(%q) 에러 : %s", line, err.Error())
+			continue
+		}
+
+		if record.hashIndex != hashIndex || record.Command != fixture.Command ||
+			record.Key != fixture.Key || record.Value != fixture.Value {
+
+			t.Errorf(
+				"decodeDataLogRecord(%q) = (%d, %s, %q, %q), expected : (%d, %s, %q, %q)",
+				line,
+				record.hashIndex, record.Command, record.Key, record.Value,
+				hashIndex, fixture.Command, fixture.Key, fixture.Value,
+			)
+		}
+	}
+}
+
+func TestDecodeDataLogRecordRejectsBrokenRecord(t *testing.T) {
+
+	validLine := encodeDataLogRecord(hash.GetHashSlotIndex("foo"), "SET", "foo", "bar")
+	words := strings.Split(validLine, " ")
+
+	fixtures := map[string]string{
+		"체크섬 불일치":  strings.Join(append(append([]string{}, words[:checksumWord]...), "00000000"), " "),
+		"값 변경":     strings.Replace(validLine, words[valueWord], encodeDataLogField("baz"), 1),
+		"필드 누락":    strings.Join(words[:checksumWord], " "),
+		"기록 도중 종료": validLine[:len(validLine)/2],
+		"버전 1 레코드": "12182 SET foo bar",
+		"빈 줄":      "",
+	}
+
+	for name, line := range fixtures {
+		if _, err := decodeDataLogRecord(line); err == nil {
+			t.Errorf("%s : decodeDataLogRecord(%q) 에러가 없습니다", name, line)
+		}
+	}
+}
+
+func TestReadDataLog(t *testing.T) {
+
+	header := fmt.Sprintf(dataLogHeaderFormat, DataLogVersion)
+	first := encodeDataLogRecord(hash.GetHashSlotIndex("foo"), "SET", "foo", "bar")
+	second := encodeDataLogRecord(hash.GetHashSlotIndex("hello"), "SET", "hello", "world")
+
+	fixtures := []struct {
+		name         string
+		dataLog      string
+		expectedKeys []string
+		isErr        bool
+	}{
+		{"빈 파일", "", nil, false},
+		{"헤더만", header + "\n", nil, false},
+		{"정상", strings.Join([]string{header, first, second}, "\n") + "\n", []string{"foo", "hello"}, false},
+		{"마지막 줄바꿈 없음", strings.Join([]string{header, first, second}, "\n"), []string{"foo", "hello"}, false},
+		{"마지막 레코드 손상", strings.Join([]string{header, first, second[:10]}, "\n"), []string{"foo"}, false},
+		{"중간 레코드 손상", strings.Join([]string{header, first[:10], second}, "\n"), nil, true},
+		{"헤더 없음 (버전 1)", "12182 SET foo bar\n", nil, true},
+		{"다른 버전", dataLogHeaderPrefix + " 3\n" + first + "\n", nil, true},
+	}
+
+	for _, fixture := range fixtures {
+
+		keys := []string{}
+		err := readDataLog(strings.NewReader(fixture.dataLog), fixture.name, func(record dataLogRecord) error {
+			keys = append(keys, record.Key)
+			return nil
+		})
+
+		if (err != nil) != fixture.isErr {
+			t.Errorf("%s : readDataLog() 에러 %v, expected 에러 : %v", fixture.name, err, fixture.isErr)
+			continue
+		}
+
+		if fixture.isErr {
+			continue
+		}
+
+		if strings.Join(keys, ",") != strings.Join(fixture.expectedKeys, ",") {
+			t.Errorf("%s : readDataLog() keys %v, expected : %v", fixture.name, keys, fixture.expectedKeys)
+		}
+	}
+}
+
+func TestMigrateDataLogFile(t *testing.T) {
+
+	directory, err := ioutil.TempDir("", "datalog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	// 버전 1 레코드, 해쉬값은 이전 해쉬 함수 기준 (CRC16-CCITT, Hash Tag 없음)
+	legacyRecords := []struct {
+		legacyIndex uint16
+		command     string
+		key         string
+		value       string
+	}{
+		{9308, "SET", "foo", "bar"},
+		{1234, "SET", "{user}:1", "value with spaces"},
+		{4321, "DEL", "foo", ""},
+		{777, "SET", "{user}:2", "x"},
+	}
+
+	legacyLines := []string{}
+	for _, eachRecord := range legacyRecords {
+		legacyLines = append(
+			legacyLines,
+			fmt.Sprintf(dataLogRecordFormat, eachRecord.legacyIndex, eachRecord.command, eachRecord.key, eachRecord.value),
+		)
+	}
+
+	filePath := filepath.Join(directory, "127.0.0.1:6379")
+	if err := ioutil.WriteFile(filePath, []byte(strings.Join(legacyLines, "\n")+"\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := checkDataLogHeader(filePath); err == nil {
+		t.Errorf("checkDataLogHeader() 버전 1 파일에 에러가 없습니다")
+	}
+
+	migratedRecords, isMigrated, err := MigrateDataLogFile(filePath)
+	if err != nil {
+		t.Fatalf("MigrateDataLogFile() 에러 : %s", err.Error())
+	}
+
+	if !isMigrated || migratedRecords != len(legacyRecords) {
+		t.Errorf("MigrateDataLogFile() = (%d, %v), expected : (%d, true)", migratedRecords, isMigrated, len(legacyRecords))
+	}
+
+	if _, err := os.Stat(filePath + ".v1"); err != nil {
+		t.Errorf("변환 전 원본(.v1)이 없습니다 : %s", err.Error())
+	}
+
+	if isEmpty, err := checkDataLogHeader(filePath); err != nil || isEmpty {
+		t.Errorf("checkDataLogHeader() = (%v, %v), expected : (false, nil)", isEmpty, err)
+	}
+
+	i := 0
+	err = readDataLogFile(filePath, func(record dataLogRecord) error {
+
+		expected := legacyRecords[i]
+		i++
+
+		if record.hashIndex != hash.GetHashSlotIndex(expected.key) {
+			t.Errorf("%s 해쉬값 %d, expected : %d", expected.key, record.hashIndex, hash.GetHashSlotIndex(expected.key))
+		}
+
+		if record.Command != expected.command || record.Key != expected.key || record.Value != expected.value {
+			t.Errorf(
+				"변환된 레코드 (%s, %q, %q), expected : (%s, %q, %q)",
+				record.Command, record.Key, record.Value,
+				expected.command, expected.key, expected.value,
+			)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("readDataLogFile() 에러 : %s", err.Error())
+	}
+
+	if i != len(legacyRecords) {
+		t.Errorf("변환된 레코드 %d 개, expected : %d", i, len(legacyRecords))
+	}
+
+	// 이미 변환된 파일
+	if _, isMigrated, err := MigrateDataLogFile(filePath); err != nil || isMigrated {
+		t.Errorf("MigrateDataLogFile() 두 번째 = (%v, %v), expected : (false, nil)", isMigrated, err)
+	}
+}
+
+func TestCheckDataLogHeader(t *testing.T) {
+
+	directory, err := ioutil.TempDir("", "datalog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	header := fmt.Sprintf(dataLogHeaderFormat, DataLogVersion)
+
+	fixtures := []struct {
+		name            string
+		dataLog         string
+		expectedIsEmpty bool
+		isErr           bool
+	}{
+		{"빈 파일", "", true, false},
+		{"현재 형식", header + "\n", false, false},
+		{"버전 1", "12182 SET foo bar\n", false, true},
+		{"다른 버전", dataLogHeaderPrefix + " 1\n", false, true},
+	}
+
+	for i, fixture := range fixtures {
+
+		filePath := filepath.Join(directory, fmt.Sprintf("node%d", i))
+		if err := ioutil.WriteFile(filePath, []byte(fixture.dataLog), 0666); err != nil {
+			t.Fatal(err)
+		}
+
+		isEmpty, err := checkDataLogHeader(filePath)
+		if (err != nil) != fixture.isErr || isEmpty != fixture.expectedIsEmpty {
+			t.Errorf(
+				"%s : checkDataLogHeader() = (%v, %v), expected : (%v, 에러 %v)",
+				fixture.name, isEmpty, err, fixture.expectedIsEmpty, fixture.isErr,
+			)
+		}
+	}
+}
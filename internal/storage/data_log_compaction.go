@@ -0,0 +1,378 @@
+package storage
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"hash_interface/internal/hash"
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+// DataLogCompactionPolicy : 데이터 로그 자동 압축 조건 (Redis 의 auto-aof-rewrite 와 유사)
+//  - MinSize : 이보다 작은 파일은 압축하지 않는다 (bytes)
+//  - GrowthPercentage : 마지막 압축 직후 크기보다 이만큼(%) 커지면 압축 (0 이면 사용 안 함)
+//  - MaxAge : 마지막 압축 후 이 시간이 지났고 그동안 기록이 있었으면 압축 (0 이면 사용 안 함)
+//
+type DataLogCompactionPolicy struct {
+	MinSize          int64
+	GrowthPercentage int64
+	MaxAge           time.Duration
+}
+
+// DefaultDataLogCompactionPolicy : 1MB 이상이면서 마지막 압축 후 두 배가 되었거나, 1시간이 지난 경우
+var DefaultDataLogCompactionPolicy = DataLogCompactionPolicy{
+	MinSize:          1 << 20,
+	GrowthPercentage: 100,
+	MaxAge:           1 * time.Hour,
+}
+
+// dataLogCompactingSuffix : 압축 중인 임시 파일 (<노드 주소>.compacting)
+const dataLogCompactingSuffix = ".compacting"
+
+// StartDataLogCompaction : @interval 마다 각 노드의 데이터 로그 크기를 확인해 @policy 에 맞으면 압축
+func StartDataLogCompaction(interval time.Duration, policy DataLogCompactionPolicy) {
+
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		for range ticker.C {
+			for _, eachAddress := range dataLogsToCompact(policy, time.Now()) {
+
+				beforeSize, afterSize, err := CompactDataLog(eachAddress)
+				if err != nil {
+					tools.ErrorLogger.Printf(msg.DataLogCompactionFail, eachAddress, err.Error())
+					continue
+				}
+
+				tools.InfoLogger.Printf(msg.DataLogCompacted, eachAddress, beforeSize, afterSize)
+			}
+		}
+	}()
+}
+
+// dataLogsToCompact : @policy 에 따라 압축할 노드 주소들
+func dataLogsToCompact(policy DataLogCompactionPolicy, now time.Time) []string {
+
+	dataLogsMutex.RLock()
+	defer dataLogsMutex.RUnlock()
+
+	addresses := []string{}
+	for eachAddress, eachDataLog := range dataLogs {
+
+		if eachDataLog.isCompacting {
+			continue
+		}
+
+		size, err := eachDataLog.size()
+		if err != nil {
+			continue
+		}
+
+		if policy.shouldCompact(size, eachDataLog.compactedSize, now.Sub(eachDataLog.compactedAt)) {
+			addresses = append(addresses, eachAddress)
+		}
+	}
+
+	return addresses
+}
+
+// shouldCompact : 현재 크기 @size, 마지막 압축 직후 크기 @compactedSize, 마지막 압축 후 지난 시간 @age
+func (policy DataLogCompactionPolicy) shouldCompact(size, compactedSize int64, age time.Duration) bool {
+
+	if size < policy.MinSize || size <= compactedSize {
+		return false
+	}
+
+	if policy.GrowthPercentage > 0 && size >= compactedSize+compactedSize*policy.GrowthPercentage/100 {
+		return true
+	}
+
+	return policy.MaxAge > 0 && age >= policy.MaxAge
+}
+
+// CompactDataLog : @address 노드의 데이터 로그를 Key 별 최신 상태만 남도록 다시 쓴다 (AOF rewrite)
+// 압축 전후 파일 크기 반환
+//  1. 현재 파일 크기까지의 레코드로 최신 상태를 만들어 임시 파일에 기록 (그동안의 기록은 기존 파일에 계속)
+//  2. 기록을 잠시 막고, 그 사이 기존 파일에 추가된 레코드를 임시 파일 뒤에 그대로 복사
+//  3. 임시 파일로 교체 (rename), 이후 기록은 새 파일에
+//
+func CompactDataLog(address string) (beforeSize int64, afterSize int64, err error) {
+
+	dataLogsMutex.Lock()
+	targetDataLog, isSet := dataLogs[address]
+	if !isSet {
+		dataLogsMutex.Unlock()
+		return 0, 0, fmt.Errorf(msg.DataLoggerSetupError)
+	}
+	if targetDataLog.isCompacting {
+		dataLogsMutex.Unlock()
+		return 0, 0, fmt.Errorf(msg.DataLogCompactionInProgress, address)
+	}
+
+	// 기록 중인 레코드가 없는 시점의 크기 (레코드 경계)
+	snapshotSize, err := targetDataLog.size()
+	if err != nil {
+		dataLogsMutex.Unlock()
+		return 0, 0, err
+	}
+
+	targetDataLog.isCompacting = true
+	dataLogsMutex.Unlock()
+
+	defer func() {
+		dataLogsMutex.Lock()
+		targetDataLog.isCompacting = false
+		dataLogsMutex.Unlock()
+	}()
+
+	filePath := dataLogFilePath(address)
+	compactingPath := filePath + dataLogCompactingSuffix
+
+	compactedFile, err := os.OpenFile(compactingPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC|os.O_APPEND, 0666)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	abort := func(err error) (int64, int64, error) {
+		compactedFile.Close()
+		os.Remove(compactingPath)
+		return 0, 0, err
+	}
+
+	if err := writeCompactedDataLog(compactedFile, filePath, snapshotSize); err != nil {
+		return abort(err)
+	}
+
+	dataLogsMutex.Lock()
+	defer dataLogsMutex.Unlock()
+
+	// 압축 중 노드가 제거되어 데이터 로그 파일이 삭제/재생성된 경우
+	if dataLogs[address] != targetDataLog {
+		return abort(fmt.Errorf(msg.DataLogCompactionAborted, address))
+	}
+
+	beforeSize, err = targetDataLog.size()
+	if err != nil {
+		return abort(err)
+	}
+
+	// 압축하는 동안 추가된 레코드
+	if err := copyDataLogTail(compactedFile, filePath, snapshotSize); err != nil {
+		return abort(err)
+	}
+
+	if err := compactedFile.Sync(); err != nil {
+		return abort(err)
+	}
+
+	if err := os.Rename(compactingPath, filePath); err != nil {
+		return abort(err)
+	}
+	syncDirectory(logDirectory)
+
+	// 교체된 파일에 이어서 기록 (열린 파일은 rename 후에도 같은 파일)
+	targetDataLog.file.Close()
+	targetDataLog.file = compactedFile
+	targetDataLog.logger = log.New(compactedFile, "", 0)
+
+	afterSize, _ = targetDataLog.size()
+	targetDataLog.compactedSize = afterSize
+	targetDataLog.compactedAt = time.Now()
+
+	return beforeSize, afterSize, nil
+}
+
+// writeCompactedDataLog : @filePath 의 처음 @snapshotSize bytes 를 읽어, Key 별 최신 상태만 @writer 에 기록
+//  - 문자열 Key : SET
+//  - 자료형 Key : 명령들을 하나로 합친 HSET / LPUSH / SADD (RESTORE 된 Key 는 RESTORE 와 이후 명령들)
+//  - 만료 시각이 있으면 PEXPIREAT
+//  - Key 의 버전 (삭제된 Key 포함) VERSION
+//
+func writeCompactedDataLog(writer io.Writer, filePath string, snapshotSize int64) error {
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf(msg.DataLogOpenError, filePath, err.Error())
+	}
+	defer file.Close()
+
+	dataContainer := make(HashToDataMap)
+	expireAtContainer := make(KeyExpireMap)
+	versionContainer := make(KeyVersionMap)
+
+	snapshot := io.LimitReader(file, snapshotSize)
+	if err := readDataLog(snapshot, filePath, collectLatestData(dataContainer, expireAtContainer, versionContainer)); err != nil {
+		return err
+	}
+
+	removeExpiredData(dataContainer, expireAtContainer)
+
+	bufferedWriter := bufio.NewWriter(writer)
+	fmt.Fprintf(bufferedWriter, dataLogHeaderFormat+"\n", DataLogVersion)
+
+	for hashIndex, keyValueMap := range dataContainer {
+		for eachKey, eachValue := range keyValueMap {
+
+			steps, err := eachValue.compactedSteps(eachKey)
+			if err != nil {
+				return err
+			}
+
+			// 모든 원소를 꺼낸 리스트는 레디스에서도 없는 Key
+			if len(steps) == 0 {
+				continue
+			}
+
+			for _, eachStep := range steps {
+				fmt.Fprintln(
+					bufferedWriter,
+					encodeDataLogRecord(hashIndex, eachStep.Command, eachStep.Key, eachStep.Value),
+				)
+			}
+
+			if expireAt, isSet := expireAtContainer[eachKey]; isSet {
+				fmt.Fprintln(
+					bufferedWriter,
+					encodeDataLogRecord(hashIndex, PExpireAtCommand, eachKey, strconv.FormatInt(expireAt, 10)),
+				)
+			}
+		}
+	}
+
+	// 삭제된 Key 의 버전도 남긴다 (다시 쓰여도 이전 버전으로 CAS 가 통과하지 않도록)
+	for eachKey, eachVersion := range versionContainer {
+		fmt.Fprintln(
+			bufferedWriter,
+			encodeDataLogRecord(hash.GetHashSlotIndex(eachKey), KeyVersionCommand, eachKey, strconv.FormatUint(eachVersion, 10)),
+		)
+	}
+
+	return bufferedWriter.Flush()
+}
+
+// compactedSteps : 압축된 데이터 로그에 남길 @key 의 (명령, Key, Value) 목록
+// 새 파일에는 이전 값이 없으므로 replaySteps 의 DEL 은 생략한다
+// RESTORE 된 Key 는 직렬화 값을 해석할 수 없으므로 이후 명령들을 합치지 않는다
+//
+func (value *loggedValue) compactedSteps(key string) ([]logFormat, error) {
+
+	if !value.isTyped() {
+		return value.replaySteps(key), nil
+	}
+
+	if value.dump != "" {
+		return value.replaySteps(key)[1:], nil
+	}
+
+	return foldTypedCommands(key, value.commands)
+}
+
+// foldTypedCommands : 같은 Key 에 대한 자료형 명령들을 최종 상태를 만드는 명령 하나로 합친다
+//  - HSET : 필드 별 마지막 값 (처음 추가된 순서)
+//  - LPUSH / RPOP : 남은 원소들 (리스트 오른쪽 원소부터 LPUSH)
+//  - SADD : 중복 없는 원소들 (처음 추가된 순서)
+// 남은 원소가 없으면 빈 목록
+//
+func foldTypedCommands(key string, commands []logFormat) ([]logFormat, error) {
+
+	if len(commands) == 0 {
+		return nil, nil
+	}
+
+	command := commands[0].Command
+	if command == RPopCommand {
+		command = LPushCommand
+	}
+
+	// 리스트는 왼쪽 원소부터, 그 외는 처음 추가된 순서
+	elements := []string{}
+	hashFields := make(map[string]int)
+	isMember := make(map[string]bool)
+
+	for _, eachCommand := range commands {
+
+		args, err := decodeTypedArgs(eachCommand.Value)
+		if err != nil {
+			return nil, fmt.Errorf(msg.ParseTypedArgsError, key, eachCommand.Command, eachCommand.Value)
+		}
+
+		switch eachCommand.Command {
+		case HSetCommand:
+			for i := 0; i+1 < len(args); i += 2 {
+				if index, isSet := hashFields[args[i]]; isSet {
+					elements[index+1] = args[i+1]
+					continue
+				}
+				hashFields[args[i]] = len(elements)
+				elements = append(elements, args[i], args[i+1])
+			}
+
+		case LPushCommand:
+			for _, eachArg := range args {
+				elements = append([]string{eachArg}, elements...)
+			}
+
+		case RPopCommand:
+			if len(elements) > 0 {
+				elements = elements[:len(elements)-1]
+			}
+
+		case SAddCommand:
+			for _, eachArg := range args {
+				if !isMember[eachArg] {
+					isMember[eachArg] = true
+					elements = append(elements, eachArg)
+				}
+			}
+		}
+	}
+
+	if len(elements) == 0 {
+		return nil, nil
+	}
+
+	// LPUSH 는 인자를 차례로 왼쪽에 넣으므로, 오른쪽 원소부터
+	if command == LPushCommand {
+		for i, j := 0, len(elements)-1; i < j; i, j = i+1, j-1 {
+			elements[i], elements[j] = elements[j], elements[i]
+		}
+	}
+
+	return []logFormat{newLogFormat(command, key, encodeTypedArgs(elements))}, nil
+}
+
+// copyDataLogTail : @filePath 의 @offset 이후 (압축 중 추가된 레코드) 를 @writer 에 복사
+func copyDataLogTail(writer io.Writer, filePath string, offset int64) error {
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf(msg.DataLogOpenError, filePath, err.Error())
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(writer, file)
+
+	return err
+}
+
+// syncDirectory : rename 결과가 디스크에 남도록 디렉토리도 동기화 (실패해도 교체는 이미 끝남)
+func syncDirectory(directory string) {
+
+	dir, err := os.Open(directory)
+	if err != nil {
+		return
+	}
+	defer dir.Close()
+
+	dir.Sync()
+}
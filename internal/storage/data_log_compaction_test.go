@@ -0,0 +1,287 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"hash_interface/internal/hash"
+	msg "hash_interface/internal/storage/message"
+)
+
+// newDataLog : (명령, Key, Value) 레코드들로 만든 현재 형식의 데이터 로그
+func newDataLog(records ...logFormat) string {
+
+	lines := []string{fmt.Sprintf(dataLogHeaderFormat, DataLogVersion)}
+	for _, eachRecord := range records {
+		lines = append(
+			lines,
+			encodeDataLogRecord(hash.GetHashSlotIndex(eachRecord.Key), eachRecord.Command, eachRecord.Key, eachRecord.Value),
+		)
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// compactTestDataLog : @dataLog 를 파일로 쓴 뒤 압축한 레코드들 (압축 파일에 쓰인 순서)
+func compactTestDataLog(t *testing.T, dataLog string) []logFormat {
+
+	directory, err := ioutil.TempDir("", "datalog")
+	if err != nil {
+		t.Fatalf("임시 디렉토리 생성 에러 : %s", err.Error())
+	}
+	defer os.RemoveAll(directory)
+
+	dataLogPath := filepath.Join(directory, "compaction.log")
+	if err := ioutil.WriteFile(dataLogPath, []byte(dataLog), 0666); err != nil {
+		t.Fatalf("데이터 로그 파일 생성 에러 : %s", err.Error())
+	}
+
+	compacted := &bytes.Buffer{}
+	if err := writeCompactedDataLog(compacted, dataLogPath, int64(len(dataLog))); err != nil {
+		t.Fatalf("writeCompactedDataLog() 에러 : %s", err.Error())
+	}
+
+	records := []logFormat{}
+	err = readDataLog(compacted, "compacted", func(record dataLogRecord) error {
+		records = append(records, record.logFormat)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("압축한 데이터 로그 readDataLog() 에러 : %s", err.Error())
+	}
+
+	return records
+}
+
+func TestFoldTypedCommands(t *testing.T) {
+
+	fixtures := []struct {
+		name        string
+		commands    []logFormat
+		expected    []logFormat
+		expectedErr string
+	}{
+		{
+			name: "HSET 필드 별 마지막 값 (처음 추가된 순서)",
+			commands: []logFormat{
+				newLogFormat(HSetCommand, "key", encodeTypedArgs([]string{"a", "1", "b", "2"})),
+				newLogFormat(HSetCommand, "key", encodeTypedArgs([]string{"a", "3"})),
+			},
+			expected: []logFormat{
+				newLogFormat(HSetCommand, "key", encodeTypedArgs([]string{"a", "3", "b", "2"})),
+			},
+		},
+		{
+			name: "LPUSH / RPOP 후 남은 원소 (오른쪽 원소부터)",
+			commands: []logFormat{
+				newLogFormat(LPushCommand, "key", encodeTypedArgs([]string{"a", "b"})),
+				newLogFormat(LPushCommand, "key", encodeTypedArgs([]string{"c"})),
+				newLogFormat(RPopCommand, "key", ""),
+			},
+			expected: []logFormat{
+				newLogFormat(LPushCommand, "key", encodeTypedArgs([]string{"b", "c"})),
+			},
+		},
+		{
+			name: "RPOP 으로 시작해도 LPUSH",
+			commands: []logFormat{
+				newLogFormat(RPopCommand, "key", ""),
+				newLogFormat(LPushCommand, "key", encodeTypedArgs([]string{"a"})),
+			},
+			expected: []logFormat{
+				newLogFormat(LPushCommand, "key", encodeTypedArgs([]string{"a"})),
+			},
+		},
+		{
+			name: "모든 원소를 꺼낸 리스트",
+			commands: []logFormat{
+				newLogFormat(LPushCommand, "key", encodeTypedArgs([]string{"a"})),
+				newLogFormat(RPopCommand, "key", ""),
+			},
+			expected: nil,
+		},
+		{
+			name: "SADD 중복 제거 (처음 추가된 순서)",
+			commands: []logFormat{
+				newLogFormat(SAddCommand, "key", encodeTypedArgs([]string{"a", "b"})),
+				newLogFormat(SAddCommand, "key", encodeTypedArgs([]string{"b", "c"})),
+			},
+			expected: []logFormat{
+				newLogFormat(SAddCommand, "key", encodeTypedArgs([]string{"a", "b", "c"})),
+			},
+		},
+		{
+			name:     "명령 없음",
+			expected: nil,
+		},
+		{
+			name: "잘못된 인자",
+			commands: []logFormat{
+				newLogFormat(SAddCommand, "key", "broken"),
+			},
+			expectedErr: fmt.Sprintf(msg.ParseTypedArgsError, "key", SAddCommand, "broken"),
+		},
+	}
+
+	for _, fixture := range fixtures {
+
+		folded, err := foldTypedCommands("key", fixture.commands)
+
+		if fixture.expectedErr != "" {
+			if err == nil || err.Error() != fixture.expectedErr {
+				t.Errorf("%s : foldTypedCommands() 에러 %v, expected : %s", fixture.name, err, fixture.expectedErr)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s : foldTypedCommands() 에러 : %s", fixture.name, err.Error())
+			continue
+		}
+
+		if !reflect.DeepEqual(folded, fixture.expected) {
+			t.Errorf("%s : foldTypedCommands() = %v, expected : %v", fixture.name, folded, fixture.expected)
+		}
+	}
+}
+
+func TestShouldCompact(t *testing.T) {
+
+	policy := DataLogCompactionPolicy{
+		MinSize:          100,
+		GrowthPercentage: 100,
+		MaxAge:           time.Hour,
+	}
+
+	fixtures := []struct {
+		name          string
+		size          int64
+		compactedSize int64
+		age           time.Duration
+		expected      bool
+	}{
+		{"최소 크기 미만", 99, 0, 2 * time.Hour, false},
+		{"압축 후 기록 없음", 200, 200, 2 * time.Hour, false},
+		{"두 배로 커짐", 200, 100, 0, true},
+		{"두 배 미만", 199, 100, 0, false},
+		{"압축 후 오래 지남", 150, 100, 2 * time.Hour, true},
+	}
+
+	for _, fixture := range fixtures {
+
+		result := policy.shouldCompact(fixture.size, fixture.compactedSize, fixture.age)
+		if result != fixture.expected {
+			t.Errorf("%s : shouldCompact() = %v, expected : %v", fixture.name, result, fixture.expected)
+		}
+	}
+}
+
+// 압축한 데이터 로그에는 Key 별 최신 상태 (만료된 Key, 삭제된 Key 제외) 만 남는다
+func TestWriteCompactedDataLog(t *testing.T) {
+
+	futureExpireAt := "99999999999999"
+
+	fixtures := []struct {
+		name     string
+		records  []logFormat
+		expected []logFormat
+	}{
+		{
+			name: "문자열 Key 의 마지막 값",
+			records: []logFormat{
+				newLogFormat(SetCommand, "string", "1"),
+				newLogFormat(SetCommand, "string", "2"),
+			},
+			expected: []logFormat{
+				newLogFormat(SetCommand, "string", "2"),
+			},
+		},
+		{
+			name: "삭제된 Key",
+			records: []logFormat{
+				newLogFormat(SetCommand, "deleted", "1"),
+				newLogFormat(DelCommand, "deleted", ""),
+			},
+			expected: []logFormat{},
+		},
+		{
+			name: "만료된 Key 는 제외, 만료 전인 Key 는 만료 시각과 함께",
+			records: []logFormat{
+				newLogFormat(SetCommand, "expired", "1"),
+				newLogFormat(PExpireAtCommand, "expired", "1"),
+				newLogFormat(SetCommand, "expiring", "1"),
+				newLogFormat(PExpireAtCommand, "expiring", futureExpireAt),
+			},
+			expected: []logFormat{
+				newLogFormat(PExpireAtCommand, "expiring", futureExpireAt),
+				newLogFormat(SetCommand, "expiring", "1"),
+			},
+		},
+		{
+			name: "INCRBY 는 증가 후 값으로",
+			records: []logFormat{
+				newLogFormat(SetCommand, "counter", "1"),
+				newLogFormat(IncrByCommand, "counter", "5"),
+			},
+			expected: []logFormat{
+				newLogFormat(SetCommand, "counter", "6"),
+			},
+		},
+		{
+			name: "RESTORE 된 Key 의 INCRBY 는 RESTORE 이후 명령으로",
+			records: []logFormat{
+				newLogFormat(RestoreCommand, "counter", "ZHVtcA=="),
+				newLogFormat(IncrByCommand, "counter", "5"),
+				newLogFormat(IncrByCommand, "counter", "-2"),
+			},
+			expected: []logFormat{
+				newLogFormat(IncrByCommand, "counter", "5"),
+				newLogFormat(IncrByCommand, "counter", "-2"),
+				newLogFormat(RestoreCommand, "counter", "ZHVtcA=="),
+			},
+		},
+		{
+			name: "자료형 명령은 하나로",
+			records: []logFormat{
+				newLogFormat(SAddCommand, "set", encodeTypedArgs([]string{"a"})),
+				newLogFormat(SAddCommand, "set", encodeTypedArgs([]string{"a", "b"})),
+			},
+			expected: []logFormat{
+				newLogFormat(SAddCommand, "set", encodeTypedArgs([]string{"a", "b"})),
+			},
+		},
+		{
+			name: "모든 원소를 꺼낸 리스트",
+			records: []logFormat{
+				newLogFormat(LPushCommand, "popped", encodeTypedArgs([]string{"a"})),
+				newLogFormat(RPopCommand, "popped", ""),
+			},
+			expected: []logFormat{},
+		},
+	}
+
+	for _, fixture := range fixtures {
+
+		records := compactTestDataLog(t, newDataLog(fixture.records...))
+
+		// Key 순서는 정해져 있지 않다
+		sort.SliceStable(records, func(i, j int) bool {
+			if records[i].Command != records[j].Command {
+				return records[i].Command < records[j].Command
+			}
+			return records[i].Key < records[j].Key
+		})
+
+		if !reflect.DeepEqual(records, fixture.expected) {
+			t.Errorf("%s : 압축한 데이터 로그 %v, expected : %v", fixture.name, records, fixture.expected)
+		}
+	}
+}
@@ -0,0 +1,368 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gomodule/redigo/redis"
+
+	msg "hash_interface/internal/storage/message"
+)
+
+// 문자열 외 자료형 (Hash / List / Set) 쓰기 명령
+//  - HSET : Field 에 Value 저장
+//  - LPUSH : Values 를 리스트 왼쪽에 추가
+//  - RPOP : 리스트 오른쪽 원소 하나 꺼냄
+//  - SADD : Values 를 집합에 추가
+// 데이터 로그와 슬레이브 전파에는 인자들을 JSON 배열(각 인자는 base64) 하나로 묶어 Value 자리에 기록한다
+//  ex) HSET user ["bmFtZQ==","a2lt"] (name, kim), RPOP queue
+//
+const (
+	HSetCommand  = "HSET"
+	LPushCommand = "LPUSH"
+	RPopCommand  = "RPOP"
+	SAddCommand  = "SADD"
+
+	// RestoreCommand : 슬롯 이동으로 옮겨진 Key, Value 에 DUMP 직렬화 값 (base64)
+	// 자료형과 관계없이 Key 전체를 그대로 옮기므로 데이터 로그 / 슬레이브 전파에만 사용한다
+	RestoreCommand = "RESTORE"
+)
+
+// Redis TYPE 응답
+const (
+	StringType = "string"
+	HashType   = "hash"
+	ListType   = "list"
+	SetType    = "set"
+)
+
+// IsTypedCommand : 문자열 외 자료형 쓰기 명령인지
+func IsTypedCommand(command string) bool {
+
+	switch command {
+	case HSetCommand, LPushCommand, RPopCommand, SAddCommand:
+		return true
+	}
+
+	return false
+}
+
+// IsWrongTypeError : Key 의 자료형과 맞지 않는 명령이라 거절된 에러인지
+func IsWrongTypeError(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), msg.WrongTypeMarker)
+}
+
+// NewHashEntry : @key 의 @field 에 @value 를 저장하는 엔트리
+func NewHashEntry(key, field, value string) KeyValuePair {
+	return KeyValuePair{
+		Command: HSetCommand,
+		Key:     key,
+		Field:   field,
+		Value:   value,
+	}
+}
+
+// NewElementsEntry : @key 에 @values 를 추가하는 엔트리 (LPUSH / SADD)
+func NewElementsEntry(command, key string, values []string) KeyValuePair {
+	return KeyValuePair{
+		Command: command,
+		Key:     key,
+		Values:  values,
+	}
+}
+
+// validateTypedEntry : 자료형 쓰기 명령에 필요한 인자가 있는지
+func validateTypedEntry(entry KeyValuePair) error {
+
+	switch entry.Command {
+	case HSetCommand:
+		if entry.Field == "" {
+			return fmt.Errorf(msg.HashFieldMissing, entry.Key)
+		}
+
+	case LPushCommand, SAddCommand:
+		if len(entry.Values) == 0 {
+			return fmt.Errorf(msg.EmptyElements, entry.Key, entry.Command)
+		}
+	}
+
+	return nil
+}
+
+// typedArgs : 자료형 쓰기 명령의 Key 뒤 인자들
+func typedArgs(entry KeyValuePair) []string {
+
+	switch entry.Command {
+	case HSetCommand:
+		return []string{entry.Field, entry.Value}
+
+	case LPushCommand, SAddCommand:
+		return entry.Values
+	}
+
+	return nil
+}
+
+// encodeTypedArgs : 데이터 로그 / 슬레이브 전파용 인자 문자열, 인자가 없으면 빈 문자열
+// 바이너리 인자도 그대로 복구되도록 각 인자는 base64 로 (JSON 배열)
+//
+func encodeTypedArgs(args []string) string {
+
+	if len(args) == 0 {
+		return ""
+	}
+
+	binaryArgs := make([][]byte, len(args))
+	for i, eachArg := range args {
+		binaryArgs[i] = []byte(eachArg)
+	}
+
+	encodedArgs, _ := json.Marshal(binaryArgs)
+
+	return string(encodedArgs)
+}
+
+// decodeTypedArgs : encodeTypedArgs 의 역
+func decodeTypedArgs(value string) ([]string, error) {
+
+	if value == "" {
+		return nil, nil
+	}
+
+	var binaryArgs [][]byte
+	if err := json.Unmarshal([]byte(value), &binaryArgs); err != nil {
+		return nil, err
+	}
+
+	args := make([]string, len(binaryArgs))
+	for i, eachArg := range binaryArgs {
+		args[i] = string(eachArg)
+	}
+
+	return args, nil
+}
+
+// typedCommandArgs : 데이터 로그 / 슬레이브 전파 형식의 (@key, @value) 를 레디스 명령 인자로 변환
+//  - 자료형 쓰기 명령 : Key, JSON 배열의 인자들
+//  - RESTORE : Key, TTL 0 (만료 시각은 별도 PEXPIREAT), 직렬화 값, REPLACE
+//
+func typedCommandArgs(command string, key string, value string) ([]interface{}, error) {
+
+	if command == RestoreCommand {
+		serializedValue, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{key, 0, serializedValue, "REPLACE"}, nil
+	}
+
+	args, err := decodeTypedArgs(value)
+	if err != nil {
+		return nil, err
+	}
+
+	redisArgs := make([]interface{}, 0, len(args)+1)
+	redisArgs = append(redisArgs, key)
+	for _, eachArg := range args {
+		redisArgs = append(redisArgs, eachArg)
+	}
+
+	return redisArgs, nil
+}
+
+// executeTypedEntry : 자료형 쓰기 엔트리 반영, 레디스 응답을 결과로 반환
+//  - HSET / SADD : 새로 추가된 필드 / 원소 수
+//  - LPUSH : 추가 후 리스트 길이
+//  - RPOP : 꺼낸 원소 (빈 리스트면 NOKEY 에러)
+// 자료형이 맞지 않는 Key 는 WRONGTYPE 에러
+//
+func executeTypedEntry(entry KeyValuePair, durability Durability) (RedisClient, string, error) {
+
+	// 분산 트랜잭션이 잠근 키는 결정이 날 때까지 변경 불가
+	if err := checkKeysUnlocked([]string{entry.Key}); err != nil {
+		return RedisClient{}, "", err
+	}
+
+	// Key의 해쉬 슬롯을 담당하는 레디스 획득 (슬롯 이동 중이면 Key 위치에 따라)
+	redisClient, release, err := GetRedisClientWithKey(entry.Key)
+	if err != nil {
+		return RedisClient{}, "", err
+	}
+
+	value := encodeTypedArgs(typedArgs(entry))
+	args, _ := typedCommandArgs(entry.Command, entry.Key, value)
+
+	reply, err := redisClient.Connection.Do(entry.Command, args...)
+	if err != nil {
+		release()
+		return redisClient, "", err
+	}
+
+	// 빈 리스트의 RPOP 은 아무것도 바꾸지 않는다
+	if reply == nil {
+		release()
+		return redisClient, "", fmt.Errorf(msg.NoSuchKey, entry.Key)
+	}
+
+	var result string
+	if count, isCount := reply.(int64); isCount {
+		result = strconv.FormatInt(count, 10)
+	} else {
+		result, err = redis.String(reply, nil)
+	}
+	if err != nil {
+		release()
+		return redisClient, "", err
+	}
+
+	bumpKeyVersions(redisClient, []string{entry.Key})
+
+	// 변경사항 데이터 로그 기록
+	err = redisClient.RecordModificationLog(entry.Command, entry.Key, value)
+	release()
+	if err != nil {
+		return redisClient, "", err
+	}
+
+	// 슬레이브에게 전파 (durability 설정 시 레플리카 확인까지 대기)
+	err = redisClient.ReplicateToSlaveWithAck(entry.Command, entry.Key, value, durability)
+
+	return redisClient, result, err
+}
+
+// loggedValue : 데이터 로그를 재생해 얻은 Key 하나의 최신 상태
+//  - 문자열 Key : value
+//  - 자료형 Key : 슬롯 이동으로 옮겨진 직렬화 값(dump, 없을 수 있음) 위에 순서대로 실행할 자료형 명령들
+//  - 슬롯 이동으로 옮겨진 문자열 Key : dump 위에 순서대로 실행할 INCRBY 명령들
+// 자료형 Key 는 Go 에서 값을 해석하지 않고, 다른 레디스에 같은 명령을 다시 실행해 복구한다
+//
+type loggedValue struct {
+	value    string
+	dump     string
+	commands []logFormat
+}
+
+func (value *loggedValue) isTyped() bool {
+	return value.dump != "" || len(value.commands) > 0
+}
+
+// replaySteps : @key 의 최신 상태를 만드는 (명령, Key, Value) 목록
+//  - 문자열 Key : SET
+//  - 자료형 Key : DEL, (RESTORE), 자료형 명령들
+//
+func (value *loggedValue) replaySteps(key string) []logFormat {
+
+	if !value.isTyped() {
+		return []logFormat{newLogFormat(SetCommand, key, value.value)}
+	}
+
+	steps := []logFormat{newLogFormat(DelCommand, key, "")}
+	if value.dump != "" {
+		steps = append(steps, newLogFormat(RestoreCommand, key, value.dump))
+	}
+
+	return append(steps, value.commands...)
+}
+
+func newLogFormat(command, key, value string) logFormat {
+
+	var eachLog logFormat
+	eachLog.Command = command
+	eachLog.Key = key
+	eachLog.Value = value
+
+	return eachLog
+}
+
+// replayLoggedValue : 데이터 로그에서 복원한 @key 를 @redisClient 에 다시 쓰고, 데이터 로그에 기록
+//  @applyToRedis : 레디스에도 실행할지 (Native 복제의 슬레이브는 로그만)
+//  @replicate : 슬레이브에게도 전파할지
+//
+func (redisClient RedisClient) replayLoggedValue(key string, value *loggedValue, applyToRedis, replicate bool) error {
+
+	for _, eachStep := range value.replaySteps(key) {
+
+		if applyToRedis {
+			if _, err := redisClient.Connection.Do(
+				eachStep.Command,
+				commandArgs(eachStep.Command, eachStep.Key, eachStep.Value)...,
+			); err != nil {
+				return err
+			}
+		}
+
+		if err := redisClient.RecordModificationLog(eachStep.Command, eachStep.Key, eachStep.Value); err != nil {
+			return fmt.Errorf(msg.LogFailWhileMigration, redisClient.Address)
+		}
+
+		if replicate {
+			redisClient.ReplicateToSlave(eachStep.Command, eachStep.Key, eachStep.Value)
+		}
+	}
+
+	return nil
+}
+
+// readTyped : @key 의 해쉬 슬롯을 담당하는 레디스에 읽기 명령 실행
+func readTyped(key string, command string, args ...interface{}) (interface{}, RedisClient, error) {
+
+	// Key의 해쉬 슬롯을 담당하는 레디스 획득 (슬롯 이동 중이면 Key 위치에 따라)
+	redisClient, release, err := GetRedisClientWithKey(key)
+	if err != nil {
+		return nil, RedisClient{}, err
+	}
+	defer release()
+
+	reply, err := redisClient.Connection.Do(command, append([]interface{}{key}, args...)...)
+
+	return reply, redisClient, err
+}
+
+// GetHashField : @key 해쉬의 @field 값 (HGET), 없으면 redis.ErrNil
+func GetHashField(key, field string) (string, RedisClient, error) {
+
+	reply, redisClient, err := readTyped(key, "HGET", field)
+	value, err := redis.String(reply, err)
+
+	return value, redisClient, err
+}
+
+// GetHashAll : @key 해쉬의 모든 (필드, 값) (HGETALL), 없는 Key 는 빈 맵
+func GetHashAll(key string) (map[string]string, RedisClient, error) {
+
+	reply, redisClient, err := readTyped(key, "HGETALL")
+	fields, err := redis.StringMap(reply, err)
+
+	return fields, redisClient, err
+}
+
+// GetListRange : @key 리스트의 [start, stop] 원소 (LRANGE), 음수는 끝에서부터
+func GetListRange(key string, start, stop int64) ([]string, RedisClient, error) {
+
+	reply, redisClient, err := readTyped(key, "LRANGE", start, stop)
+	elements, err := redis.Strings(reply, err)
+
+	return elements, redisClient, err
+}
+
+// GetSetMembers : @key 집합의 원소들 (SMEMBERS), 정렬해서 반환
+func GetSetMembers(key string) ([]string, RedisClient, error) {
+
+	reply, redisClient, err := readTyped(key, "SMEMBERS")
+	members, err := redis.Strings(reply, err)
+	sort.Strings(members)
+
+	return members, redisClient, err
+}
+
+// GetKeyType : @key 의 자료형 (TYPE), 없는 Key 는 "none"
+func GetKeyType(key string) (string, error) {
+
+	reply, _, err := readTyped(key, "TYPE")
+
+	return redis.String(reply, err)
+}
@@ -4,8 +4,8 @@ import (
 	"fmt"
 	msg "hash_interface/internal/storage/message"
 	"hash_interface/tools"
-	"log"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -13,23 +13,24 @@ import (
 	ctx "golang.org/x/net/context"
 )
 
-type DockerWrapper struct {
+// DockerRestarter : 노드 주소의 IP 를 가진 Docker 컨테이너를 재시작 (기본값)
+// Docker 클라이언트는 처음 재시작할 때 환경변수(DOCKER_HOST 등)로 생성한다
+//
+type DockerRestarter struct {
 	Context *ctx.Context
 	Client  *client.Client
-}
 
-var docker DockerWrapper
+	mutex sync.Mutex
+}
 
 var restartTimeOutDuration = 5 * time.Second
 
-func init() {
-	if err := docker.checkInit(); err != nil {
-		tools.ErrorLogger.Println(msg.DockerInitFail)
-		log.Fatal(err)
-	}
+// RestartNode : 컨테이너가 실행 중이 아니면 재시작
+func (docker *DockerRestarter) RestartNode(address string) error {
+	return docker.restartRedisContainer(address)
 }
 
-func (docker DockerWrapper) restartRedisContainer(targetIP string) error {
+func (docker *DockerRestarter) restartRedisContainer(targetIP string) error {
 
 	redisContainer, err := docker.getContainerWithIP(targetIP)
 	if err != nil {
@@ -66,7 +67,7 @@ func (docker DockerWrapper) restartRedisContainer(targetIP string) error {
 	return nil
 }
 
-func dockerClientSetUp() error {
+func (docker *DockerRestarter) dockerClientSetUp() error {
 
 	var err error
 
@@ -82,9 +83,13 @@ func dockerClientSetUp() error {
 	return nil
 }
 
-func (docker DockerWrapper) checkInit() error {
+func (docker *DockerRestarter) checkInit() error {
+
+	docker.mutex.Lock()
+	defer docker.mutex.Unlock()
+
 	if docker.Client == nil || docker.Context == nil {
-		if err := dockerClientSetUp(); err != nil {
+		if err := docker.dockerClientSetUp(); err != nil {
 			return err
 		}
 	}
@@ -92,7 +97,7 @@ func (docker DockerWrapper) checkInit() error {
 	return nil
 }
 
-func (docker DockerWrapper) getContainerWithIP(targetIP string) (types.Container, error) {
+func (docker *DockerRestarter) getContainerWithIP(targetIP string) (types.Container, error) {
 
 	var err error
 
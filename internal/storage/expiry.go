@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	msg "hash_interface/internal/storage/message"
+)
+
+// 만료 시간 관련 WAL 엔트리 / 데이터 로그 명령
+//  - PEXPIREAT : Key 만료 시각 설정 (Value / ExpireAt 에 Unix millisecond)
+//  - PERSIST : Key 만료 시간 제거
+//
+const (
+	PExpireAtCommand = "PEXPIREAT"
+	PersistCommand   = "PERSIST"
+)
+
+// KeyExpireMap : Key -> 만료 시각 (Unix millisecond), 만료 시간이 있는 Key만
+type KeyExpireMap map[string]int64
+
+// StampExpiry : 요청의 TTL(상대 시간)을 WAL 에 기록하기 전 만료 시각(절대 시간)으로 변환
+// 모든 노드가 반영 시점과 관계없이 같은 시각에 만료되도록
+//
+func StampExpiry(entry KeyValuePair, now time.Time) KeyValuePair {
+
+	if entry.TTL > 0 && entry.ExpireAt == 0 {
+		entry.ExpireAt = toUnixMillisecond(now) + entry.TTL
+	}
+
+	return entry
+}
+
+// IsNoSuchKeyError : 없는 Key에 대한 요청이라 거절된 에러인지
+func IsNoSuchKeyError(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), msg.NoSuchKeyMarker)
+}
+
+func toUnixMillisecond(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// remainingMillisecond : @expireAt 까지 남은 시간 (SET PX 용)
+// 반영 시점에 이미 지났으면 1ms (바로 만료)
+//
+func remainingMillisecond(expireAt int64) int64 {
+
+	remaining := expireAt - toUnixMillisecond(time.Now())
+	if remaining < 1 {
+		return 1
+	}
+
+	return remaining
+}
+
+// executeExpiry : PEXPIREAT / PERSIST 엔트리 반영, 없는 Key 면 NOKEY 에러
+func executeExpiry(entry KeyValuePair, durability Durability) (RedisClient, error) {
+
+	redisClient, release, err := GetRedisClientWithKey(entry.Key)
+	if err != nil {
+		return RedisClient{}, err
+	}
+
+	value := ""
+	var isApplied int
+
+	switch entry.Command {
+	case PExpireAtCommand:
+		value = strconv.FormatInt(entry.ExpireAt, 10)
+		isApplied, err = redis.Int(redisClient.Connection.Do(PExpireAtCommand, entry.Key, entry.ExpireAt))
+
+	case PersistCommand:
+		// 만료 시간이 없던 Key도 성공으로 본다
+		var exists int
+		if exists, err = redis.Int(redisClient.Connection.Do("EXISTS", entry.Key)); err == nil && exists == 1 {
+			_, err = redisClient.Connection.Do(PersistCommand, entry.Key)
+			isApplied = 1
+		}
+	}
+
+	if err != nil {
+		release()
+		return redisClient, err
+	}
+
+	if isApplied == 0 {
+		release()
+		return redisClient, fmt.Errorf(msg.NoSuchKey, entry.Key)
+	}
+
+	bumpKeyVersions(redisClient, []string{entry.Key})
+
+	// 변경사항 데이터 로그 기록
+	err = redisClient.RecordModificationLog(entry.Command, entry.Key, value)
+	release()
+	if err != nil {
+		return redisClient, err
+	}
+
+	// 슬레이브에게 전파 (durability 설정 시 레플리카 확인까지 대기)
+	err = redisClient.ReplicateToSlaveWithAck(entry.Command, entry.Key, value, durability)
+
+	return redisClient, err
+}
+
+// recordExpiry : 다른 노드로 옮긴 Key의 만료 시각을 @redisClient 에 설정, 로그 기록 & 슬레이브 전파
+// (데이터 로그로 복구/이동하는 경우, SET 뒤에 호출)
+//
+func (redisClient RedisClient) recordExpiry(key string, expireAt int64, replicate bool) error {
+
+	if _, err := redisClient.Connection.Do(PExpireAtCommand, key, expireAt); err != nil {
+		return err
+	}
+
+	value := strconv.FormatInt(expireAt, 10)
+
+	if err := redisClient.RecordModificationLog(PExpireAtCommand, key, value); err != nil {
+		return err
+	}
+
+	if replicate {
+		redisClient.ReplicateToSlave(PExpireAtCommand, key, value)
+	}
+
+	return nil
+}
+
+// GetTTL : @key 의 남은 만료 시간 (millisecond, PTTL)
+//  -1 : 만료 시간 없음, -2 : Key 없음
+//
+func GetTTL(key string) (int64, RedisClient, error) {
+
+	redisClient, release, err := GetRedisClientWithKey(key)
+	if err != nil {
+		return 0, RedisClient{}, err
+	}
+	defer release()
+
+	ttlInMillisecond, err := redis.Int64(redisClient.Connection.Do("PTTL", key))
+	if err != nil {
+		return 0, redisClient, err
+	}
+
+	return ttlInMillisecond, redisClient, nil
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"fmt"
+	"testing"
+
+	msg "hash_interface/internal/storage/message"
+)
+
+func TestValidateExpiryEntry(t *testing.T) {
+
+	fixtures := []struct {
+		name        string
+		entry       KeyValuePair
+		expectedErr string
+	}{
+		{
+			name:  "TTL 있는 SET",
+			entry: KeyValuePair{Key: "key", Value: "value", TTL: 1000},
+		},
+		{
+			name:        "음수 TTL 인 SET",
+			entry:       KeyValuePair{Key: "key", Value: "value", TTL: -1},
+			expectedErr: fmt.Sprintf(msg.InvalidTTL, "key", -1),
+		},
+		{
+			name:  "WAL 기록 전 PEXPIREAT (TTL)",
+			entry: KeyValuePair{Key: "key", Command: PExpireAtCommand, TTL: 1000},
+		},
+		{
+			name:  "WAL 기록 후 PEXPIREAT (만료 시각)",
+			entry: KeyValuePair{Key: "key", Command: PExpireAtCommand, ExpireAt: 1},
+		},
+		{
+			name:        "만료 시간 없는 PEXPIREAT",
+			entry:       KeyValuePair{Key: "key", Command: PExpireAtCommand},
+			expectedErr: fmt.Sprintf(msg.InvalidTTL, "key", 0),
+		},
+		{
+			name:  "PERSIST",
+			entry: KeyValuePair{Key: "key", Command: PersistCommand},
+		},
+	}
+
+	for _, fixture := range fixtures {
+
+		err := ValidateEntry(fixture.entry)
+
+		if fixture.expectedErr == "" {
+			if err != nil {
+				t.Errorf("%s : ValidateEntry() 에러 %s", fixture.name, err.Error())
+			}
+			continue
+		}
+
+		if err == nil || err.Error() != fixture.expectedErr {
+			t.Errorf("%s : ValidateEntry() 에러 %v, expected : %s", fixture.name, err, fixture.expectedErr)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+// FailoverEventType : Failover 과정에서 일어난 일의 종류
+type FailoverEventType string
+
+const (
+	// PromotionEvent : 슬레이브를 새로운 마스터로 승격 (promoteToMaster, ManualFailover)
+	PromotionEvent FailoverEventType = "promotion"
+
+	// DemotionEvent : 기존 마스터를 새로운 마스터의 슬레이브로 연결 (handleIfDead 의 재시작 후, ManualFailover)
+	DemotionEvent FailoverEventType = "demotion"
+
+	// SlotRedistributionEvent : 마스터 - 슬레이브가 모두 죽어 해쉬 슬롯을 남은 마스터들에게 재분배 (distributeFrom)
+	SlotRedistributionEvent FailoverEventType = "slot_redistribution"
+
+	// NodeRestartEvent : 죽은 노드 재시작 (handleIfDead, checkSlaveAlive)
+	NodeRestartEvent FailoverEventType = "node_restart"
+)
+
+const (
+	FailoverEventSuccess = "success"
+	FailoverEventFail    = "fail"
+)
+
+// failoverJournalPath : Failover 이벤트 기록 파일 (한 줄에 JSON 이벤트 하나)
+const failoverJournalPath = "./logs/failover_events"
+
+// FailoverEvent : Failover 이벤트 하나 (GET /events)
+//  - OldRole / NewRole : 역할이 바뀐 경우 이전, 이후 역할
+//  - SlotsMoved : 다른 노드로 옮겨진 해쉬 슬롯 수
+//  - Votes : 이벤트를 일으킨 생존 투표 결과 (투표 없이 일어난 이벤트는 생략)
+//  - Manual : 운영자가 요청한 계획된 Failover (POST /clients/{address}/failover)
+//
+type FailoverEvent struct {
+	Type       FailoverEventType `json:"type"`
+	Node       string            `json:"node"`
+	OldRole    string            `json:"old_role,omitempty"`
+	NewRole    string            `json:"new_role,omitempty"`
+	SlotsMoved int               `json:"slots_moved,omitempty"`
+	Votes      *VoteTally        `json:"votes,omitempty"`
+	Manual     bool              `json:"manual,omitempty"`
+	StartedAt  time.Time         `json:"started_at"`
+	DurationMs float64           `json:"duration_ms"`
+	Result     string            `json:"result"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// VoteTally : 살아있다고 / 죽었다고 투표한 수, 전체 투표 수 (모니터 서버들 + 호스트)
+// 최근 관찰 결과가 없는 모니터는 어느 쪽으로도 투표하지 않는다 (Alive + Down <= Total)
+//
+type VoteTally struct {
+	Alive int `json:"alive"`
+	Down  int `json:"down"`
+	Total int `json:"total"`
+}
+
+// isDown : 죽었다는 투표가 정족수 (과반수와 최소 정족수 중 큰 값) 이상인지
+func (tally VoteTally) isDown() bool {
+	return tally.Down >= quorumOf(tally.Total)
+}
+
+var failoverJournalMutex = &sync.Mutex{}
+
+// newFailoverEvent : 지금 시작하는 Failover 이벤트
+func newFailoverEvent(eventType FailoverEventType, node string, oldRole string, newRole string) FailoverEvent {
+
+	return FailoverEvent{
+		Type:      eventType,
+		Node:      node,
+		OldRole:   oldRole,
+		NewRole:   newRole,
+		StartedAt: time.Now(),
+	}
+}
+
+// recordFailoverEvent : 이벤트의 소요 시간, 결과(@err)를 채워 기록 파일에 추가
+// 기록에 실패해도 Failover 는 계속 진행한다
+//
+func recordFailoverEvent(event FailoverEvent, err error) {
+
+	event.DurationMs = float64(time.Since(event.StartedAt)) / float64(time.Millisecond)
+	event.Result = FailoverEventSuccess
+	if err != nil {
+		event.Result = FailoverEventFail
+		event.Error = err.Error()
+	}
+
+	tools.InfoLogger.Printf(
+		msg.FailoverEventRecorded,
+		event.Type,
+		event.Node,
+		event.Result,
+		event.DurationMs,
+	)
+
+	encodedEvent, encodeErr := json.Marshal(event)
+	if encodeErr != nil {
+		tools.ErrorLogger.Printf(msg.FailoverJournalWriteFail, encodeErr.Error())
+		return
+	}
+
+	failoverJournalMutex.Lock()
+	defer failoverJournalMutex.Unlock()
+
+	journal, openErr := os.OpenFile(failoverJournalPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if openErr != nil {
+		tools.ErrorLogger.Printf(msg.FailoverJournalWriteFail, openErr.Error())
+		return
+	}
+	defer journal.Close()
+
+	if _, writeErr := fmt.Fprintln(journal, string(encodedEvent)); writeErr != nil {
+		tools.ErrorLogger.Printf(msg.FailoverJournalWriteFail, writeErr.Error())
+	}
+}
+
+// GetFailoverEvents : @since 이후에 시작한 Failover 이벤트들 (기록 순), 기록이 없으면 빈 목록
+func GetFailoverEvents(since time.Time) ([]FailoverEvent, error) {
+
+	failoverJournalMutex.Lock()
+	defer failoverJournalMutex.Unlock()
+
+	events := []FailoverEvent{}
+
+	journal, err := os.Open(failoverJournalPath)
+	if os.IsNotExist(err) {
+		return events, nil
+	} else if err != nil {
+		return nil, fmt.Errorf(msg.FailoverJournalReadFail, err.Error())
+	}
+	defer journal.Close()
+
+	scanner := bufio.NewScanner(journal)
+	for scanner.Scan() {
+
+		event := FailoverEvent{}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			// 기록 도중 종료되어 깨진 줄
+			continue
+		}
+
+		if event.StartedAt.Before(since) {
+			continue
+		}
+
+		events = append(events, event)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf(msg.FailoverJournalReadFail, err.Error())
+	}
+
+	return events, nil
+}
+
+// countSlots : 해쉬 슬롯 구간들 ([startIndex, endIndex)) 의 슬롯 수
+func countSlots(hashRanges []HashRange) int {
+
+	slots := 0
+	for _, eachHashRange := range hashRanges {
+		slots += int(eachHashRange.endIndex) - int(eachHashRange.startIndex)
+	}
+
+	return slots
+}
@@ -10,6 +10,9 @@ import (
 type HashSlot struct {
 	slots             map[uint16]*RedisClient
 	redistributeMutex *sync.Mutex
+
+	// slotsMutex : 슬롯 이동(Live Migration) 중 슬롯 조회/변경 동기화용
+	slotsMutex *sync.RWMutex
 }
 
 var hashSlot HashSlot
@@ -36,9 +39,16 @@ func init() {
 	if hashSlot.redistributeMutex == nil {
 		hashSlot.redistributeMutex = &sync.Mutex{}
 	}
+	if hashSlot.slotsMutex == nil {
+		hashSlot.slotsMutex = &sync.RWMutex{}
+	}
 }
 
 func (hashSlot HashSlot) get(slotIndex uint16) RedisClient {
+
+	hashSlot.slotsMutex.RLock()
+	defer hashSlot.slotsMutex.RUnlock()
+
 	return *hashSlot.slots[slotIndex]
 }
 
@@ -52,6 +62,13 @@ func (hashSlot HashSlot) assign(redisClient *RedisClient, start uint16, end uint
 
 	// tools.InfoLogger.Printf(msg.HashSlotAssignStart, redisClient.Address)
 
+	hashSlot.slotsMutex.Lock()
+	defer hashSlot.slotsMutex.Unlock()
+
+	if hashSlot.isOwnerChanged(redisClient, start, end) {
+		slotMapEpoch++
+	}
+
 	var i uint16
 	nextSlotIndex := start + 16
 	// Replace Hash Map With Slave Client
@@ -86,18 +103,21 @@ func (hashSlot HashSlot) assign(redisClient *RedisClient, start uint16, end uint
 // distributeFrom : srcClient 인스턴스에게 할당된 해쉬 슬롯을 다른 Redis 마스터 Client 들에게 분배.
 //  완료 후, srcClient는 해쉬슬롯에서 제거된다.
 //
-func (hashSlot HashSlot) distributeFrom(srcClient *RedisClient) error {
+func (hashSlot HashSlot) distributeFrom(srcClient *RedisClient) (err error) {
 
 	// tools.InfoLogger.Printf(msg.HashSlotRedistributeStart, srcClient.Address)
 	// tools.InfoLogger.Printf(msg.DeadRedisNodeInfo, srcClient.Address, srcClient.Role)
 
+	event := newFailoverEvent(SlotRedistributionEvent, srcClient.Address, srcClient.Role, "")
+	event.SlotsMoved = countSlots(clientHashRangeMap[srcClient.Address])
+	defer func() {
+		recordFailoverEvent(event, err)
+	}()
+
 	if len(clientHashRangeMap[srcClient.Address]) == 0 {
 		return fmt.Errorf(msg.NoHashRangeIsAssigned, srcClient.Address)
 	}
 
-	hashSlot.redistributeMutex.Lock()
-	defer hashSlot.redistributeMutex.Unlock()
-
 	restOfMasterNumber := len(redisMasterClients) - 1
 
 	if restOfMasterNumber < 1 {
@@ -108,44 +128,18 @@ func (hashSlot HashSlot) distributeFrom(srcClient *RedisClient) error {
 		return fmt.Errorf(msg.NoMasterClients)
 	}
 
-	// srcClient가 담당하던 해쉬 슬롯 범위에 대해
-	for _, eachHashRangeOfClient := range clientHashRangeMap[srcClient.Address] {
-
-		srcHashSlotStart := eachHashRangeOfClient.startIndex
-		srcHashSlotEnd := eachHashRangeOfClient.endIndex
-		srcHashSlotRange := srcHashSlotEnd - srcHashSlotStart + 1
-
-		i := 0 // 임의의 마스터 클라이언트 인덱스
-		// 다른 마스터에게 해쉬 슬롯 균일 분배
-		for idx, eachMasterNode := range redisMasterClients {
-
-			if eachMasterNode.Address != srcClient.Address {
-
-				// 소수부 손실을 막기 위한 계산 순서
-				// arithmatic order fixed to prevent Mantissa Loss
-				normalizedHashSlotStart := uint16(
-					float64(i) / float64(restOfMasterNumber) * float64(srcHashSlotRange),
-				)
-				normalizedhashSlotEnd := uint16(
-					float64(i+1) / float64(restOfMasterNumber) * float64(srcHashSlotRange),
-				)
-
-				hashSlotStart := normalizedHashSlotStart + srcHashSlotStart
-				hashSlotEnd := normalizedhashSlotEnd + srcHashSlotStart
-				hashSlot.assign(&redisMasterClients[idx], hashSlotStart, hashSlotEnd)
-
-				newHashRange := HashRange{
-					startIndex: hashSlotStart,
-					endIndex:   hashSlotEnd,
-				}
-
-				clientHashRangeMap[eachMasterNode.Address] = append(
-					clientHashRangeMap[eachMasterNode.Address],
-					newHashRange,
-				)
-				i++
-			}
-		}
+	// srcClient의 가중치를 0으로 두고 재분배 계획, 다른 마스터에게 해쉬 슬롯 균일 분배
+	hashSlot.redistributeMutex.Lock()
+	plan, err := hashSlot.planRebalance(map[string]float64{srcClient.Address: 0})
+	hashSlot.redistributeMutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	// srcClient는 죽었으므로 소유권만 변경, 데이터는 로그로부터 복구
+	if err := hashSlot.assignPlan(plan); err != nil {
+		return err
 	}
 
 	// srcClient가 저장하고 있던 데이터 Migration
@@ -186,77 +180,27 @@ func PrintCurrentMasterSlaves() {
 	}
 }
 
-// distributeTo :  새로운 마스터에게 할당 될 해쉬 슬롯은
-// "각 마스터의 해쉬슬롯 / 총 마스터 클라이언트 수" 크기만큼
-// 기존 마스터들로부터 재분배
-// * @destClient 이외의 마스터에겐 해쉬슬롯이 분배되지 않는다.
-//
-func (hashSlot HashSlot) distributeTo(destClient *RedisClient) bool {
+// setOwner : @slotIndex 해쉬 슬롯의 소유권을 @redisClient 로 변경
+func (hashSlot HashSlot) setOwner(slotIndex uint16, redisClient *RedisClient) {
 
-	notDistributed := false
+	hashSlot.slotsMutex.Lock()
+	if hashSlot.isOwnerChanged(redisClient, slotIndex, slotIndex+1) {
+		slotMapEpoch++
+	}
+	hashSlot.slots[slotIndex] = redisClient
+	hashSlot.slotsMutex.Unlock()
+}
 
-	for _, eachMaster := range redisMasterClients {
-		//fmt.Printf("distributeTo() : 소스 마스터 노드 주소 - %s\n", eachMaster.Address)
-		// 새로 추가된 마스터가 아닌 경우
-		if eachMaster.Address != destClient.Address {
-
-			// fmt.Printf(
-			// 	"distributeTo() : 소스 마스터 할당 해쉬 슬롯 범위 개수 : %d\n",
-			// 	len(clientHashRangeMap[eachMaster.Address]),
-			// )
-
-			// for _, eachRange := range clientHashRangeMap[eachMaster.Address] {
-
-			// fmt.Printf(
-			// 	"distributeTo() : 소스 마스터 할당 해쉬 슬롯 범위 (%d ~ %d)\n",
-			// 	eachRange.startIndex,
-			// 	eachRange.endIndex,
-			// )
-			// }
-
-			// 기존 마스터가 담당하는 해쉬 슬롯 범위들
-			for idx, eachRange := range clientHashRangeMap[eachMaster.Address] {
-
-				srcHashSlotRange := eachRange.endIndex - eachRange.startIndex + 1
-
-				// 새로운 마스터가 할당 받은 해쉬슬롯 크기 : (기존 마스터 담당 해쉬 슬롯 / n)
-				destHashSlotRange := uint16(
-					float64(1) / float64(len(redisMasterClients)) * float64(srcHashSlotRange),
-				)
-				destHashSlotStart := eachRange.startIndex
-				destHashSlotEnd := destHashSlotStart + destHashSlotRange
-
-				// To-Do :해쉬 슬롯을 더 이상 나눌 수 없을 정도로 레디스 클라이언트의 개수가 많아 질 때 에러
-
-				hashSlot.assign(destClient, destHashSlotStart, destHashSlotEnd)
-
-				newHashRange := HashRange{
-					startIndex: destHashSlotStart,
-					endIndex:   destHashSlotEnd,
-				}
-
-				clientHashRangeMap[destClient.Address] = append(
-					clientHashRangeMap[destClient.Address],
-					newHashRange,
-				)
-
-				// 기존 마스터가 나눠준 해쉬 슬롯 범위에 맞게 수정
-				clientHashRangeMap[eachMaster.Address][idx].startIndex = destHashSlotEnd
-
-				tools.InfoLogger.Printf(
-					msg.HashSlotAssignResult,
-					destClient.Address,
-					destHashSlotStart,
-					destHashSlotEnd-1,
-				)
-
-				// 분배된 적 있는지 체크하는 프래그
-				if !notDistributed {
-					notDistributed = true
-				}
-			}
+// isOwnerChanged : [@start, @end) 범위 중 담당 마스터가 @redisClient 가 아닌 슬롯이 있는지
+// 같은 마스터를 다시 연결하는 경우 (relinkMasterSlots 등) 구성 버전을 올리지 않기 위함, 호출 전 slotsMutex Lock 필요
+//
+func (hashSlot HashSlot) isOwnerChanged(redisClient *RedisClient, start uint16, end uint16) bool {
+
+	for i := int(start); i < int(end); i++ {
+		if owner := hashSlot.slots[uint16(i)]; owner == nil || owner.Address != redisClient.Address {
+			return true
 		}
 	}
 
-	return notDistributed
+	return false
 }
@@ -1,7 +1,6 @@
 package storage
 
 import (
-	"bufio"
 	"fmt"
 	"hash_interface/internal/hash"
 	msg "hash_interface/internal/storage/message"
@@ -9,11 +8,25 @@ import (
 	"log"
 	"os"
 	"strconv"
-	"strings"
+	"sync"
+	"time"
 )
 
-// dataLoggers gets a logger by passed-key of Each Node address
-var dataLoggers map[string] /* key = each Node's address*/ *log.Logger
+// dataLogs gets a data log file by passed-key of Each Node address
+// 압축 시 파일이 교체되므로 dataLogsMutex 로 보호 (기록은 RLock, 교체는 Lock)
+var dataLogs map[string] /* key = each Node's address*/ *dataLog
+var dataLogsMutex = &sync.RWMutex{}
+
+// dataLog : 노드 하나의 데이터 로그 파일과 로거
+type dataLog struct {
+	file   *os.File
+	logger *log.Logger
+
+	// compactedSize / compactedAt : 마지막 압축(또는 생성) 직후의 파일 크기와 시각
+	compactedSize int64
+	compactedAt   time.Time
+	isCompacting  bool
+}
 
 type logFormat struct {
 	KeyValuePair
@@ -23,26 +36,46 @@ type logFormat struct {
 type KeyValuePair struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
+
+	// Command : 실행할 명령, 생략 시 SET
+	// MSET / EXEC 는 Commands 를 하나의 Redis 트랜잭션(MULTI/EXEC)으로 실행
+	Command  string         `json:"command,omitempty"`
+	Commands []KeyValuePair `json:"commands,omitempty"`
+
+	// TxID : 분산 트랜잭션(TXN_PREPARE / TXN_COMMIT / TXN_ABORT) ID
+	TxID string `json:"tx_id,omitempty"`
+
+	// Condition : 조건부 쓰기, 생략 시 항상 덮어쓴다
+	//  "nx" : Key가 없을 때만, "xx" : Key가 있을 때만,
+	//  "cas" : 현재 값이 ExpectedValue, 버전이 ExpectedVersion 과 같을 때만 (설정된 것만 비교)
+	Condition       string  `json:"condition,omitempty"`
+	ExpectedValue   *string `json:"expected_value,omitempty"`
+	ExpectedVersion *uint64 `json:"expected_version,omitempty"`
+
+	// TTL : 만료 시간 (millisecond), 생략 시 만료 없음
+	// ExpireAt : TTL 을 WAL 에 기록하기 전 변환한 만료 시각 (Unix millisecond)
+	//  TXN_PREPARE 는 결정 기한 (NewPrepareEntry)
+	TTL      int64 `json:"ttl,omitempty"`
+	ExpireAt int64 `json:"expire_at,omitempty"`
+
+	// Field : HSET 의 필드 (Value 에 필드 값)
+	// Values : LPUSH / SADD 의 원소들
+	Field  string   `json:"field,omitempty"`
+	Values []string `json:"values,omitempty"`
+}
+
+// IsEmpty : WAL 에 기록되지 않은 빈 엔트리인지
+func (keyValuePair KeyValuePair) IsEmpty() bool {
+	return keyValuePair.Key == "" && len(keyValuePair.Commands) == 0 && keyValuePair.TxID == ""
 }
 
 const (
 	//LogDirectory is a directory path where log files are saved
 	logDirectory = "./internal/cluster/dump"
-
-	// dataLogFormat : 순서대로 (해쉬값, 명령, Key, Value)
-	dataLogFormat = "%d %s %s %s"
-)
-
-const (
-	/* constants for "index" of Data Log each lines */
-	hashIndexWord = iota
-	commandWord
-	keyWord
-	valueWord
 )
 
-// KeyValueMap : Key -> Value map
-type KeyValueMap map[string]string
+// KeyValueMap : Key -> 데이터 로그로 복원한 최신 상태 map
+type KeyValueMap map[string]*loggedValue
 
 // HashToDataMap : Hash Index -> (Key -> Value) map
 type HashToDataMap map[uint16]KeyValueMap
@@ -54,8 +87,8 @@ func init() {
 		os.Mkdir(logDirectory, os.ModePerm)
 	}
 
-	if dataLoggers == nil {
-		dataLoggers = make(map[string]*log.Logger)
+	if dataLogs == nil {
+		dataLogs = make(map[string]*dataLog)
 	}
 
 }
@@ -76,38 +109,85 @@ func SetUpModificationLogger(nodeAddressList []string) {
 }
 
 // createDataLogFile : 각 노드의 주소 = 각 파일명
+// 재시작 전에 기록하던 파일이 있으면 이어서 기록한다, 이전 형식 파일이면 에러
+//
 func createDataLogFile(address string) error {
-	filePath := fmt.Sprintf("%s/%s", logDirectory, address)
+	filePath := dataLogFilePath(address)
+
+	dataLogsMutex.RLock()
+	_, isOpened := dataLogs[address]
+	dataLogsMutex.RUnlock()
+
+	_, statErr := os.Stat(filePath)
+	isNewFile := os.IsNotExist(statErr)
 
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+	// 이어서 기록할 파일은 현재 형식이어야 한다 (이전 형식이면 datalog_migrate 로 변환 후 시작)
+	if !isNewFile && !isOpened {
+		isEmpty, err := checkDataLogHeader(filePath)
+		if err != nil {
+			return err
+		}
+		isNewFile = isEmpty
+	}
+
+	if isNewFile || !isOpened {
 		fpLog, err := os.OpenFile(filePath,
 			os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
 		if err != nil {
 			panic(err)
 		}
 
-		dataLoggers[address] = log.New(fpLog, "", 0)
+		newDataLog := &dataLog{
+			file:        fpLog,
+			logger:      log.New(fpLog, "", 0),
+			compactedAt: time.Now(),
+		}
+
+		// 새 파일은 형식 버전 헤더부터
+		if isNewFile {
+			newDataLog.logger.Printf(dataLogHeaderFormat, DataLogVersion)
+		}
+		newDataLog.compactedSize, _ = newDataLog.size()
+
+		dataLogsMutex.Lock()
+		dataLogs[address] = newDataLog
+		dataLogsMutex.Unlock()
 	}
 
 	return nil
 }
 
+func dataLogFilePath(address string) string {
+	return fmt.Sprintf("%s/%s", logDirectory, address)
+}
+
+// size : 현재 데이터 로그 파일 크기 (bytes)
+func (targetDataLog *dataLog) size() (int64, error) {
+
+	fileInfo, err := targetDataLog.file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	return fileInfo.Size(), nil
+}
+
 func (redisClient RedisClient) RecordModificationLog(command string, key string, value string) error {
 
 	//tools.InfoLogger.Printf(msg.RecordDataLogStart, redisClient.Address)
 
-	targetDataLogger, isSet := dataLoggers[redisClient.Address]
+	// 압축으로 파일이 교체되는 동안에는 기록하지 않는다
+	dataLogsMutex.RLock()
+	defer dataLogsMutex.RUnlock()
+
+	targetDataLog, isSet := dataLogs[redisClient.Address]
 	if isSet == false {
 		return fmt.Errorf(msg.DataLoggerSetupError)
 	}
 
 	hashSlotIndex := hash.GetHashSlotIndex(key)
-	targetDataLogger.Printf(
-		dataLogFormat,
-		hashSlotIndex,
-		command,
-		key,
-		value,
+	targetDataLog.logger.Println(
+		encodeDataLogRecord(hashSlotIndex, command, key, value),
 	)
 
 	return nil
@@ -115,121 +195,149 @@ func (redisClient RedisClient) RecordModificationLog(command string, key string,
 
 // getLatestDataFromLog : 인스턴스의 데이터 로그파일을 읽어 @dataContainer에 (key, value)로 저장한다.
 // 동일한 Key 값에 대해서는 최신의 데이터가 저장된다.
+// 자료형(Hash / List / Set) Key는 값 대신 다시 실행할 명령들이 저장된다 (loggedValue).
+// 만료 시각이 있는 Key는 @expireAtContainer 에 저장 (nil 이면 생략), 이미 만료된 Key는 제외한다.
+// Key 의 버전은 @versionContainer 에 저장 (nil 이면 생략), 삭제된 Key의 버전도 남는다.
 //
-func (redisClient RedisClient) getLatestDataFromLog(dataContainer HashToDataMap) error {
+func (redisClient RedisClient) getLatestDataFromLog(dataContainer HashToDataMap, expireAtContainer KeyExpireMap, versionContainer KeyVersionMap) error {
+
+	if expireAtContainer == nil {
+		expireAtContainer = make(KeyExpireMap)
+	}
 
 	//tools.InfoLogger.Printf(msg.ReadDataLogStart, redisClient.Address)
 
-	filePath := fmt.Sprintf("%s/%s", logDirectory, redisClient.Address)
-	file, err := os.Open(filePath)
+	filePath := dataLogFilePath(redisClient.Address)
+
+	// 로그 파일의 끝까지 레코드 하나 씩 읽는다.
+	err := readDataLogFile(filePath, collectLatestData(dataContainer, expireAtContainer, versionContainer))
 	if err != nil {
-		err := fmt.Errorf(msg.DataLogOpenError, filePath, err.Error())
-		tools.ErrorLogger.Printf(err.Error())
+		tools.ErrorLogger.Println(err.Error())
 		return err
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
+	removeExpiredData(dataContainer, expireAtContainer)
 
-	// 로그 파일의 끝까지 한 줄 씩 읽는다.
-	for scanner.Scan() {
+	//tools.InfoLogger.Printf("노드(%s)의 데이터 로그 파일 읽기 완료", redisClient.Address)
 
-		// 공백 기준으로 Split
-		words := strings.Fields(scanner.Text())
-		hashIndexIn64, err := strconv.ParseUint(words[hashIndexWord], 10, 16)
-		if err != nil {
-			return fmt.Errorf(msg.ParseHashIndexStringError)
-		}
+	return nil
+}
 
-		hashIndex := uint16(hashIndexIn64)
+// collectLatestData : 데이터 로그 레코드를 순서대로 받아 @dataContainer, @expireAtContainer, @versionContainer 에 최신 상태를 만드는 핸들러
+func collectLatestData(dataContainer HashToDataMap, expireAtContainer KeyExpireMap, versionContainer KeyVersionMap) func(dataLogRecord) error {
 
-		// tools.InfoLogger.Printf(
-		// 	msg.ReadDataLogEachLine,
-		// 	hashIndex,
-		// 	words[commandWord],
-		// 	words[keyWord],
-		// 	words[valueWord],
-		// )
+	return func(record dataLogRecord) error {
 
-		if dataContainer[hashIndex] == nil {
-			dataContainer[hashIndex] = make(map[string]string)
+		if dataContainer[record.hashIndex] == nil {
+			dataContainer[record.hashIndex] = make(KeyValueMap)
 		}
 
-		keyValueMap := dataContainer[hashIndex]
+		keyValueMap := dataContainer[record.hashIndex]
+		key, value := record.Key, record.Value
 
 		// 데이터 로그 => @dataContainer에 기록
 		// 가장 최신의 데이터만 기록에 남음 (이전 데이터 덮어씌움)
-		switch words[commandWord] {
+		switch record.Command {
 		case "SET":
-			keyValueMap[words[keyWord]] = words[valueWord]
+			// SET 은 기존 만료 시간을 없앤다 (Redis 와 동일)
+			keyValueMap[key] = &loggedValue{value: value}
+			delete(expireAtContainer, key)
+			break
+		case RestoreCommand:
+			// 슬롯 이동으로 옮겨진 Key, 이후 명령은 직렬화 값 위에 쌓인다
+			keyValueMap[key] = &loggedValue{dump: value}
+			delete(expireAtContainer, key)
+			break
+		case HSetCommand, LPushCommand, RPopCommand, SAddCommand:
+			if _, err := decodeTypedArgs(value); err != nil {
+				return fmt.Errorf(msg.ParseTypedArgsError, key, record.Command, value)
+			}
+			if keyValueMap[key] == nil {
+				keyValueMap[key] = &loggedValue{}
+			}
+			keyValueMap[key].commands = append(
+				keyValueMap[key].commands,
+				newLogFormat(record.Command, key, value),
+			)
 			break
 		case "DEL":
-			delete(keyValueMap, words[keyWord])
+			delete(keyValueMap, key)
+			delete(expireAtContainer, key)
+			break
+		case PExpireAtCommand:
+			expireAt, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf(msg.ParseExpireAtError, key, value)
+			}
+			if _, isSet := keyValueMap[key]; isSet {
+				expireAtContainer[key] = expireAt
+			}
+			break
+		case PersistCommand:
+			delete(expireAtContainer, key)
+			break
+		case IncrByCommand:
+			// 로그에는 증가량이 기록되어 있으므로 이전 값에 더한다
+			delta, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf(msg.ParseDeltaError, key, value)
+			}
+			if keyValueMap[key] == nil {
+				keyValueMap[key] = &loggedValue{}
+			}
+			// 슬롯 이동으로 옮겨진 Key 는 직렬화 값을 해석할 수 없으므로 RESTORE 이후에 다시 실행한다
+			if keyValueMap[key].dump != "" {
+				keyValueMap[key].commands = append(
+					keyValueMap[key].commands,
+					newLogFormat(IncrByCommand, key, value),
+				)
+				break
+			}
+			current, _ := strconv.ParseInt(keyValueMap[key].value, 10, 64)
+			keyValueMap[key].value = strconv.FormatInt(current+delta, 10)
+			break
+		case KeyVersionCommand:
+			version, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf(msg.ParseKeyVersionError, key, value)
+			}
+			if versionContainer != nil && version > versionContainer[key] {
+				versionContainer[key] = version
+			}
 			break
 		default:
-			return fmt.Errorf(msg.UnsupportedCommand, words[commandWord])
+			return fmt.Errorf(msg.UnsupportedCommand, record.Command)
 		}
-	}
 
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf(msg.FileScannerError, err.Error())
+		return nil
 	}
+}
 
-	//tools.InfoLogger.Printf("노드(%s)의 데이터 로그 파일 읽기 완료", redisClient.Address)
+// removeExpiredData : 이미 만료된 Key 제외
+func removeExpiredData(dataContainer HashToDataMap, expireAtContainer KeyExpireMap) {
 
-	return nil
+	now := toUnixMillisecond(time.Now())
+	for key, expireAt := range expireAtContainer {
+		if expireAt <= now {
+			delete(dataContainer[hash.GetHashSlotIndex(key)], key)
+			delete(expireAtContainer, key)
+		}
+	}
 }
 
 // readDataLogs reads Node's data log file and records the information in @hashIndexToKeyValuePairMap
 func (redisClient RedisClient) readDataLogs(hashIndexToLogFormatMap map[uint16][]logFormat) error {
-	filePath := fmt.Sprintf("%s/%s", logDirectory, redisClient.Address)
-	file, err := os.Open(filePath)
-	if err != nil {
-		return fmt.Errorf(msg.DataLogOpenError, filePath, err.Error())
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		words := strings.Fields(scanner.Text())
-		hashIndexIn64, err := strconv.ParseUint(words[hashIndexWord], 10, 16)
-		if err != nil {
-			return fmt.Errorf(msg.ParseHashIndexStringError)
-		}
-
-		hashIndex := uint16(hashIndexIn64)
+	filePath := dataLogFilePath(redisClient.Address)
 
-		var logFormat logFormat
-		logFormat.Key = words[keyWord]
-		logFormat.Value = words[valueWord]
-		logFormat.Command = words[commandWord]
+	return readDataLogFile(filePath, func(record dataLogRecord) error {
 
-		// 공백을 기준으로 split을 하므로, Value 값이 쪼개진 경우 처리
-		if len(words) > 4 {
-			for i := 4; i < len(words); i++ {
-				logFormat.Value += fmt.Sprintf(" %s", words[i])
-			}
-		}
-
-		// tools.InfoLogger.Printf(
-		// 	msg.ReadDataLogEachLine,
-		// 	hashIndex,
-		// 	words[commandWord],
-		// 	words[keyWord],
-		// 	words[valueWord],
-		// )
-
-		hashIndexToLogFormatMap[hashIndex] = append(
-			hashIndexToLogFormatMap[hashIndex],
-			logFormat,
+		hashIndexToLogFormatMap[record.hashIndex] = append(
+			hashIndexToLogFormatMap[record.hashIndex],
+			record.logFormat,
 		)
-	}
 
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf(msg.FileScannerError, err.Error())
-	}
-
-	return nil
+		return nil
+	})
 }
 
 func (redisClient RedisClient) createDataLogFile() error {
@@ -242,13 +350,19 @@ func (redisClient RedisClient) createDataLogFile() error {
 }
 
 func (redisClient RedisClient) removeDataLogFile() error {
-	filePath := fmt.Sprintf("%s/%s", logDirectory, redisClient.Address)
+	filePath := dataLogFilePath(redisClient.Address)
+
+	dataLogsMutex.Lock()
+	defer dataLogsMutex.Unlock()
 
 	if err := os.Remove(filePath); err != nil {
 		return fmt.Errorf(msg.RemoveLogFileError, filePath, err.Error())
 	}
 
-	delete(dataLoggers, redisClient.Address)
+	if targetDataLog, isSet := dataLogs[redisClient.Address]; isSet {
+		targetDataLog.file.Close()
+	}
+	delete(dataLogs, redisClient.Address)
 
 	return nil
 }
@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+
+	"hash_interface/tools"
+)
+
+func TestMain(m *testing.M) {
+
+	tools.InfoLogger = log.New(ioutil.Discard, "", 0)
+	tools.ErrorLogger = log.New(ioutil.Discard, "", 0)
+
+	os.Exit(m.Run())
+}
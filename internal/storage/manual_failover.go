@@ -0,0 +1,226 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+const (
+	// manualFailoverCatchUpTimeout : 계획된 Failover 에서 슬레이브가 마스터를 따라잡기를 기다리는 최대 시간
+	manualFailoverCatchUpTimeout = 5 * time.Second
+
+	catchUpPollInterval = 100 * time.Millisecond
+)
+
+// maintenanceNodes : 점검 중인 노드 주소들, 자동 Failover (승격 / 재분배) 와 재시작 대상에서 제외
+var maintenanceNodes = make(map[string]bool)
+var maintenanceMutex = &sync.RWMutex{}
+
+// SetMaintenance : 등록된 노드(마스터 / 슬레이브)의 점검 모드 설정 / 해제
+func SetMaintenance(address string, isMaintenance bool) error {
+
+	_, masterErr := GetMasterWithAddress(address)
+	_, slaveErr := GetSlaveClientWithAddress(address)
+	if masterErr != nil && slaveErr != nil {
+		return fmt.Errorf(msg.NoMatchingResponseNode)
+	}
+
+	maintenanceMutex.Lock()
+	if isMaintenance {
+		maintenanceNodes[address] = true
+	} else {
+		delete(maintenanceNodes, address)
+	}
+	maintenanceMutex.Unlock()
+
+	tools.InfoLogger.Printf(msg.MaintenanceChanged, address, isMaintenance)
+
+	return nil
+}
+
+// IsInMaintenance : @address 노드가 점검 중인지
+func IsInMaintenance(address string) bool {
+
+	maintenanceMutex.RLock()
+	defer maintenanceMutex.RUnlock()
+
+	return maintenanceNodes[address]
+}
+
+// GetMaintenanceNodes : 점검 중인 노드 주소들 (주소 순)
+func GetMaintenanceNodes() []string {
+
+	maintenanceMutex.RLock()
+	defer maintenanceMutex.RUnlock()
+
+	addresses := make([]string, 0, len(maintenanceNodes))
+	for eachAddress := range maintenanceNodes {
+		addresses = append(addresses, eachAddress)
+	}
+	sort.Strings(addresses)
+
+	return addresses
+}
+
+// ManualFailover : @slaveAddress 슬레이브를 그룹의 새로운 마스터로 승격 (계획된 Failover), 기존 마스터는 슬레이브가 된다
+//  1. 그룹 Lock 으로 그룹에 대한 새로운 요청 중지
+//  2. 슬레이브가 마스터를 따라잡을 때까지 대기
+//  3. 마스터 - 슬레이브 설정 교체 (swapMasterSlaveConfigs), 해쉬 슬롯 이전
+//  4. Native 복제 모드면 복제 방향도 교체 (REPLICAOF)
+// 점검 중인 슬레이브는 승격할 수 없다 (점검 중인 마스터는 가능)
+//
+func ManualFailover(slaveAddress string) (RedisClient, error) {
+
+	if IsInMaintenance(slaveAddress) {
+		return RedisClient{}, fmt.Errorf(msg.NodeInMaintenance, slaveAddress)
+	}
+
+	masterClient, isSlave := slaveMasterMap[slaveAddress]
+	if isSlave == false {
+		return RedisClient{}, fmt.Errorf(msg.NotASlave, slaveAddress)
+	}
+
+	groupMutex := redisMutexMap[masterClient.Address]
+	groupMutex.Lock()
+	defer groupMutex.Unlock()
+
+	// Lock 을 기다리는 동안 그룹이 바뀌었을 수 있다
+	masterClient, isSlave = slaveMasterMap[slaveAddress]
+	if isSlave == false {
+		return RedisClient{}, fmt.Errorf(msg.NotASlave, slaveAddress)
+	}
+	slaveClient := masterSlaveMap[masterClient.Address]
+
+	if isMigratingWith(masterClient.Address) {
+		return RedisClient{}, fmt.Errorf(msg.FailoverDuringMigration, masterClient.Address)
+	}
+
+	promotionEvent := newFailoverEvent(PromotionEvent, slaveClient.Address, SlaveRole, MasterRole)
+	promotionEvent.Manual = true
+	promotionEvent.SlotsMoved = countSlots(clientHashRangeMap[masterClient.Address])
+
+	demotionEvent := newFailoverEvent(DemotionEvent, masterClient.Address, MasterRole, SlaveRole)
+	demotionEvent.Manual = true
+
+	err := masterClient.promoteGracefully(&slaveClient)
+
+	recordFailoverEvent(promotionEvent, err)
+	recordFailoverEvent(demotionEvent, err)
+
+	if err != nil {
+		return RedisClient{}, err
+	}
+
+	return slaveClient, nil
+}
+
+// promoteGracefully : 그룹 Lock 을 잡은 상태에서 @slaveClient 와 역할 교체
+func (masterClient RedisClient) promoteGracefully(slaveClient *RedisClient) error {
+
+	if err := masterClient.waitForSlaveCatchUp(*slaveClient, manualFailoverCatchUpTimeout); err != nil {
+		return err
+	}
+
+	if replicationMode == NativeReplication {
+		if err := slaveClient.stopReplication(); err != nil {
+			return err
+		}
+	}
+
+	hashRanges := clientHashRangeMap[masterClient.Address]
+
+	if err := swapMasterSlaveConfigs(&masterClient, slaveClient); err != nil {
+		return err
+	}
+
+	// 기존 마스터의 해쉬 슬롯을 새로운 마스터가 이어 받음
+	newMasterClient := *slaveClient
+	for _, eachHashRange := range hashRanges {
+		hashSlot.assign(&newMasterClient, eachHashRange.startIndex, eachHashRange.endIndex)
+	}
+	clientHashRangeMap[newMasterClient.Address] = hashRanges
+	delete(clientHashRangeMap, masterClient.Address)
+
+	// 기존 마스터는 새로운 마스터의 레플리카로
+	if replicationMode == NativeReplication {
+		if err := masterClient.replicaOf(newMasterClient); err != nil {
+			return err
+		}
+	}
+
+	replicationInfoMutex.Lock()
+	delete(replicationInfoMap, masterClient.Address)
+	replicationInfoMutex.Unlock()
+
+	return nil
+}
+
+// waitForSlaveCatchUp : 슬레이브가 마스터의 모든 변경사항을 반영할 때까지 대기
+//  - Native 복제 : 복제 offset 이 같아질 때까지
+//  - 명령 재실행 복제 : 두 노드의 Key 수(DBSIZE)가 같아질 때까지
+//
+func (masterClient RedisClient) waitForSlaveCatchUp(slaveClient RedisClient, timeout time.Duration) error {
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		isCaughtUp, err := masterClient.isSlaveCaughtUp(slaveClient)
+		if err != nil {
+			return err
+		}
+		if isCaughtUp {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf(msg.SlaveCatchUpTimeout, slaveClient.Address, timeout)
+		}
+
+		time.Sleep(catchUpPollInterval)
+	}
+}
+
+func (masterClient RedisClient) isSlaveCaughtUp(slaveClient RedisClient) (bool, error) {
+
+	if replicationMode == NativeReplication {
+		replicationInfo, err := masterClient.getReplicationInfo()
+		if err != nil {
+			return false, err
+		}
+		return replicationInfo.IsLinkUp && replicationInfo.Lag == 0, nil
+	}
+
+	masterKeys, err := redis.Int(masterClient.Connection.Do("DBSIZE"))
+	if err != nil {
+		return false, err
+	}
+
+	slaveKeys, err := redis.Int(slaveClient.Connection.Do("DBSIZE"))
+	if err != nil {
+		return false, err
+	}
+
+	return masterKeys == slaveKeys, nil
+}
+
+// isMigratingWith : @address 마스터가 소스 / 타겟인 슬롯 이동이 진행 중인지
+func isMigratingWith(address string) bool {
+
+	migratingSlotsMutex.RLock()
+	defer migratingSlotsMutex.RUnlock()
+
+	for _, eachMigration := range migratingSlots {
+		if eachMigration.sourceAddress == address || eachMigration.targetAddress == address {
+			return true
+		}
+	}
+
+	return false
+}
@@ -27,27 +27,181 @@ const (
 	AddSlaveParameterEror           = "NodeConnectionSetup() : AddSlave option needs 1 address"
 	ReconnectFail                   = "Redis Node(%s) 재연결 시도 실패"
 	NoMasterClients                 = "살아있는 Master Node가 없습니다."
-	DeleteDataFail                  = "reshardDataTo() : deleting from source node error - %s"
+	DeleteDataFail                  = "migrateKeyTo() : deleting from source node error - %s"
 	VoteResultSlaveDead             = "투표 결과 : 슬레이브(%s) 죽음"
 	ConnectionCloseFailure          = "RemoveFromList() : 레디스(%s) 커넥션 닫기 에러"
 	RedisRoleNotInit                = "RemoveFromList() : Redis Client(%s) Role has not been set!"
 	NoClientInList                  = "RemoveFromList() : Client(%s) not in its %s list"
 
 	/* Data Log Related Messages*/
-	CreateLogFileError        = "데이터 로그파일 생성 오류"
-	DataLoggerSetupError      = "data Logger is not set up"
-	DataLogOpenError          = "데이터 로그파일 %s 열기 에러 - %s"
-	ParseHashIndexStringError = "데이터 로그의 해쉬 인덱스 파싱 에러"
-	UnsupportedCommand        = "데이터 로그에서 지원하지 않는 명령(%s) 읽음"
-	FileScannerError          = "데이터 로그 스캐너 에러 - %s"
-	RemoveLogFileError        = "데이터 로그 파일 %s 삭제 에러 - %s"
-	LogFailWhileMigration     = "노드(%s)의 데이터 로그 기록 중 에러"
+	CreateLogFileError          = "데이터 로그파일 생성 오류"
+	DataLoggerSetupError        = "data Logger is not set up"
+	DataLogOpenError            = "데이터 로그파일 %s 열기 에러 - %s"
+	ParseHashIndexStringError   = "데이터 로그의 해쉬 인덱스 파싱 에러"
+	UnsupportedCommand          = "데이터 로그에서 지원하지 않는 명령(%s) 읽음"
+	ParseExpireAtError          = "데이터 로그의 Key(%s) 만료 시각(%s) 파싱 에러"
+	ParseDeltaError             = "데이터 로그의 Key(%s) 증가량(%s) 파싱 에러"
+	ParseKeyVersionError        = "데이터 로그의 Key(%s) 버전(%s) 파싱 에러"
+	ParseTypedArgsError         = "데이터 로그의 Key(%s) %s 인자(%s) 파싱 에러"
+	FileScannerError            = "데이터 로그 스캐너 에러 - %s"
+	DataLogRecordMalformed      = "데이터 로그 레코드 형식 오류 - %q"
+	DataLogChecksumMismatch     = "데이터 로그 레코드 체크섬 불일치 - %q"
+	DataLogCorrupted            = "데이터 로그 파일 %s 의 %d 번째 줄 손상 - %s"
+	DataLogTornRecord           = "데이터 로그 파일 %s 의 마지막 레코드가 완전히 기록되지 않아 무시합니다 - %s"
+	DataLogCompactionFail       = "데이터 로그 압축 실패 : 노드(%s) - %s"
+	DataLogCompactionInProgress = "노드(%s)의 데이터 로그는 이미 압축 중입니다"
+	DataLogCompactionAborted    = "노드(%s)의 데이터 로그 파일이 압축 중 교체되어 압축을 취소합니다"
+	UnsupportedDataLogVersion   = "데이터 로그 파일 %s 의 형식(%q)을 읽을 수 없습니다, 이전 형식이면 datalog_migrate 로 변환해주세요"
+	RemoveLogFileError          = "데이터 로그 파일 %s 삭제 에러 - %s"
+	LogFailWhileMigration       = "노드(%s)의 데이터 로그 기록 중 에러"
 
 	/* Monitor server Messages */
 	UnsupportedMonitorRequest = "Moniter Client ask() : 지원하지 않는 옵션"
 	MonitorRequestTimeout     = "모니터 서버(%s) 요청 타임아웃(3sec) 에러"
-	CreateRequestError        = "requestUnregister() : 요청 생성 에러 %s"
+	CreateRequestError        = "requestUnregister() : 요청 생성 에러 %s - %s"
+
+	/* Replication Messages */
+	UnsupportedReplicationMode = "지원하지 않는 복제 방식(%s) - replay / native"
+	ReplicaOfFail              = "슬레이브(%s) => 마스터(%s) REPLICAOF 설정 실패 - %s"
+	StopReplicationFail        = "레디스(%s) REPLICAOF NO ONE 실패 - %s"
+	ReplicationInfoFail        = "마스터(%s) 그룹의 INFO replication 확인 실패 - %s"
+	UnsupportedDurability      = "지원하지 않는 durability(%s) - none / replica-ack / all-replicas"
+	ReplicaAckFail             = "슬레이브(%s) 복제 확인 에러 - %s"
+
+	// ReplicaQuorumNotReachedMarker : 클러스터 노드 간 전달된 에러 메세지에서 확인용
+	ReplicaQuorumNotReachedMarker = "replica acknowledgment quorum not reached"
+	ReplicaQuorumNotReached       = ReplicaQuorumNotReachedMarker + " : 마스터(%s) 레플리카 확인 %d / %d (durability : %s)"
+	NoReplicaAvailable            = ReplicaQuorumNotReachedMarker + " : 마스터(%s) 에 확인할 레플리카가 없습니다 (durability : %s)"
+
+	/* Slot Migration Messages */
+	KeyMigrationFail      = "키(%s) 이동 실패 - %s => %s : %s"
+	SlotMigrationFail     = "해쉬 슬롯(%d) 이동 실패 - %s => %s : %s"
+	SlotMigrationTryAgain = SlotMigrationTryAgainMarker + " 해쉬 슬롯(%d) 이동 중, 요청한 키들이 나뉘어 있습니다. 다시 시도해주세요"
+
+	// SlotMigrationTryAgainMarker : 클러스터 노드 간 전달된 에러 메세지에서 확인용
+	SlotMigrationTryAgainMarker = "TRYAGAIN"
+
+	/* Multi Key Command Messages */
+	// CrossSlotMarker : 클러스터 노드 간 전달된 에러 메세지에서 확인용
+	CrossSlotMarker               = "CROSSSLOT"
+	CrossSlot                     = CrossSlotMarker + " Keys in request don't hash to the same slot"
+	EmptyKeys                     = "요청한 Key가 없습니다"
+	UnsupportedTransactionCommand = "트랜잭션에서 지원하지 않는 명령(%s) - SET / DEL"
+	TransactionFail               = "마스터(%s) 트랜잭션 실행 실패 - %s"
+
+	/* Conditional Write Messages */
+	// ConditionFailedMarker : 클러스터 노드 간 전달된 에러 메세지에서 확인용
+	ConditionFailedMarker            = "CONFLICT"
+	ConditionFailed                  = ConditionFailedMarker + " Key(%s) 조건부 쓰기(%s) 조건 불일치"
+	VersionMismatch                  = ConditionFailedMarker + " Key(%s) 버전 불일치 - 기대 버전 %d, 현재 버전 %d"
+	UnsupportedCondition             = "지원하지 않는 조건부 쓰기(%s) - nx / xx / cas"
+	CompareAndSwapWithoutExpectation = "Key(%s) cas 는 expected_value 또는 expected_version 이 필요합니다"
+	ConditionInTransaction           = "Key(%s) 조건부 쓰기는 단일 SET 에서만 지원합니다"
+
+	/* Counter Messages */
+	InvalidDelta = "Key(%s) 증가량(%s)은 정수여야 합니다"
+
+	/* Data Type (Hash / List / Set) Messages */
+	// WrongTypeMarker : Redis 의 자료형 불일치 에러 (ex. 문자열 Key 에 HSET)
+	WrongTypeMarker  = "WRONGTYPE"
+	HashFieldMissing = "Key(%s) HSET 은 field 가 필요합니다"
+	EmptyElements    = "Key(%s) %s 는 values 가 하나 이상 필요합니다"
+	InvalidListRange = "LRANGE 범위(start : %s, stop : %s)는 정수여야 합니다"
+	NotStringValue   = WrongTypeMarker + " Key(%s)는 %s 자료형입니다"
+
+	/* Key Scan Messages */
+	// ScanCursorMarker : 다시 사용할 수 없는 SCAN 커서 에러 확인용
+	ScanCursorMarker  = "BADCURSOR"
+	InvalidScanCursor = ScanCursorMarker + " 잘못된 SCAN 커서(%s)입니다"
+	ScanCursorExpired = ScanCursorMarker + " SCAN 커서가 만료되었습니다 (클러스터 구성 변경), 처음(cursor=0)부터 다시 시도해주세요"
+
+	/* Expiration Messages */
+	// NoSuchKeyMarker : 클러스터 노드 간 전달된 에러 메세지에서 확인용
+	NoSuchKeyMarker  = "NOKEY"
+	NoSuchKey        = NoSuchKeyMarker + " Key(%s)가 없습니다"
+	InvalidTTL       = "Key(%s) TTL(%d)은 0보다 커야 합니다 (millisecond)"
+	TTLInTransaction = "Key(%s) TTL은 단일 SET 에서만 지원합니다"
+
+	/* Distributed Transaction (2PC) Messages */
+	// TransactionKeyLockedMarker : 클러스터 노드 간 전달된 에러 메세지에서 확인용
+	TransactionKeyLockedMarker = "LOCKED"
+	TransactionKeyLocked       = TransactionKeyLockedMarker + " Key(%s)는 분산 트랜잭션(%s)이 잠금 중입니다"
+	TransactionIDMissing       = "분산 트랜잭션 ID가 없습니다"
+	TransactionAlreadyDecided  = "분산 트랜잭션(%s)은 이미 결정되었습니다 (%s)"
+	TransactionPrepareFail     = "분산 트랜잭션(%s) PREPARE 실패 - %s"
+	TransactionCommitFail      = "분산 트랜잭션(%s) COMMIT 실패 - 마스터(%s) : %s"
+	TransactionRecoveryFail    = "분산 트랜잭션(%s) 복구 실패 (결정 : %s) - %s"
+
+	/* Rebalance Messages */
+	RebalanceUnknownMaster = "재분배 가중치 에러 - 등록되지 않은 마스터(%s)"
+	RebalanceInvalidWeight = "재분배 가중치 에러 - 마스터(%s)의 가중치(%v)는 0 이상이어야 합니다"
+	RebalanceNoWeight      = "재분배 가중치 에러 - 모든 마스터의 가중치가 0"
 
 	DockerInitFail    = "docker client init error"
 	ContainerNotFound = "No Such Container with IP : %s"
+
+	/* Node Restarter Messages */
+	UnsupportedNodeRestarter = "지원하지 않는 노드 재시작 방식(%s) - docker / process / none"
+	ProcessStartFail         = "노드(%s) redis-server 실행 실패 - %s"
+	ProcessExited            = "노드(%s) redis-server 가 연결을 받기 전에 종료되었습니다 - %s"
+	ProcessNotReady          = "노드(%s) redis-server 가 %s 안에 연결을 받지 않습니다"
+
+	/* Monitor Gossip Messages */
+	FailureDetectorNotStarted = "모니터 서버의 노드 감시가 시작되지 않았습니다"
+	GossipSendFail            = "모니터 서버(%s)에 Gossip 전달 실패 - %s"
+	MonitorEventPushFail      = "인터페이스 서버에 노드(%s) 상태(%s) 알림 실패 - %s"
+	MonitorEventRejected      = "인터페이스 서버 응답 코드 %d"
+	UnsupportedMonitorEvent   = "지원하지 않는 노드 상태(%s) 알림 - odown / sdown / up"
+	FailoverByEventFail       = "odown 알림을 받은 노드(%s) Failover 실패 - %s"
+	InvalidMonitorQuorum      = "잘못된 최소 정족수(%s) - 1 이상의 정수"
+
+	/* Monitor Registry Messages */
+	InvalidMonitorAddress    = "잘못된 모니터 서버 주소(%s) - %s"
+	MonitorRegistryRejected  = "인터페이스 서버 모니터 등록 응답 코드 %d"
+	MonitorRegisterRetry     = "인터페이스 서버(%s)에 모니터 등록 실패, 다시 시도 - %s"
+	MonitorDeregisterFail    = "인터페이스 서버(%s)에서 모니터 해제 실패 - %s"
+	MonitorRegistryFetchFail = "인터페이스 서버(%s)에서 모니터 목록 조회 실패 - %s"
+
+	/* Watched Node Messages */
+	WatchNodeFail  = "노드(%s) 감시 시작 실패 - %s"
+	NodeNotWatched = "모니터 서버가 감시 중인 노드(%s)가 아닙니다"
+
+	/* Failover Event Messages */
+	FailoverJournalWriteFail = "Failover 이벤트 기록 실패 - %s"
+	FailoverJournalReadFail  = "Failover 이벤트 기록 읽기 실패 - %s"
+	InvalidEventsSince       = "잘못된 since(%s) - RFC3339 시각 또는 Unix millisecond"
+
+	/* Manual Failover Messages */
+	NodeInMaintenance       = "점검 중인 노드(%s)"
+	NotASlave               = "노드(%s)는 등록된 슬레이브가 아닙니다"
+	FailoverDuringMigration = "마스터(%s)의 해쉬 슬롯 이동이 진행 중입니다"
+	SlaveCatchUpTimeout     = "슬레이브(%s)가 %s 안에 마스터를 따라잡지 못했습니다"
+
+	/* Automatic Failover Messages */
+	FailoverTryAgain         = SlotMigrationTryAgainMarker + " 마스터(%s) Failover 중입니다. 다시 시도해주세요"
+	FailoverDispatchFail     = "노드(%s) Failover 구성 변경 %s 요청 실패 - %s"
+	TopologyDispatcherNotSet = "구성 변경 엔트리를 Raft 로그에 기록할 TopologyDispatcher 가 설정되지 않았습니다"
+
+	/* Node Removal Messages */
+	NodeNotRegistered     = "노드(%s)는 등록된 마스터 / 슬레이브가 아닙니다"
+	LastMasterRemoval     = "마지막 마스터(%s)는 제거할 수 없습니다"
+	MonitorUnregisterFail = "모니터 서버에 노드(%s) 감시 해제 요청 실패"
+
+	/* Topology Messages */
+	TopologyChangeIDMissing   = "%s 엔트리에 변경 ID 가 없습니다"
+	InvalidTopologyChange     = "잘못된 %s 엔트리 내용 - %s"
+	TopologySnapshotWriteFail = "클러스터 구성 기록 실패 - %s"
+	TopologySnapshotReadFail  = "클러스터 구성 기록 읽기 실패 - %s"
+	RehashKeyFail             = "Key(%s) 담당 마스터로 이동 실패 (%s -> %s) - %s"
+	RecordKeyVersionFail      = "Key(%s) 버전 기록 실패 : 노드(%s) - %s"
+
+	// TopologyRejectedMarker : 클러스터 노드 간 전달된 에러 메세지에서 확인용
+	TopologyRejectedMarker = "TOPOLOGY"
+	TopologyRejected       = TopologyRejectedMarker + " %s 구성 변경(%s) 반영 실패 - %s"
+
+	/* Slot Redirect Messages (Redis Cluster 의 MOVED / ASK 와 같은 형식) */
+	// SlotMovedMarker, SlotAskMarker : 리다이렉트 종류, 응답의 type 값으로도 사용
+	SlotMovedMarker = "MOVED"
+	SlotAskMarker   = "ASK"
+	SlotRedirected  = "%s %d %s - 해쉬 슬롯 구성 버전(epoch) : %d"
 )
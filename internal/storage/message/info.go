@@ -39,16 +39,43 @@ const (
 	StartReplicaiton            = "슬레이브로 Replicate 시작"
 	EndReplication              = "슬레이브로 Replicate 종료"
 
+	/* Slot Migration Messages */
+	SlotMigrationStart  = "해쉬 슬롯 %d ~ %d 이동 시작 : %s (MIGRATING) => %s (IMPORTING)"
+	SlotMigrationFinish = "해쉬 슬롯 %d ~ %d 이동 완료 : %s => %s"
+
+	/* Distributed Transaction (2PC) Messages */
+	TransactionPrepared  = "분산 트랜잭션(%s) PREPARE 완료 - 명령 %d개, 키 잠금"
+	TransactionCommitted = "분산 트랜잭션(%s) COMMIT 완료 - 마스터 %d개"
+	TransactionAborted   = "분산 트랜잭션(%s) ABORT 완료 - 키 잠금 해제"
+	TransactionRecovery  = "분산 트랜잭션(%s) 복구 - 결정 : %s"
+
 	/* Data Log Related Messages */
 	RecordDataLogStart  = "%s 노드에 데이터 수정사항 로그 저장"
 	ReadDataLogStart    = "getLatestDataFromLog() : 노드(%s)의 데이터 로그 파일 읽기 시작"
 	ReadDataLogEachLine = "readDataLogs() : data log file read result : %d %s %s %s"
+	DataLogCompacted    = "노드(%s)의 데이터 로그 압축 완료 : %d bytes => %d bytes"
 
 	/* Monitor server Messages */
-	NewConnectRequest = "monitorClient askConnect() : %s 노드에 대해 새로 연결 요청"
+	MonitorEventPushed     = "인터페이스 서버에 노드(%s) 상태(%s) 알림 - 죽었다고 판단한 모니터 %d / %d"
+	MonitorEventReceived   = "모니터 서버(%s) 알림 : 노드(%s) 상태(%s) - 죽었다고 판단한 모니터 %d / %d"
+	NewConnectRequest      = "monitorClient askConnect() : %s 노드에 대해 새로 연결 요청"
+	MonitorRegistryChanged = "%s 모니터 서버(%s) - 등록된 모니터 %d개"
+	MonitorRegistered      = "인터페이스 서버(%s)에 모니터(%s) 등록 완료"
+	MonitorDeregistered    = "인터페이스 서버(%s)에서 모니터(%s) 해제 완료"
+	NodeWatched            = "노드(%s) 감시 시작"
+	NodeUnwatched          = "노드(%s) 감시 중단"
+	FailoverEventRecorded  = "Failover 이벤트 %s - 노드(%s) %s (%.1fms)"
+	MaintenanceChanged     = "노드(%s) 점검 모드 : %t"
+	NodeRemoved            = "%s 노드(%s) 제거"
+	SlaveRehomed           = "슬레이브(%s)의 마스터 변경 : %s -> %s"
+	SlaveAlreadyRehomed    = "슬레이브(%s)는 이미 마스터(%s)의 슬레이브"
+	TopologyChanged        = "클러스터 구성 변경 %s (ID : %s) - %s"
+	TopologyRestored       = "기록된 클러스터 구성 복원 - 마스터 %d개, 슬레이브 %d개"
 
-	ContainerStatus   = "RestartRedisContainer() : Redis container(%s) status : %s"
-	ContainerRestart  = "RestartRedisContainer() : Redis container(%s) restart"
-	TargetContainerIP = "getContainerWithIP() : target container IP : "
-	ContainerFound    = "Container selected success - "
+	ContainerStatus    = "RestartRedisContainer() : Redis container(%s) status : %s"
+	ContainerRestart   = "RestartRedisContainer() : Redis container(%s) restart"
+	TargetContainerIP  = "getContainerWithIP() : target container IP : "
+	ProcessRestart     = "노드(%s) redis-server 실행 (pid : %d)"
+	NodeRestartSkipped = "노드(%s) 재시작 생략 (재시작 방식 : none)"
+	ContainerFound     = "Container selected success - "
 )
@@ -1,10 +1,14 @@
 package storage
 
 import (
+	"encoding/base64"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/gomodule/redigo/redis"
 
+	"hash_interface/internal/hash"
 	msg "hash_interface/internal/storage/message"
 	"hash_interface/tools"
 )
@@ -20,7 +24,9 @@ func (deadClient RedisClient) migrateDataToOthers() error {
 
 	// deadClient의 로그 파일 읽기 => 최신 데이터 현황 생성
 	deadClientDataContainer := make(HashToDataMap)
-	err := deadClient.getLatestDataFromLog(deadClientDataContainer)
+	deadClientExpireAtContainer := make(KeyExpireMap)
+	deadClientVersionContainer := make(KeyVersionMap)
+	err := deadClient.getLatestDataFromLog(deadClientDataContainer, deadClientExpireAtContainer, deadClientVersionContainer)
 	if err != nil {
 		return err
 	}
@@ -51,152 +57,175 @@ func (deadClient RedisClient) migrateDataToOthers() error {
 			// 	newMappedClient.Address,
 			// )
 
-			// 레디스에 저장
-			_, err := redis.String(newMappedClient.Connection.Do("SET", eachKey, eachValue))
-			if err != nil {
+			// 레디스에 저장, 로그 기록 & 저장 목표 마스터의 슬레이브에게도 전파
+			// (자료형 Key 는 로그에 남은 명령들을 다시 실행)
+			if err := newMappedClient.replayLoggedValue(eachKey, eachValue, true, true); err != nil {
 				return err
 			}
 
-			// 저장 목표 마스터가 중간에 죽어도, 로그 파일에는 기록을 남김
-			err = newMappedClient.RecordModificationLog("SET", eachKey, eachValue)
-			if err != nil {
-				return fmt.Errorf(msg.LogFailWhileMigration, deadClient.Address)
+			// 남은 만료 시간도 그대로 옮긴다
+			if expireAt, isSet := deadClientExpireAtContainer[eachKey]; isSet {
+				if err := newMappedClient.recordExpiry(eachKey, expireAt, true); err != nil {
+					return fmt.Errorf(msg.LogFailWhileMigration, deadClient.Address)
+				}
 			}
-
-			// 저장 목표 마스터의 슬레이브에게도 전파
-			newMappedClient.ReplicateToSlave("SET", eachKey, eachValue)
 		}
 	}
 
+	// Key 의 버전 (삭제된 Key 포함) 도 새로 매핑된 마스터의 데이터 로그로 옮긴다
+	for eachKey, eachVersion := range deadClientVersionContainer {
+		hashSlot.get(hash.GetHashSlotIndex(eachKey)).recordKeyVersion(eachKey, eachVersion)
+	}
+
 	return nil
 
 }
 
-// reshardData : 모든 마스터 클라이언트의 최신 데이터 로그 생성 & 현재 해쉬슬롯 기준 데이터 재분배
+// copyDataTo : masterClient의 데이터를 슬레이브에 복사
+//  데이터 로그파일을 읽어 최신 데이터 만을 복사한다
+//  Native 복제 모드에서는 REPLICAOF 설정 후 Redis의 Full Sync에 맡기고, 데이터 로그만 복사한다
 //
-func (redisClient RedisClient) reshardData() error {
-
-	for _, srcMasterClient := range redisMasterClients {
-
-		// 마스터 클라이언트의 로그 파일 읽기 => 최신 데이터 현황 생성
-		dataOfSrcMaster := make(HashToDataMap)
+func (masterClient RedisClient) copyDataTo(slaveClient RedisClient) error {
 
-		err := srcMasterClient.getLatestDataFromLog(dataOfSrcMaster)
-		if err != nil {
+	if replicationMode == NativeReplication {
+		if err := slaveClient.replicaOf(masterClient); err != nil {
 			return err
 		}
+	}
 
-		// 데이터 분배 후 데이터 최신 현황 변경에 따른, 로그 파일 재생성
-		srcMasterClient.removeDataLogFile()
-
-		if err := srcMasterClient.createDataLogFile(); err != nil {
-			return err
-		}
+	// masterClient의 최신 데이터 현황 생성
+	masterDataContainer := make(HashToDataMap)
+	masterExpireAtContainer := make(KeyExpireMap)
+	masterVersionContainer := make(KeyVersionMap)
+	if err := masterClient.getLatestDataFromLog(masterDataContainer, masterExpireAtContainer, masterVersionContainer); err != nil {
+		return err
+	}
 
-		// 마스터 클라이언트의 데이터를, 갱신된 해쉬 슬롯에 매핑된 마스터들에게 할당
-		for hashIndex, keyValueMap := range dataOfSrcMaster {
+	for _, keyValueMap := range masterDataContainer {
 
-			newMappedClient := hashSlot.slots[hashIndex]
+		for eachKey, eachValue := range keyValueMap {
 
-			// 갱신된 해쉬 슬롯에 매핑된 마스터가 변하지 않은 경우
-			if newMappedClient.Address == srcMasterClient.Address {
+			// tools.InfoLogger.Printf(
+			// 	msg.CopyFromMasterToSlave,
+			// 	masterClient.Address,
+			// 	eachKey,
+			// 	eachValue,
+			// 	slaveClient.Address,
+			// )
 
-				for eachKey, eachValue := range keyValueMap {
+			// 슬레이브에 데이터 복사 & 로그 기록 (Native 복제 모드에서는 로그만)
+			err := slaveClient.replayLoggedValue(eachKey, eachValue, replicationMode == ReplayReplication, false)
+			if err != nil {
+				return err
+			}
 
-					err := newMappedClient.RecordModificationLog("SET", eachKey, eachValue)
-					if err != nil {
-						return fmt.Errorf(msg.LogFailWhileMigration, newMappedClient.Address)
-					}
-				}
+			expireAt, isSet := masterExpireAtContainer[eachKey]
+			if !isSet {
+				continue
+			}
 
-			} else {
-				// 새로 매핑된 마스터인 경우
+			// 남은 만료 시간 복사
+			if replicationMode == ReplayReplication {
+				slaveClient.Connection.Do(PExpireAtCommand, eachKey, expireAt)
+			}
 
-				for eachKey, eachValue := range keyValueMap {
+			err = slaveClient.RecordModificationLog(PExpireAtCommand, eachKey, strconv.FormatInt(expireAt, 10))
+			if err != nil {
+				tools.ErrorLogger.Printf(msg.LogFailWhileMigration, slaveClient.Address)
+			}
+		}
+	}
 
-					tools.ErrorLogger.Printf("데이터 로그 key : %s, value : %s", eachKey, eachValue)
+	// Key 의 버전 복사 (Failover 로 승격되어도 같은 버전)
+	for eachKey, eachVersion := range masterVersionContainer {
+		err := slaveClient.RecordModificationLog(KeyVersionCommand, eachKey, strconv.FormatUint(eachVersion, 10))
+		if err != nil {
+			tools.ErrorLogger.Printf(msg.LogFailWhileMigration, slaveClient.Address)
+		}
+	}
 
-					redisResponse, err := redis.String(srcMasterClient.Connection.Do("GET", eachKey))
-					if err == redis.ErrNil {
-						redisResponse = "nil(없음)"
+	return nil
+}
 
-					} else if err != nil {
-						tools.ErrorLogger.Printf("데이터 가져오기 실패 key : %s, Error : %s", eachKey, err.Error())
-					}
+// RehashMisplacedKeys : 데이터 로그 기준, 해쉬 슬롯의 담당 마스터가 아닌 마스터에 남아있는 Key 들을 담당 마스터로 이동
+// 해쉬 함수 변경 (CRC16-CCITT -> CRC16-XMODEM, Hash Tag) 전에 저장된 Key 는 이전 해쉬 슬롯의 마스터에 남아 있다
+// datalog_migrate 로 데이터 로그를 변환한 뒤, 인터페이스 서버 시작 시 (요청 처리 전) 실행한다
+// 옮길 Key 가 없으면 데이터 로그만 읽는다, 이동한 Key 수 반환
+//
+func RehashMisplacedKeys() (int, error) {
 
-					tools.InfoLogger.Printf("키 : %s, 값 : %s", eachKey, redisResponse)
+	masters := append([]RedisClient{}, GetMasterClients()...)
+	movedKeys := 0
 
-					// 기존 데이터 주인이었던 마스터 클라이언트에서는 제거
-					_, err = srcMasterClient.Connection.Do("DEL", eachKey)
-					if err != nil {
-						tools.ErrorLogger.Printf("데이터 삭제간 에러!")
-						return fmt.Errorf(msg.DeleteDataFail, err.Error())
-					}
+	for _, eachMaster := range masters {
 
-					// tools.InfoLogger.Printf(
-					// 	msg.MigrateDataFromTo,
-					// 	srcMasterClient.Address,
-					// 	eachKey,
-					// 	eachValue,
-					// 	newMappedClient.Address,
-					// )
+		dataContainer := make(HashToDataMap)
+		if err := eachMaster.getLatestDataFromLog(dataContainer, nil, nil); err != nil {
+			return movedKeys, err
+		}
 
-					// 새로 매핑된 마스터에 저장
-					_, err = redis.String(newMappedClient.Connection.Do("SET", eachKey, eachValue))
+		for _, keyValueMap := range dataContainer {
+			for eachKey := range keyValueMap {
 
-					// 새로 매핑된 마스터가 중간에 죽어도, 로그 파일에는 기록을 해놓는다
-					err = newMappedClient.RecordModificationLog("SET", eachKey, eachValue)
-					if err != nil {
-						return fmt.Errorf(msg.LogFailWhileMigration, newMappedClient.Address)
-					}
+				owner := hashSlot.get(hash.GetHashSlotIndex(eachKey))
+				if owner.Address == eachMaster.Address {
+					continue
+				}
 
-					// 데이터를 redisClient로 옮긴 후, redisClient의 슬레이브에게도 전파
-					newMappedClient.ReplicateToSlave("SET", eachKey, eachValue)
+				if err := eachMaster.rehashKeyTo(owner, eachKey); err != nil {
+					return movedKeys, fmt.Errorf(msg.RehashKeyFail, eachKey, eachMaster.Address, owner.Address, err.Error())
 				}
+
+				movedKeys++
 			}
 		}
 	}
 
-	return nil
-
+	return movedKeys, nil
 }
 
-// copyDataTo : masterClient의 데이터를 슬레이브에 복사
-//  데이터 로그파일을 읽어 최신 데이터 만을 복사한다
+// rehashKeyTo : @key 를 담당 마스터 @ownerClient 로 이동
+// 다른 인터페이스 서버가 먼저 옮긴 Key 는 Redis 는 그대로 두고, 이 서버의 데이터 로그만 맞춘다
 //
-func (masterClient RedisClient) copyDataTo(slaveClient RedisClient) error {
+func (sourceClient RedisClient) rehashKeyTo(ownerClient RedisClient, key string) error {
 
-	// masterClient의 최신 데이터 현황 생성
-	masterDataContainer := make(HashToDataMap)
-	if err := masterClient.getLatestDataFromLog(masterDataContainer); err != nil {
+	existCount, err := redis.Int(sourceClient.Connection.Do("EXISTS", key))
+	if err != nil {
 		return err
 	}
 
-	for _, keyValueMap := range masterDataContainer {
+	if existCount > 0 {
+		return sourceClient.migrateKeyTo(ownerClient, key)
+	}
 
-		for eachKey, eachValue := range keyValueMap {
+	serializedValue, err := redis.Bytes(ownerClient.Connection.Do("DUMP", key))
+	if err != nil && err != redis.ErrNil {
+		return err
+	}
 
-			// tools.InfoLogger.Printf(
-			// 	msg.CopyFromMasterToSlave,
-			// 	masterClient.Address,
-			// 	eachKey,
-			// 	eachValue,
-			// 	slaveClient.Address,
-			// )
+	// 담당 마스터에 있는 Key (만료 / 삭제된 Key 는 이동 기록 없이 제거만)
+	if err == nil {
+		value := base64.StdEncoding.EncodeToString(serializedValue)
+		if err := ownerClient.RecordModificationLog(RestoreCommand, key, value); err != nil {
+			return err
+		}
 
-			// 슬레이브에 데이터 복사
-			_, err := redis.String(slaveClient.Connection.Do("SET", eachKey, eachValue))
-			if err != nil {
+		ttlInMillisecond, err := redis.Int64(ownerClient.Connection.Do("PTTL", key))
+		if err != nil {
+			return err
+		}
+
+		if ttlInMillisecond > 0 {
+			expireAt := toUnixMillisecond(time.Now()) + ttlInMillisecond
+			if err := ownerClient.recordExpiry(key, expireAt, false); err != nil {
 				return err
 			}
+		}
 
-			// 슬레이브가 중간에 죽어도, 로그 파일에는 기록을 해놓는다
-			err = slaveClient.RecordModificationLog("SET", eachKey, eachValue)
-			if err != nil {
-				tools.ErrorLogger.Printf(msg.LogFailWhileMigration, slaveClient.Address)
-			}
+		if version := GetKeyVersion(key); version > 0 {
+			ownerClient.recordKeyVersion(key, version)
 		}
 	}
 
-	return nil
+	return sourceClient.RecordModificationLog("DEL", key, "")
 }
@@ -4,18 +4,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
-	"hash_interface/configs"
+	"github.com/gomodule/redigo/redis"
+
 	msg "hash_interface/internal/storage/message"
 	"hash_interface/tools"
 )
 
 // MonitorClient : Monitor Server들에게 요청을 보낼 Client
+// ServerAddressList 는 모니터 서버들이 스스로 등록 / 해제 (MONITOR_REGISTER / MONITOR_DEREGISTER 엔트리)
+//
 type MonitorClient struct {
 	ServerAddressList []string
 }
 
+// monitorClient : 등록된 모니터 서버 목록, 직접 읽지 않고 currentMonitorClient() 로 복사해 사용
 var monitorClient MonitorClient
 
 // Question : 모니터 서버에게 요청할 수 있는 내용 옵션 종류
@@ -38,13 +44,149 @@ type MonitorServerResponse struct {
 
 var errorChannel chan error
 
+// monitorStatusStaleAfter : 모니터 서버가 알려온 상태를 투표에 세는 기간
+// 모니터 서버는 monitorStatusRefreshInterval 마다 모든 노드 상태를 다시 알린다 (알림이 끊기면 투표하지 않은 것으로 본다)
+//
+const monitorStatusStaleAfter = 3 * monitorStatusRefreshInterval
+
+// monitorStatus : 모니터 서버가 마지막으로 알려온 노드 상태와 받은 시각
+type monitorStatus struct {
+	event      MonitorEvent
+	receivedAt time.Time
+}
+
+// monitorView : 노드 주소 -> 모니터 서버들이 마지막으로 알려온 상태 (odown / sdown / up)
+var monitorView map[string]monitorStatus
+var monitorViewMutex = &sync.RWMutex{}
+
 func init() {
-	if len(monitorClient.ServerAddressList) == 0 {
-		monitorClient.ServerAddressList = []string{
-			configs.MonitorNodeOneAddress,
-			configs.MonitorNodeTwoAddress,
+	if monitorView == nil {
+		monitorView = make(map[string]monitorStatus)
+	}
+}
+
+// ApplyMonitorEvent : 모니터 서버가 알려온 노드 상태 (변화 또는 주기적인 갱신) 반영
+// odown 이 된 노드는 요청을 기다리지 않고 바로 처리 (마스터 : 슬레이브 승격, 슬레이브 : 재시작)
+//
+func ApplyMonitorEvent(event MonitorEvent) error {
+
+	switch event.State {
+	case NodeObjectivelyDown, NodeSubjectivelyDown, NodeUp:
+	default:
+		return fmt.Errorf(msg.UnsupportedMonitorEvent, event.State)
+	}
+
+	monitorViewMutex.Lock()
+	monitorView[event.Address] = monitorStatus{event: event, receivedAt: time.Now()}
+	monitorViewMutex.Unlock()
+
+	tools.InfoLogger.Printf(
+		msg.MonitorEventReceived,
+		event.Monitor,
+		event.Address,
+		event.State,
+		event.DownVotes,
+		event.Monitors,
+	)
+
+	if event.State == NodeObjectivelyDown {
+		go handleObjectivelyDown(event.Address)
+	}
+
+	return nil
+}
+
+// handleObjectivelyDown : odown 이 된 노드의 마스터-슬레이브 그룹 Lock 후 Failover 처리
+func handleObjectivelyDown(address string) {
+
+	if _, isMaster := redisMutexMap[address]; isMaster {
+
+		redisMutexMap[address].Lock()
+		defer redisMutexMap[address].Unlock()
+
+		// Lock 을 기다리는 동안 이미 처리되었을 수 있다
+		masterClient, err := GetMasterWithAddress(address)
+		if err != nil {
+			return
 		}
+
+		// Failover 요청 중 (TRYAGAIN) 은 실패가 아니다
+		targetClient := *masterClient
+		if err := targetClient.handleIfDead(); err != nil && !IsTryAgainError(err) {
+			tools.ErrorLogger.Printf(msg.FailoverByEventFail, address, err.Error())
+		}
+		return
+	}
+
+	masterClient, isSlave := slaveMasterMap[address]
+	if !isSlave {
+		return
+	}
+
+	redisMutexMap[masterClient.Address].Lock()
+	defer redisMutexMap[masterClient.Address].Unlock()
+
+	masterClient.checkSlaveAlive()
+}
+
+// votes : 모니터 서버들의 @redisNode 생존 / 죽음 투표 수
+// 최근 (monitorStatusStaleAfter 이내) 알려온 상태에서, 최근 관찰 결과가 있는 모니터들의 투표만 센다
+// 알려온 상태가 없거나 오래되었으면 어느 쪽으로도 투표하지 않는다 (살아있다고 보지 않는다)
+//
+func (monitorClient MonitorClient) votes(redisNode RedisClient, now time.Time) (aliveVotes int, downVotes int) {
+
+	monitorViewMutex.RLock()
+	status, isSet := monitorView[redisNode.Address]
+	monitorViewMutex.RUnlock()
+
+	if !isSet || now.Sub(status.receivedAt) > monitorStatusStaleAfter {
+		return 0, 0
+	}
+
+	// 등록된 모니터 수보다 많이 세지 않는다
+	numberOfMonitors := len(monitorClient.ServerAddressList)
+
+	downVotes = status.event.DownVotes
+	if downVotes > numberOfMonitors {
+		downVotes = numberOfMonitors
+	}
+
+	aliveVotes = status.event.Monitors - status.event.DownVotes
+	if aliveVotes > numberOfMonitors-downVotes {
+		aliveVotes = numberOfMonitors - downVotes
+	}
+	if aliveVotes < 0 {
+		aliveVotes = 0
+	}
+
+	return aliveVotes, downVotes
+}
+
+// voteAlive : 모니터 서버들과 호스트 인터페이스 서버의 생존 투표 결과, 호스트의 PING 에러 반환
+func (redisClient RedisClient) voteAlive() (VoteTally, error) {
+
+	monitors := currentMonitorClient()
+
+	tally := VoteTally{Total: monitors.totalVotes()}
+	tally.Alive, tally.Down = monitors.votes(redisClient, time.Now())
+
+	// 호스트 인터페이스 서버의 생존 확인/투표
+	hostPingResult, hostPingErr := redis.String(redisClient.Connection.Do("PING"))
+	if strings.Contains(hostPingResult, "PONG") {
+		tally.Alive++
+	} else {
+		tally.Down++
 	}
+
+	return tally, hostPingErr
+}
+
+// forgetMonitorView : 모니터 서버에 새로 등록/해제된 노드는 이전 상태를 잊는다
+func forgetMonitorView(address string) {
+
+	monitorViewMutex.Lock()
+	delete(monitorView, address)
+	monitorViewMutex.Unlock()
 }
 
 // askConnect : 모니터 서버들에게 @redisNode에 대한 연결 setup 요청
@@ -146,6 +288,10 @@ func (monitorClient MonitorClient) ask(
 		}
 	}
 
+	if question == NewConnect || question == EndConnect {
+		forgetMonitorView(redisNode.Address)
+	}
+
 	return votes, nil
 }
 
@@ -193,7 +339,7 @@ func (monitorClient MonitorClient) requestTest(
 
 	if err := decoder.Decode(&monitorServerResponse); err != nil {
 
-		tools.ErrorLogger.Println(
+		tools.ErrorLogger.Printf(
 			msg.ResponseMonitorError,
 			monitorServerIp,
 			monitorServerResponse.ErrorMsg,
@@ -282,7 +428,7 @@ func (monitorClient MonitorClient) requestNewConnect(
 	decoder := json.NewDecoder(response.Body)
 
 	if err := decoder.Decode(&monitorServerResponse); err != nil {
-		tools.ErrorLogger.Println(
+		tools.ErrorLogger.Printf(
 			msg.ResponseMonitorError,
 			monitorServerIp,
 			monitorServerResponse.ErrorMsg,
@@ -338,6 +484,7 @@ func (monitorClient MonitorClient) requestUnregister(
 	if err != nil {
 		tools.ErrorLogger.Printf(
 			msg.CreateRequestError,
+			monitorServerIp,
 			err,
 		)
 
@@ -379,7 +526,7 @@ func (monitorClient MonitorClient) requestUnregister(
 	decoder := json.NewDecoder(response.Body)
 
 	if err := decoder.Decode(&monitorServerResponse); err != nil {
-		tools.ErrorLogger.Println(
+		tools.ErrorLogger.Printf(
 			msg.ResponseMonitorError,
 			monitorServerIp,
 			monitorServerResponse.ErrorMsg,
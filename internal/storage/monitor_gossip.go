@@ -0,0 +1,444 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+// NodeState : 모니터 서버들이 판단한 레디스 노드 상태 (Redis Sentinel 과 같은 방식)
+//  - up : 모든 모니터가 살아있다고 판단
+//  - sdown (subjectively down) : 일부 모니터만 죽었다고 판단
+//  - odown (objectively down) : 정족수 이상의 모니터가 죽었다고 판단, 인터페이스 서버에 알린다
+//
+type NodeState string
+
+const (
+	NodeUp               NodeState = "up"
+	NodeSubjectivelyDown NodeState = "sdown"
+	NodeObjectivelyDown  NodeState = "odown"
+)
+
+// monitorRequestTimeout : 모니터 간 Gossip, 인터페이스 서버 알림 요청 타임아웃
+const monitorRequestTimeout = 2 * time.Second
+
+// peerRefreshTicks : 감시 주기 몇 번마다 인터페이스 서버에 등록된 모니터 목록으로 @peers 를 갱신할지
+const peerRefreshTicks = 10
+
+// monitorStatusRefreshInterval : 상태가 바뀌지 않아도 인터페이스 서버에 모든 노드 상태를 다시 알리는 주기
+// 인터페이스 서버는 monitorStatusStaleAfter 보다 오래된 상태를 투표에 세지 않는다
+//
+const monitorStatusRefreshInterval = 5 * time.Second
+
+// MonitorObservation : 모니터 서버 하나가 직접 PING 해 본 노드 상태
+type MonitorObservation struct {
+	Address   string    `json:"address"`
+	IsDown    bool      `json:"is_down"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// MonitorGossip : 모니터 서버끼리 주고받는 관찰 결과
+type MonitorGossip struct {
+	Monitor      string               `json:"monitor"`
+	Observations []MonitorObservation `json:"observations"`
+}
+
+// MonitorNodeStatus : 모니터 서버들의 관찰을 모은 노드 하나의 상태 (GET /monitor/status)
+//  - DownVotes : 죽었다고 판단한 모니터 수 (ReportedBy)
+//  - Monitors : 최근 관찰 결과가 있는 모니터 수 (자신 포함)
+//
+type MonitorNodeStatus struct {
+	Address    string    `json:"address"`
+	State      NodeState `json:"state"`
+	DownVotes  int       `json:"down_votes"`
+	Monitors   int       `json:"monitors"`
+	Quorum     int       `json:"quorum"`
+	ReportedBy []string  `json:"reported_by,omitempty"`
+	Since      time.Time `json:"since"`
+}
+
+// MonitorEvent : 모니터 서버 -> 인터페이스 서버, 노드의 odown / up 상태 변화와 주기적인 상태 갱신 알림
+type MonitorEvent struct {
+	Monitor string `json:"monitor"`
+	MonitorNodeStatus
+}
+
+// failureDetector : 모니터 서버의 노드 감시 & 모니터 간 Gossip 상태
+type failureDetector struct {
+	self             string
+	peers            []string
+	interfaceAddress string
+	interval         time.Duration
+	downAfter        time.Duration
+	quorum           int
+
+	mutex sync.RWMutex
+
+	// lastPong : 노드 주소 -> 마지막으로 PING 에 응답한 시각 (처음 감시를 시작한 시각)
+	lastPong map[string]time.Time
+
+	// observations : 모니터 주소 -> (노드 주소 -> 관찰 결과), receivedAt : 모니터 주소 -> 마지막 Gossip 수신 시각
+	observations map[string]map[string]MonitorObservation
+	receivedAt   map[string]time.Time
+
+	// states : 노드 주소 -> 합의된 상태, pushedStates : 인터페이스 서버에 마지막으로 알린 상태 (odown / up)
+	// refreshedAt : 인터페이스 서버에 모든 노드 상태를 마지막으로 다시 알린 시각
+	states       map[string]MonitorNodeStatus
+	pushedStates map[string]NodeState
+	refreshedAt  time.Time
+}
+
+var detector *failureDetector
+
+var monitorHTTPClient = &http.Client{Timeout: monitorRequestTimeout}
+
+// StartFailureDetector : 모니터 서버에서 @interval 마다
+//  1. 등록된 노드들을 직접 PING, @downAfter 동안 응답이 없으면 죽었다고 판단 (sdown)
+//  2. 관찰 결과를 다른 모니터 서버들(@peers)에게 전달 (Gossip)
+//  3. 모든 모니터의 관찰을 모아 정족수(과반수와 최소 정족수 중 큰 값) 이상이 죽었다고 보면 odown
+//  4. odown / up 으로 바뀐 노드를 인터페이스 서버(@interfaceAddress)에 알림, monitorStatusRefreshInterval 마다 모든 노드 상태를 다시 알림
+// @peers 와 정족수는 인터페이스 서버에 등록된 모니터 목록으로 주기적으로 갱신된다
+//
+func StartFailureDetector(
+	self string,
+	peers []string,
+	interfaceAddress string,
+	interval time.Duration,
+	downAfter time.Duration,
+) {
+
+	detector = &failureDetector{
+		self:             self,
+		peers:            peers,
+		interfaceAddress: interfaceAddress,
+		interval:         interval,
+		downAfter:        downAfter,
+		quorum:           quorumOf(len(peers) + 1),
+		lastPong:         make(map[string]time.Time),
+		observations:     make(map[string]map[string]MonitorObservation),
+		receivedAt:       make(map[string]time.Time),
+		states:           make(map[string]MonitorNodeStatus),
+		pushedStates:     make(map[string]NodeState),
+	}
+
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		for tick := 0; ; tick++ {
+			<-ticker.C
+
+			if tick%peerRefreshTicks == 0 {
+				detector.refreshPeers()
+			}
+
+			gossip := detector.observe(time.Now())
+			detector.gossip(gossip)
+			detector.pushChangedStates(detector.decide(time.Now()))
+		}
+	}()
+}
+
+// ReceiveMonitorGossip : 다른 모니터 서버의 관찰 결과 기록
+func ReceiveMonitorGossip(gossip MonitorGossip) error {
+
+	if detector == nil {
+		return fmt.Errorf(msg.FailureDetectorNotStarted)
+	}
+
+	observations := make(map[string]MonitorObservation)
+	for _, eachObservation := range gossip.Observations {
+		observations[eachObservation.Address] = eachObservation
+	}
+
+	detector.mutex.Lock()
+	detector.observations[gossip.Monitor] = observations
+	detector.receivedAt[gossip.Monitor] = time.Now()
+	detector.mutex.Unlock()
+
+	return nil
+}
+
+// GetMonitorStatus : 모니터 서버들이 합의한 모든 노드의 상태 (주소 순)
+func GetMonitorStatus() ([]MonitorNodeStatus, error) {
+
+	if detector == nil {
+		return nil, fmt.Errorf(msg.FailureDetectorNotStarted)
+	}
+
+	detector.mutex.RLock()
+	defer detector.mutex.RUnlock()
+
+	statuses := make([]MonitorNodeStatus, 0, len(detector.states))
+	for _, eachStatus := range detector.states {
+		statuses = append(statuses, eachStatus)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Address < statuses[j].Address
+	})
+
+	return statuses, nil
+}
+
+// observe : 등록된 노드들을 PING 해 자신의 관찰 결과 갱신
+func (detector *failureDetector) observe(now time.Time) MonitorGossip {
+
+	nodes := getWatchedNodeAddresses()
+
+	// 죽은 노드의 타임아웃을 기다리지 않도록 동시에 PING
+	isPonged := make([]bool, len(nodes))
+	waitGroup := sync.WaitGroup{}
+	for i, eachNode := range nodes {
+		waitGroup.Add(1)
+		go func(i int, address string) {
+			defer waitGroup.Done()
+			isPonged[i], _ = PingWatchedNode(address, detector.interval)
+		}(i, eachNode)
+	}
+	waitGroup.Wait()
+
+	detector.mutex.Lock()
+	defer detector.mutex.Unlock()
+
+	observations := make(map[string]MonitorObservation)
+	gossip := MonitorGossip{Monitor: detector.self}
+
+	for i, eachNode := range nodes {
+
+		lastPong, isSet := detector.lastPong[eachNode]
+		if isPonged[i] || !isSet {
+			lastPong = now
+			detector.lastPong[eachNode] = now
+		}
+
+		observation := MonitorObservation{
+			Address:   eachNode,
+			IsDown:    now.Sub(lastPong) >= detector.downAfter,
+			CheckedAt: now,
+		}
+
+		observations[eachNode] = observation
+		gossip.Observations = append(gossip.Observations, observation)
+	}
+
+	// 감시 대상에서 제거된 노드
+	for eachAddress := range detector.lastPong {
+		if _, isSet := observations[eachAddress]; !isSet {
+			delete(detector.lastPong, eachAddress)
+		}
+	}
+
+	detector.observations[detector.self] = observations
+	detector.receivedAt[detector.self] = now
+
+	return gossip
+}
+
+// gossip : 다른 모니터 서버들에게 관찰 결과 전달 (응답은 기다리지 않는다)
+func (detector *failureDetector) gossip(gossip MonitorGossip) {
+
+	encodedGossip, err := json.Marshal(gossip)
+	if err != nil {
+		return
+	}
+
+	detector.mutex.RLock()
+	peers := detector.peers
+	detector.mutex.RUnlock()
+
+	for _, eachPeer := range peers {
+
+		go func(peer string) {
+
+			response, err := monitorHTTPClient.Post(
+				fmt.Sprintf("http://%s/monitor/gossip", peer),
+				"application/json",
+				bytes.NewReader(encodedGossip),
+			)
+			if err != nil {
+				tools.ErrorLogger.Printf(msg.GossipSendFail, peer, err.Error())
+				return
+			}
+			response.Body.Close()
+		}(eachPeer)
+	}
+}
+
+// refreshPeers : 인터페이스 서버에 등록된 모니터 목록(자신 제외)으로 @peers, 정족수 갱신
+// 조회에 실패하면 이전 목록을 그대로 사용한다
+//
+func (detector *failureDetector) refreshPeers() {
+
+	registry, err := FetchMonitorRegistry(detector.interfaceAddress)
+	if err != nil {
+		tools.ErrorLogger.Printf(msg.MonitorRegistryFetchFail, detector.interfaceAddress, err.Error())
+		return
+	}
+
+	peers := []string{}
+	for _, eachMonitor := range registry.Monitors {
+		if eachMonitor != detector.self {
+			peers = append(peers, eachMonitor)
+		}
+	}
+
+	detector.mutex.Lock()
+	detector.peers = peers
+	detector.quorum = quorumOf(len(peers) + 1)
+	detector.mutex.Unlock()
+}
+
+// decide : 최근 관찰 결과들로 노드 별 상태 결정, odown / up 이 인터페이스 서버에 알린 상태와 다른 노드들 반환
+// monitorStatusRefreshInterval 이 지났으면 모든 노드 반환 (sdown 포함, 인터페이스 서버가 최근 투표 수로 판단하도록)
+// 오래된 (Gossip 주기 3번 이상 지난) 관찰 결과는 세지 않는다
+//
+func (detector *failureDetector) decide(now time.Time) []MonitorNodeStatus {
+
+	detector.mutex.Lock()
+	defer detector.mutex.Unlock()
+
+	staleAfter := 3 * detector.interval
+	nextStates := make(map[string]MonitorNodeStatus)
+
+	for eachAddress := range detector.observations[detector.self] {
+
+		status := MonitorNodeStatus{
+			Address: eachAddress,
+			Quorum:  detector.quorum,
+		}
+
+		for eachMonitor, eachObservations := range detector.observations {
+
+			if now.Sub(detector.receivedAt[eachMonitor]) > staleAfter {
+				continue
+			}
+
+			observation, isObserved := eachObservations[eachAddress]
+			if !isObserved {
+				continue
+			}
+
+			status.Monitors++
+			if observation.IsDown {
+				status.DownVotes++
+				status.ReportedBy = append(status.ReportedBy, eachMonitor)
+			}
+		}
+		sort.Strings(status.ReportedBy)
+
+		switch {
+		case status.DownVotes >= detector.quorum:
+			status.State = NodeObjectivelyDown
+		case status.DownVotes > 0:
+			status.State = NodeSubjectivelyDown
+		default:
+			status.State = NodeUp
+		}
+
+		status.Since = now
+		if previousStatus, isSet := detector.states[eachAddress]; isSet && previousStatus.State == status.State {
+			status.Since = previousStatus.Since
+		}
+
+		nextStates[eachAddress] = status
+	}
+
+	detector.states = nextStates
+
+	isRefresh := now.Sub(detector.refreshedAt) >= monitorStatusRefreshInterval
+	if isRefresh {
+		detector.refreshedAt = now
+	}
+
+	changedStates := []MonitorNodeStatus{}
+	for eachAddress, eachStatus := range nextStates {
+
+		if isRefresh {
+			changedStates = append(changedStates, eachStatus)
+			continue
+		}
+
+		// 상태 변화는 odown / up 만 알린다
+		// sdown 은 알린 상태를 유지 (모니터 하나의 Gossip 이 끊겨 odown 에서 바로 up 이 되지 않도록)
+		if eachStatus.State == NodeSubjectivelyDown {
+			continue
+		}
+
+		pushedState, isPushed := detector.pushedStates[eachAddress]
+		if !isPushed {
+			pushedState = NodeUp
+		}
+
+		if eachStatus.State != pushedState {
+			changedStates = append(changedStates, eachStatus)
+		}
+	}
+
+	// 감시 대상에서 제거된 노드
+	for eachAddress := range detector.pushedStates {
+		if _, isSet := nextStates[eachAddress]; !isSet {
+			delete(detector.pushedStates, eachAddress)
+		}
+	}
+
+	return changedStates
+}
+
+// pushChangedStates : 인터페이스 서버에 상태 변화 알림, 실패하면 다음 주기에 다시 알린다
+func (detector *failureDetector) pushChangedStates(changedStates []MonitorNodeStatus) {
+
+	for _, eachStatus := range changedStates {
+
+		event := MonitorEvent{
+			Monitor:           detector.self,
+			MonitorNodeStatus: eachStatus,
+		}
+
+		if err := pushMonitorEvent(detector.interfaceAddress, event); err != nil {
+			tools.ErrorLogger.Printf(msg.MonitorEventPushFail, eachStatus.Address, eachStatus.State, err.Error())
+			continue
+		}
+
+		// sdown 은 투표 수만 갱신, 알린 상태 (odown / up) 는 유지
+		if eachStatus.State == NodeSubjectivelyDown {
+			continue
+		}
+
+		tools.InfoLogger.Printf(msg.MonitorEventPushed, eachStatus.Address, eachStatus.State, eachStatus.DownVotes, eachStatus.Monitors)
+
+		detector.mutex.Lock()
+		detector.pushedStates[eachStatus.Address] = eachStatus.State
+		detector.mutex.Unlock()
+	}
+}
+
+func pushMonitorEvent(interfaceAddress string, event MonitorEvent) error {
+
+	encodedEvent, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	response, err := monitorHTTPClient.Post(
+		fmt.Sprintf("http://%s/api/v1/monitor/events", interfaceAddress),
+		"application/json",
+		bytes.NewReader(encodedEvent),
+	)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf(msg.MonitorEventRejected, response.StatusCode)
+	}
+
+	return nil
+}
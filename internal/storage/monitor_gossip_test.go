@@ -0,0 +1,289 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuorumOf(t *testing.T) {
+
+	fixtures := []struct {
+		minimumQuorum  int
+		totalVotes     int
+		expectedQuorum int
+	}{
+		{minimumQuorum: 2, totalVotes: 1, expectedQuorum: 2},
+		{minimumQuorum: 2, totalVotes: 2, expectedQuorum: 2},
+		{minimumQuorum: 2, totalVotes: 3, expectedQuorum: 2},
+		{minimumQuorum: 2, totalVotes: 4, expectedQuorum: 3},
+		{minimumQuorum: 2, totalVotes: 5, expectedQuorum: 3},
+		{minimumQuorum: 1, totalVotes: 1, expectedQuorum: 1},
+		{minimumQuorum: 4, totalVotes: 5, expectedQuorum: 4},
+	}
+
+	defer func() { minimumMonitorQuorum = DefaultMonitorQuorum }()
+
+	for _, fixture := range fixtures {
+
+		minimumMonitorQuorum = fixture.minimumQuorum
+
+		if quorum := quorumOf(fixture.totalVotes); quorum != fixture.expectedQuorum {
+			t.Errorf(
+				"quorumOf(%d) (최소 %d) = %d, expected : %d",
+				fixture.totalVotes,
+				fixture.minimumQuorum,
+				quorum,
+				fixture.expectedQuorum,
+			)
+		}
+	}
+}
+
+func TestSetMonitorQuorum(t *testing.T) {
+
+	fixtures := map[string]int{
+		"":  DefaultMonitorQuorum,
+		"1": 1,
+		"3": 3,
+	}
+
+	defer func() { minimumMonitorQuorum = DefaultMonitorQuorum }()
+
+	for quorum, expectedQuorum := range fixtures {
+		if err := SetMonitorQuorum(quorum); err != nil {
+			t.Errorf("SetMonitorQuorum(%q) 에러 : %s", quorum, err.Error())
+			continue
+		}
+
+		if minimumMonitorQuorum != expectedQuorum {
+			t.Errorf("SetMonitorQuorum(%q) = %d, expected : %d", quorum, minimumMonitorQuorum, expectedQuorum)
+		}
+	}
+
+	for _, invalidQuorum := range []string{"0", "-1", "two"} {
+		if err := SetMonitorQuorum(invalidQuorum); err == nil {
+			t.Errorf("SetMonitorQuorum(%q) 에 에러가 없습니다", invalidQuorum)
+		}
+	}
+}
+
+// newTestDetector : @self 와 @peers 모니터의 관찰 결과 (모니터 -> 죽었다고 본 노드들), @staleMonitors 는 Gossip 이 끊긴 모니터
+func newTestDetector(self string, peers []string, now time.Time, downNodes map[string][]string, staleMonitors map[string]bool) *failureDetector {
+
+	testDetector := &failureDetector{
+		self:         self,
+		peers:        peers,
+		interval:     time.Second,
+		quorum:       quorumOf(len(peers) + 1),
+		observations: make(map[string]map[string]MonitorObservation),
+		receivedAt:   make(map[string]time.Time),
+		states:       make(map[string]MonitorNodeStatus),
+		pushedStates: make(map[string]NodeState),
+		refreshedAt:  now,
+	}
+
+	for _, eachMonitor := range append([]string{self}, peers...) {
+
+		observations := make(map[string]MonitorObservation)
+		for _, eachNode := range []string{"node-a", "node-b"} {
+			observations[eachNode] = MonitorObservation{Address: eachNode, CheckedAt: now}
+		}
+		for _, eachNode := range downNodes[eachMonitor] {
+			observations[eachNode] = MonitorObservation{Address: eachNode, IsDown: true, CheckedAt: now}
+		}
+
+		testDetector.observations[eachMonitor] = observations
+		testDetector.receivedAt[eachMonitor] = now
+		if staleMonitors[eachMonitor] {
+			testDetector.receivedAt[eachMonitor] = now.Add(-time.Minute)
+		}
+	}
+
+	return testDetector
+}
+
+func TestFailureDetectorDecide(t *testing.T) {
+
+	now := time.Now()
+
+	fixtures := []struct {
+		name             string
+		peers            []string
+		downNodes        map[string][]string
+		staleMonitors    map[string]bool
+		expectedState    NodeState
+		expectedMonitors int
+	}{
+		{
+			name:             "모니터 하나뿐이면 혼자 odown 판단하지 않는다",
+			downNodes:        map[string][]string{"self": {"node-a"}},
+			expectedState:    NodeSubjectivelyDown,
+			expectedMonitors: 1,
+		},
+		{
+			name:             "모니터 둘 모두 죽었다고 판단",
+			peers:            []string{"peer-1"},
+			downNodes:        map[string][]string{"self": {"node-a"}, "peer-1": {"node-a"}},
+			expectedState:    NodeObjectivelyDown,
+			expectedMonitors: 2,
+		},
+		{
+			name:             "모니터 셋 중 둘 (과반수)",
+			peers:            []string{"peer-1", "peer-2"},
+			downNodes:        map[string][]string{"self": {"node-a"}, "peer-2": {"node-a"}},
+			expectedState:    NodeObjectivelyDown,
+			expectedMonitors: 3,
+		},
+		{
+			name:             "Gossip 이 끊긴 모니터의 관찰은 세지 않는다",
+			peers:            []string{"peer-1", "peer-2"},
+			downNodes:        map[string][]string{"self": {"node-a"}, "peer-2": {"node-a"}},
+			staleMonitors:    map[string]bool{"peer-2": true},
+			expectedState:    NodeSubjectivelyDown,
+			expectedMonitors: 2,
+		},
+		{
+			name:             "모두 살아있다고 판단",
+			peers:            []string{"peer-1"},
+			expectedState:    NodeUp,
+			expectedMonitors: 2,
+		},
+	}
+
+	for _, fixture := range fixtures {
+
+		testDetector := newTestDetector("self", fixture.peers, now, fixture.downNodes, fixture.staleMonitors)
+		testDetector.decide(now)
+
+		status := testDetector.states["node-a"]
+		if status.State != fixture.expectedState || status.Monitors != fixture.expectedMonitors {
+			t.Errorf(
+				"%s : decide() = (%s, monitors %d), expected : (%s, monitors %d)",
+				fixture.name,
+				status.State,
+				status.Monitors,
+				fixture.expectedState,
+				fixture.expectedMonitors,
+			)
+		}
+	}
+}
+
+func TestFailureDetectorDecideRefresh(t *testing.T) {
+
+	now := time.Now()
+	testDetector := newTestDetector("self", []string{"peer-1"}, now, map[string][]string{"self": {"node-a"}}, nil)
+
+	// 상태 변화가 없으면 (up, sdown) 알리지 않는다
+	if changedStates := testDetector.decide(now); len(changedStates) != 0 {
+		t.Errorf("decide() 변화 없는 상태 %v 를 알립니다", changedStates)
+	}
+
+	// 갱신 주기가 지나면 sdown 을 포함한 모든 노드 상태를 알린다
+	refreshAt := now.Add(monitorStatusRefreshInterval)
+	if changedStates := testDetector.decide(refreshAt); len(changedStates) != 2 {
+		t.Errorf("decide() 갱신 주기에 %d 개 노드 상태, expected : 2", len(changedStates))
+	}
+}
+
+func TestMonitorVotes(t *testing.T) {
+
+	now := time.Now()
+	monitors := MonitorClient{ServerAddressList: []string{"monitor-1", "monitor-2", "monitor-3"}}
+
+	fixtures := []struct {
+		name          string
+		status        *monitorStatus
+		expectedAlive int
+		expectedDown  int
+	}{
+		{
+			name:          "알려온 상태가 없으면 투표하지 않는다",
+			expectedAlive: 0,
+			expectedDown:  0,
+		},
+		{
+			name: "최근 관찰한 모니터들만 투표",
+			status: &monitorStatus{
+				event:      MonitorEvent{MonitorNodeStatus: MonitorNodeStatus{State: NodeSubjectivelyDown, DownVotes: 1, Monitors: 2}},
+				receivedAt: now,
+			},
+			expectedAlive: 1,
+			expectedDown:  1,
+		},
+		{
+			name: "odown",
+			status: &monitorStatus{
+				event:      MonitorEvent{MonitorNodeStatus: MonitorNodeStatus{State: NodeObjectivelyDown, DownVotes: 3, Monitors: 3}},
+				receivedAt: now,
+			},
+			expectedAlive: 0,
+			expectedDown:  3,
+		},
+		{
+			name: "오래된 상태는 투표하지 않는다",
+			status: &monitorStatus{
+				event:      MonitorEvent{MonitorNodeStatus: MonitorNodeStatus{State: NodeUp, Monitors: 3}},
+				receivedAt: now.Add(-monitorStatusStaleAfter - time.Second),
+			},
+			expectedAlive: 0,
+			expectedDown:  0,
+		},
+		{
+			name: "등록된 모니터 수보다 많이 세지 않는다",
+			status: &monitorStatus{
+				event:      MonitorEvent{MonitorNodeStatus: MonitorNodeStatus{State: NodeUp, Monitors: 5}},
+				receivedAt: now,
+			},
+			expectedAlive: 3,
+			expectedDown:  0,
+		},
+	}
+
+	defer forgetMonitorView("node-a")
+
+	for _, fixture := range fixtures {
+
+		forgetMonitorView("node-a")
+		if fixture.status != nil {
+			monitorViewMutex.Lock()
+			monitorView["node-a"] = *fixture.status
+			monitorViewMutex.Unlock()
+		}
+
+		aliveVotes, downVotes := monitors.votes(RedisClient{Address: "node-a"}, now)
+		if aliveVotes != fixture.expectedAlive || downVotes != fixture.expectedDown {
+			t.Errorf(
+				"%s : votes() = (%d, %d), expected : (%d, %d)",
+				fixture.name,
+				aliveVotes,
+				downVotes,
+				fixture.expectedAlive,
+				fixture.expectedDown,
+			)
+		}
+	}
+}
+
+func TestVoteTallyIsDown(t *testing.T) {
+
+	fixtures := []struct {
+		tally        VoteTally
+		expectedDown bool
+	}{
+		// 모니터 서버 없이 호스트 PING 실패 하나로는 판단하지 않는다
+		{tally: VoteTally{Alive: 0, Down: 1, Total: 1}, expectedDown: false},
+		{tally: VoteTally{Alive: 0, Down: 2, Total: 2}, expectedDown: true},
+		// 모니터 둘이 응답하지 않아도 살아있다고 세지 않는다
+		{tally: VoteTally{Alive: 0, Down: 1, Total: 3}, expectedDown: false},
+		{tally: VoteTally{Alive: 1, Down: 2, Total: 3}, expectedDown: true},
+		{tally: VoteTally{Alive: 2, Down: 2, Total: 5}, expectedDown: false},
+		{tally: VoteTally{Alive: 2, Down: 3, Total: 5}, expectedDown: true},
+	}
+
+	for _, fixture := range fixtures {
+		if isDown := fixture.tally.isDown(); isDown != fixture.expectedDown {
+			t.Errorf("%+v.isDown() = %v, expected : %v", fixture.tally, isDown, fixture.expectedDown)
+		}
+	}
+}
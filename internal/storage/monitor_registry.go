@@ -0,0 +1,227 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+const (
+	// MonitorRegisterCommand / MonitorDeregisterCommand : 모니터 서버 등록 / 해제, Key 에 모니터 서버 주소
+	// Raft 로그로 모든 인터페이스 서버에 같은 순서로 반영되어, 모니터 목록과 정족수가 일치한다
+	//
+	MonitorRegisterCommand   = "MONITOR_REGISTER"
+	MonitorDeregisterCommand = "MONITOR_DEREGISTER"
+)
+
+// monitorClientMutex : 모니터 서버 목록(monitorClient.ServerAddressList) 보호
+var monitorClientMutex = &sync.RWMutex{}
+
+// DefaultMonitorQuorum : MONITOR_QUORUM 을 설정하지 않았을 때의 최소 정족수
+// 모니터 서버가 하나뿐이거나 없어도, 투표 하나로 노드가 죽었다고 판단하지 않는다
+//
+const DefaultMonitorQuorum = 2
+
+var minimumMonitorQuorum = DefaultMonitorQuorum
+
+// SetMonitorQuorum : 노드가 죽었다고 판단하는 데 필요한 최소 투표 수 설정 ("" 이면 DefaultMonitorQuorum)
+func SetMonitorQuorum(quorum string) error {
+
+	if quorum == "" {
+		minimumMonitorQuorum = DefaultMonitorQuorum
+		return nil
+	}
+
+	parsedQuorum, err := strconv.Atoi(quorum)
+	if err != nil || parsedQuorum < 1 {
+		return fmt.Errorf(msg.InvalidMonitorQuorum, quorum)
+	}
+
+	minimumMonitorQuorum = parsedQuorum
+
+	return nil
+}
+
+// quorumOf : 전체 @totalVotes 중 죽었다고 판단하는 데 필요한 투표 수, 과반수와 최소 정족수 중 큰 값
+func quorumOf(totalVotes int) int {
+
+	quorum := totalVotes/2 + 1
+	if quorum < minimumMonitorQuorum {
+		return minimumMonitorQuorum
+	}
+
+	return quorum
+}
+
+// MonitorRegistry : 등록된 모니터 서버 목록과 생존 투표 정족수
+//  - TotalVotes : 모니터 서버 수 + 호스트 인터페이스 서버 (handleIfDead 등의 투표 수)
+//  - Quorum : 죽었다고 판단하는 데 필요한 최소 투표 수 (과반수와 설정된 최소 정족수 중 큰 값)
+//
+type MonitorRegistry struct {
+	Monitors   []string `json:"monitors"`
+	TotalVotes int      `json:"total_votes"`
+	Quorum     int      `json:"quorum"`
+}
+
+// IsMonitorCommand : 모니터 서버 등록 / 해제 명령인지
+func IsMonitorCommand(command string) bool {
+
+	switch command {
+	case MonitorRegisterCommand, MonitorDeregisterCommand:
+		return true
+	}
+
+	return false
+}
+
+// validateMonitorEntry : 모니터 서버 주소가 host:port 형식인지
+func validateMonitorEntry(entry KeyValuePair) error {
+
+	if _, _, err := net.SplitHostPort(entry.Key); err != nil {
+		return fmt.Errorf(msg.InvalidMonitorAddress, entry.Key, err.Error())
+	}
+
+	return nil
+}
+
+// executeMonitorEntry : 모니터 서버 목록에 등록 / 해제 반영
+// 커밋된 엔트리를 다시 반영해도 같은 결과가 되도록, 이미 등록된 (해제된) 모니터는 그대로 둔다
+//
+func executeMonitorEntry(entry KeyValuePair) error {
+
+	monitorClientMutex.Lock()
+	defer monitorClientMutex.Unlock()
+
+	index := -1
+	for i, eachAddress := range monitorClient.ServerAddressList {
+		if eachAddress == entry.Key {
+			index = i
+			break
+		}
+	}
+
+	switch entry.Command {
+	case MonitorRegisterCommand:
+		if index >= 0 {
+			return nil
+		}
+
+		// 새 슬라이스로 교체 (복사해 간 목록은 그대로)
+		monitors := make([]string, 0, len(monitorClient.ServerAddressList)+1)
+		monitors = append(monitors, monitorClient.ServerAddressList...)
+		monitorClient.ServerAddressList = append(monitors, entry.Key)
+
+	case MonitorDeregisterCommand:
+		if index < 0 {
+			return nil
+		}
+
+		monitors := make([]string, 0, len(monitorClient.ServerAddressList)-1)
+		monitors = append(monitors, monitorClient.ServerAddressList[:index]...)
+		monitorClient.ServerAddressList = append(monitors, monitorClient.ServerAddressList[index+1:]...)
+	}
+
+	tools.InfoLogger.Printf(
+		msg.MonitorRegistryChanged,
+		entry.Command,
+		entry.Key,
+		len(monitorClient.ServerAddressList),
+	)
+
+	return nil
+}
+
+// currentMonitorClient : 현재 등록된 모니터 서버 목록의 MonitorClient
+func currentMonitorClient() MonitorClient {
+
+	monitorClientMutex.RLock()
+	defer monitorClientMutex.RUnlock()
+
+	return monitorClient
+}
+
+// totalVotes : 생존 투표 수 (모니터 서버들 + 호스트 인터페이스 서버)
+func (monitorClient MonitorClient) totalVotes() int {
+	return len(monitorClient.ServerAddressList) + 1
+}
+
+// GetMonitorRegistry : 등록된 모니터 서버 목록 (주소 순)
+func GetMonitorRegistry() MonitorRegistry {
+
+	currentClient := currentMonitorClient()
+
+	monitors := make([]string, len(currentClient.ServerAddressList))
+	copy(monitors, currentClient.ServerAddressList)
+	sort.Strings(monitors)
+
+	return MonitorRegistry{
+		Monitors:   monitors,
+		TotalVotes: currentClient.totalVotes(),
+		Quorum:     quorumOf(currentClient.totalVotes()),
+	}
+}
+
+// RegisterToInterface : 모니터 서버 @self 를 인터페이스 서버에 등록
+func RegisterToInterface(interfaceAddress string, self string) error {
+	return requestMonitorRegistry(http.MethodPost, interfaceAddress, self)
+}
+
+// DeregisterFromInterface : 모니터 서버 @self 를 인터페이스 서버에서 해제
+func DeregisterFromInterface(interfaceAddress string, self string) error {
+	return requestMonitorRegistry(http.MethodDelete, interfaceAddress, self)
+}
+
+// FetchMonitorRegistry : 인터페이스 서버에 등록된 모니터 서버 목록 조회
+func FetchMonitorRegistry(interfaceAddress string) (MonitorRegistry, error) {
+
+	response, err := monitorHTTPClient.Get(
+		fmt.Sprintf("http://%s/api/v1/monitors", interfaceAddress),
+	)
+	if err != nil {
+		return MonitorRegistry{}, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return MonitorRegistry{}, fmt.Errorf(msg.MonitorRegistryRejected, response.StatusCode)
+	}
+
+	registry := MonitorRegistry{}
+	if err := json.NewDecoder(response.Body).Decode(&registry); err != nil {
+		return MonitorRegistry{}, err
+	}
+
+	return registry, nil
+}
+
+func requestMonitorRegistry(method string, interfaceAddress string, self string) error {
+
+	request, err := http.NewRequest(
+		method,
+		fmt.Sprintf("http://%s/api/v1/monitors/%s", interfaceAddress, self),
+		bytes.NewReader(nil),
+	)
+	if err != nil {
+		return err
+	}
+
+	response, err := monitorHTTPClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf(msg.MonitorRegistryRejected, response.StatusCode)
+	}
+
+	return nil
+}
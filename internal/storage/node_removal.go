@@ -0,0 +1,219 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+// RemoveNode : 등록된 노드(마스터 / 슬레이브)를 계획적으로 제거
+//  - 마스터 : 해쉬 슬롯을 다른 마스터들에게 Live Migration 으로 옮긴 뒤 제거
+//             슬레이브는 슬레이브가 없는 다른 마스터에게 옮기고, 그런 마스터가 없으면 함께 제거
+//  - 슬레이브 : 마스터와의 복제를 끊고 제거
+// 제거된 노드는 모니터 서버의 감시 대상에서도 빠진다 (EndConnect)
+//
+func RemoveNode(address string) error {
+
+	addClientMutex.Lock()
+	defer addClientMutex.Unlock()
+
+	if masterClient, err := GetMasterWithAddress(address); err == nil {
+		return removeMaster(*masterClient)
+	}
+
+	if slaveClient, err := GetSlaveClientWithAddress(address); err == nil {
+		return detachSlave(*slaveClient)
+	}
+
+	return fmt.Errorf(msg.NodeNotRegistered, address)
+}
+
+// removeMaster : 호출 전 addClientMutex Lock 필요
+func removeMaster(masterClient RedisClient) error {
+
+	if len(redisMasterClients) < 2 {
+		return fmt.Errorf(msg.LastMasterRemoval, masterClient.Address)
+	}
+
+	if isMigratingWith(masterClient.Address) {
+		return fmt.Errorf(msg.FailoverDuringMigration, masterClient.Address)
+	}
+
+	// 가중치 0 : 모든 해쉬 슬롯을 내어준다 (이동 중에도 요청 처리 가능)
+	if _, err := hashSlot.rebalance(map[string]float64{masterClient.Address: 0}); err != nil {
+		return err
+	}
+
+	if slaveClient, hasSlave := masterSlaveMap[masterClient.Address]; hasSlave {
+
+		var err error
+		if newMaster, isFound := findMasterWithoutSlave(masterClient.Address); isFound {
+			err = slaveClient.rehomeTo(newMaster)
+		} else {
+			err = detachSlave(slaveClient)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	groupMutex := redisMutexMap[masterClient.Address]
+	groupMutex.Lock()
+	defer groupMutex.Unlock()
+
+	if err := masterClient.RemoveFromList(); err != nil {
+		return err
+	}
+	relinkMasterSlots()
+
+	if _, err := currentMonitorClient().ask(masterClient, EndConnect); err != nil {
+		tools.ErrorLogger.Printf(msg.MonitorUnregisterFail, masterClient.Address)
+	}
+
+	if err := masterClient.removeDataLogFile(); err != nil {
+		tools.ErrorLogger.Println(err.Error())
+	}
+
+	delete(clientHashRangeMap, masterClient.Address)
+	delete(MasterSlaveChannelMap, masterClient.Address)
+	delete(masterSlaveMap, masterClient.Address)
+	masterClient.forget()
+
+	tools.InfoLogger.Printf(msg.NodeRemoved, masterClient.Role, masterClient.Address)
+
+	return nil
+}
+
+// detachSlave : 슬레이브의 복제를 끊고 제거, 노드의 데이터는 그대로 둔다
+// 호출 전 addClientMutex Lock 필요
+//
+func detachSlave(slaveClient RedisClient) error {
+
+	groupMutex := redisMutexMap[slaveClient.Address]
+	groupMutex.Lock()
+	defer groupMutex.Unlock()
+
+	if replicationMode == NativeReplication {
+		if err := slaveClient.stopReplication(); err != nil {
+			return err
+		}
+	}
+
+	if err := slaveClient.RemoveFromList(); err != nil {
+		return err
+	}
+
+	if _, err := currentMonitorClient().ask(slaveClient, EndConnect); err != nil {
+		tools.ErrorLogger.Printf(msg.MonitorUnregisterFail, slaveClient.Address)
+	}
+
+	if err := slaveClient.removeDataLogFile(); err != nil {
+		tools.ErrorLogger.Println(err.Error())
+	}
+
+	if masterClient, isSet := slaveMasterMap[slaveClient.Address]; isSet {
+		delete(masterSlaveMap, masterClient.Address)
+	}
+	delete(slaveMasterMap, slaveClient.Address)
+	slaveClient.forget()
+
+	tools.InfoLogger.Printf(msg.NodeRemoved, slaveClient.Role, slaveClient.Address)
+
+	return nil
+}
+
+// rehomeTo : 슬레이브를 비우고 @newMaster 의 슬레이브로 다시 연결
+// 이미 @newMaster 의 슬레이브이면 (ex. 같은 제거 요청을 다시 반영) 데이터를 비우지 않고 그대로 둔다
+//
+func (slaveClient RedisClient) rehomeTo(newMaster RedisClient) error {
+
+	oldMaster := slaveMasterMap[slaveClient.Address]
+
+	if oldMaster.Address == newMaster.Address {
+		tools.InfoLogger.Printf(msg.SlaveAlreadyRehomed, slaveClient.Address, newMaster.Address)
+		return nil
+	}
+
+	// 기존 마스터와 새로운 마스터의 그룹 모두 요청 중지
+	for _, eachAddress := range []string{oldMaster.Address, newMaster.Address} {
+		groupMutex := redisMutexMap[eachAddress]
+		groupMutex.Lock()
+		defer groupMutex.Unlock()
+	}
+
+	// 기존 마스터의 데이터는 이미 다른 마스터들로 옮겨졌다
+	// Native 복제 모드에서는 REPLICAOF 의 Full Sync 가 데이터를 교체한다
+	if replicationMode == ReplayReplication {
+		if _, err := redis.String(slaveClient.Connection.Do("FLUSHALL")); err != nil {
+			return err
+		}
+	}
+
+	if err := slaveClient.removeDataLogFile(); err != nil {
+		return err
+	}
+
+	if err := createDataLogFile(slaveClient.Address); err != nil {
+		return err
+	}
+
+	delete(masterSlaveMap, oldMaster.Address)
+	initMasterSlaveMaps(newMaster, slaveClient)
+
+	if err := newMaster.copyDataTo(slaveClient); err != nil {
+		return err
+	}
+
+	tools.InfoLogger.Printf(msg.SlaveRehomed, slaveClient.Address, oldMaster.Address, newMaster.Address)
+
+	return nil
+}
+
+// findMasterWithoutSlave : @exceptAddress 를 제외하고 슬레이브가 없는 마스터
+func findMasterWithoutSlave(exceptAddress string) (RedisClient, bool) {
+
+	for _, eachMaster := range redisMasterClients {
+
+		if eachMaster.Address == exceptAddress {
+			continue
+		}
+
+		if _, hasSlave := masterSlaveMap[eachMaster.Address]; !hasSlave {
+			return eachMaster, true
+		}
+	}
+
+	return RedisClient{}, false
+}
+
+// relinkMasterSlots : 해쉬 슬롯이 마스터 목록의 원소를 가리키므로,
+// 목록에서 마스터를 지운 뒤 (자리가 바뀐 마스터들) 슬롯을 다시 연결
+//
+func relinkMasterSlots() {
+
+	for i := range redisMasterClients {
+
+		eachMaster := &redisMasterClients[i]
+		for _, eachHashRange := range clientHashRangeMap[eachMaster.Address] {
+			hashSlot.assign(eachMaster, eachHashRange.startIndex, eachHashRange.endIndex)
+		}
+	}
+}
+
+// forget : 제거된 노드의 연결, 그룹 Lock, 점검 모드 정리
+func (redisClient RedisClient) forget() {
+
+	if redisClient.Connection != nil {
+		redisClient.Connection.Close()
+	}
+
+	delete(redisMutexMap, redisClient.Address)
+
+	maintenanceMutex.Lock()
+	delete(maintenanceNodes, redisClient.Address)
+	maintenanceMutex.Unlock()
+}
@@ -0,0 +1,25 @@
+package storage
+
+import "testing"
+
+// 이미 새로운 마스터의 슬레이브이면 연결 (Connection) 을 쓰지 않고 그대로 둔다
+func TestRehomeToSameMaster(t *testing.T) {
+
+	masterClient := RedisClient{Address: testMasterA, Role: MasterRole}
+	slaveClient := RedisClient{Address: "127.0.0.1:8002", Role: SlaveRole}
+
+	initMasterSlaveMaps(masterClient, slaveClient)
+	defer func() {
+		delete(masterSlaveMap, masterClient.Address)
+		delete(slaveMasterMap, slaveClient.Address)
+		delete(redisMutexMap, slaveClient.Address)
+	}()
+
+	if err := slaveClient.rehomeTo(masterClient); err != nil {
+		t.Errorf("rehomeTo() 에러 : %s", err.Error())
+	}
+
+	if slaveMasterMap[slaveClient.Address].Address != masterClient.Address {
+		t.Errorf("rehomeTo() 후 마스터 %s, expected : %s", slaveMasterMap[slaveClient.Address].Address, masterClient.Address)
+	}
+}
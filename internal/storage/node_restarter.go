@@ -0,0 +1,192 @@
+package storage
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+	"sync"
+	"time"
+
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+// NodeRestarter : Failover 후 죽은 레디스 노드를 다시 실행하는 방법
+// RestartNode 가 성공하면 노드를 새로운 마스터의 슬레이브로 연결한다
+//
+type NodeRestarter interface {
+	RestartNode(address string) error
+}
+
+// NodeRestarterMode : NodeRestarter 구현 선택
+type NodeRestarterMode string
+
+const (
+	// DockerNodeRestarter : 노드 IP 의 Docker 컨테이너 재시작 (기본값)
+	DockerNodeRestarter NodeRestarterMode = "docker"
+
+	// ProcessNodeRestarter : 같은 호스트에서 redis-server 프로세스 실행
+	ProcessNodeRestarter NodeRestarterMode = "process"
+
+	// NoopNodeRestarter : 재시작하지 않음 (systemd 등 외부에서 관리)
+	NoopNodeRestarter NodeRestarterMode = "none"
+)
+
+var nodeRestarter NodeRestarter = &DockerRestarter{}
+
+// SetNodeRestarter : 노드 재시작 방식 설정, 빈 문자열은 기본값(docker)
+//  process 방식은 @serverPath 실행 파일과 @configPattern 설정 파일을 사용 (ProcessRestarter)
+//
+func SetNodeRestarter(mode string, serverPath string, configPattern string) error {
+
+	switch NodeRestarterMode(mode) {
+	case "", DockerNodeRestarter:
+		nodeRestarter = &DockerRestarter{}
+	case ProcessNodeRestarter:
+		nodeRestarter = NewProcessRestarter(serverPath, configPattern)
+	case NoopNodeRestarter:
+		nodeRestarter = NoopRestarter{}
+	default:
+		return fmt.Errorf(msg.UnsupportedNodeRestarter, mode)
+	}
+
+	return nil
+}
+
+// NoopRestarter : 노드를 재시작하지 않는다
+// 외부에서 이미 재시작한 노드라면 바로 슬레이브로 연결된다
+//
+type NoopRestarter struct{}
+
+// RestartNode : 아무것도 하지 않음
+func (NoopRestarter) RestartNode(address string) error {
+
+	tools.InfoLogger.Printf(msg.NodeRestartSkipped, address)
+
+	return nil
+}
+
+// ProcessRestarter : 노드를 같은 호스트의 redis-server 프로세스로 실행
+//  - ServerPath : redis-server 실행 파일 (기본값 "redis-server")
+//  - ConfigPattern : 노드 설정 파일 경로, %s 에 노드 포트 (ex. "./redis/%s.conf"), 빈 문자열이면 설정 파일 없이
+// 설정 파일에 "daemonize yes" 가 있으면 안 된다 (프로세스 종료로 판단)
+//
+type ProcessRestarter struct {
+	ServerPath    string
+	ConfigPattern string
+
+	processes map[string] /* node address */ *nodeProcess
+	mutex     sync.Mutex
+}
+
+// nodeProcess : ProcessRestarter 가 실행한 redis-server 프로세스
+type nodeProcess struct {
+	command *exec.Cmd
+	exited  chan struct{}
+}
+
+const defaultRedisServerPath = "redis-server"
+
+// NewProcessRestarter : @serverPath 가 빈 문자열이면 PATH 의 redis-server
+func NewProcessRestarter(serverPath string, configPattern string) *ProcessRestarter {
+
+	if serverPath == "" {
+		serverPath = defaultRedisServerPath
+	}
+
+	return &ProcessRestarter{
+		ServerPath:    serverPath,
+		ConfigPattern: configPattern,
+		processes:     make(map[string]*nodeProcess),
+	}
+}
+
+// RestartNode : 노드가 실행 중이 아니면 redis-server 를 실행하고, 연결을 받을 때까지 대기
+func (restarter *ProcessRestarter) RestartNode(address string) error {
+
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	restarter.mutex.Lock()
+	defer restarter.mutex.Unlock()
+
+	// 실행 중인 노드는 그대로 (Docker 의 running 컨테이너와 같이)
+	if process, isSet := restarter.processes[address]; isSet && process.isRunning() {
+		return nil
+	}
+	if isListening(address) {
+		return nil
+	}
+
+	args := []string{}
+	if restarter.ConfigPattern != "" {
+		args = append(args, fmt.Sprintf(restarter.ConfigPattern, port))
+	}
+	args = append(args, "--port", port)
+
+	command := exec.Command(restarter.ServerPath, args...)
+	if err := command.Start(); err != nil {
+		return fmt.Errorf(msg.ProcessStartFail, address, err.Error())
+	}
+
+	tools.InfoLogger.Printf(msg.ProcessRestart, address, command.Process.Pid)
+
+	process := &nodeProcess{
+		command: command,
+		exited:  make(chan struct{}),
+	}
+	restarter.processes[address] = process
+
+	// 종료된 프로세스 회수
+	go func() {
+		command.Wait()
+		close(process.exited)
+	}()
+
+	return process.waitUntilListening(address, restartTimeOutDuration)
+}
+
+func (process *nodeProcess) isRunning() bool {
+
+	select {
+	case <-process.exited:
+		return false
+	default:
+		return true
+	}
+}
+
+// waitUntilListening : 노드가 연결을 받을 때까지 대기, @timeout 이 지나거나 프로세스가 종료되면 에러
+func (process *nodeProcess) waitUntilListening(address string, timeout time.Duration) error {
+
+	deadline := time.Now().Add(timeout)
+
+	for !isListening(address) {
+
+		if !process.isRunning() {
+			return fmt.Errorf(msg.ProcessExited, address, process.command.ProcessState.String())
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf(msg.ProcessNotReady, address, timeout)
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	return nil
+}
+
+// isListening : @address 로 TCP 연결이 되는지
+func isListening(address string) bool {
+
+	connection, err := net.DialTimeout("tcp", address, 500*time.Millisecond)
+	if err != nil {
+		return false
+	}
+	connection.Close()
+
+	return true
+}
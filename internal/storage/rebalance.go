@@ -0,0 +1,345 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+
+	"hash_interface/internal/hash"
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+// SlotMove : 해쉬 슬롯 [Start, End] 의 소유권 이동
+type SlotMove struct {
+	Start         uint16 `json:"start"`
+	End           uint16 `json:"end"`
+	SourceAddress string `json:"source_address"`
+	TargetAddress string `json:"target_address"`
+}
+
+// RebalancePlan : 모든 해쉬 슬롯의 마스터 간 재분배 계획
+type RebalancePlan struct {
+	// Weights : 마스터 주소 -> 가중치
+	Weights map[string]float64 `json:"weights"`
+
+	// CurrentSlots, TargetSlots : 마스터 주소 -> 담당 해쉬 슬롯 개수 (현재 / 계획 적용 후)
+	CurrentSlots map[string]int `json:"current_slots"`
+	TargetSlots  map[string]int `json:"target_slots"`
+
+	Moves      []SlotMove `json:"moves"`
+	MovedSlots int        `json:"moved_slots"`
+
+	// Assignments : 담당 마스터가 없는 해쉬 슬롯의 할당 (SourceAddress 없음, 데이터 이동 없음)
+	Assignments   []SlotMove `json:"assignments"`
+	AssignedSlots int        `json:"assigned_slots"`
+}
+
+// PlanRebalance : 가중치(@weights)에 비례하도록 16384개 해쉬 슬롯을 재분배하는 계획 (Dry-run)
+// @weights 에 없는 마스터의 가중치는 1, 가중치 0 은 모든 슬롯을 내어준다
+//
+func PlanRebalance(weights map[string]float64) (RebalancePlan, error) {
+
+	hashSlot.redistributeMutex.Lock()
+	defer hashSlot.redistributeMutex.Unlock()
+
+	return hashSlot.planRebalance(weights)
+}
+
+// ApplyRebalance : PlanRebalance() 계획을 세운 뒤, 슬롯 단위 Live Migration 으로 적용
+//
+func ApplyRebalance(weights map[string]float64) (RebalancePlan, error) {
+
+	addClientMutex.Lock()
+	defer addClientMutex.Unlock()
+
+	return hashSlot.rebalance(weights)
+}
+
+// rebalance : 재분배 계획 수립 & 적용, 호출 전 addClientMutex Lock 필요
+func (hashSlot HashSlot) rebalance(weights map[string]float64) (RebalancePlan, error) {
+
+	hashSlot.redistributeMutex.Lock()
+	plan, err := hashSlot.planRebalance(weights)
+	hashSlot.redistributeMutex.Unlock()
+
+	if err != nil {
+		return plan, err
+	}
+
+	// 담당 마스터가 없는 슬롯은 옮길 데이터가 없으므로 소유권만 변경
+	if err := hashSlot.assignSlots(plan.Assignments); err != nil {
+		hashSlot.rebuildHashRanges()
+		return plan, err
+	}
+
+	for _, eachMove := range plan.Moves {
+
+		sourceClient, err := GetMasterWithAddress(eachMove.SourceAddress)
+		if err != nil {
+			return plan, err
+		}
+
+		targetClient, err := GetMasterWithAddress(eachMove.TargetAddress)
+		if err != nil {
+			return plan, err
+		}
+
+		// 슬롯 별로 키를 옮긴 후 소유권 변경 (이동 중에도 요청 처리 가능)
+		err = sourceClient.migrateSlotRange(targetClient, eachMove.Start, eachMove.End+1)
+		if err != nil {
+			hashSlot.rebuildHashRanges()
+			return plan, err
+		}
+
+		tools.InfoLogger.Printf(
+			msg.HashSlotAssignResult,
+			targetClient.Address,
+			eachMove.Start,
+			eachMove.End,
+		)
+	}
+
+	hashSlot.rebuildHashRanges()
+
+	return plan, nil
+}
+
+// planRebalance : 최소 이동 재분배 계획, 호출 전 redistributeMutex Lock 필요
+//  1. 가중치 비례 목표 슬롯 개수 계산 (최대 잉여 방식, 합계 16384)
+//  2. 목표보다 많은 마스터는 뒤쪽 슬롯부터 내어놓음 (마스터가 아닌 노드의 슬롯도 포함)
+//  3. 내어놓은 슬롯을 앞에서부터 목표보다 적은 마스터에게 할당 (담당 마스터가 없던 슬롯은 Assignments)
+// 목표를 초과한 만큼만 옮기므로 이동하는 슬롯 수가 최소이다
+//
+func (hashSlot HashSlot) planRebalance(weights map[string]float64) (RebalancePlan, error) {
+
+	plan := RebalancePlan{
+		Weights:      make(map[string]float64),
+		CurrentSlots: make(map[string]int),
+		TargetSlots:  make(map[string]int),
+		Moves:        []SlotMove{},
+		Assignments:  []SlotMove{},
+	}
+
+	masterAddresses := make([]string, 0, len(redisMasterClients))
+	for _, eachMaster := range redisMasterClients {
+		masterAddresses = append(masterAddresses, eachMaster.Address)
+	}
+	sort.Strings(masterAddresses)
+
+	if len(masterAddresses) == 0 {
+		return plan, fmt.Errorf(msg.NoMasterClients)
+	}
+
+	// 1. 가중치 확인
+	totalWeight := float64(0)
+	for _, eachAddress := range masterAddresses {
+		plan.Weights[eachAddress] = 1
+	}
+
+	for eachAddress, eachWeight := range weights {
+		if _, isMaster := plan.Weights[eachAddress]; isMaster == false {
+			return plan, fmt.Errorf(msg.RebalanceUnknownMaster, eachAddress)
+		}
+		if eachWeight < 0 {
+			return plan, fmt.Errorf(msg.RebalanceInvalidWeight, eachAddress, eachWeight)
+		}
+		plan.Weights[eachAddress] = eachWeight
+	}
+
+	for _, eachAddress := range masterAddresses {
+		totalWeight += plan.Weights[eachAddress]
+	}
+
+	if totalWeight == 0 {
+		return plan, fmt.Errorf(msg.RebalanceNoWeight)
+	}
+
+	// 2. 목표 슬롯 개수 (소수부가 큰 순서대로 나머지 할당)
+	type remainder struct {
+		address  string
+		fraction float64
+	}
+
+	remainders := make([]remainder, 0, len(masterAddresses))
+	assignedSlots := 0
+
+	for _, eachAddress := range masterAddresses {
+
+		quota := float64(hash.HashSlotsNumber) * plan.Weights[eachAddress] / totalWeight
+		plan.TargetSlots[eachAddress] = int(quota)
+		assignedSlots += int(quota)
+
+		remainders = append(remainders, remainder{
+			address:  eachAddress,
+			fraction: quota - float64(int(quota)),
+		})
+	}
+
+	sort.SliceStable(remainders, func(i, j int) bool {
+		return remainders[i].fraction > remainders[j].fraction
+	})
+
+	for i := 0; assignedSlots < hash.HashSlotsNumber; i++ {
+		plan.TargetSlots[remainders[i%len(remainders)].address]++
+		assignedSlots++
+	}
+
+	// 3. 현재 슬롯 소유 현황
+	owners := hashSlot.ownerAddresses()
+	for _, eachOwner := range owners {
+		if eachOwner != "" {
+			plan.CurrentSlots[eachOwner]++
+		}
+	}
+
+	// 4. 앞쪽 슬롯부터 목표 개수만큼 유지, 초과분(뒤쪽 슬롯)은 내어놓기
+	// 마스터가 아닌 노드의 목표 개수는 0
+	keptSlots := make(map[string]int)
+	isReleased := make([]bool, hash.HashSlotsNumber)
+
+	for slotIndex, eachOwner := range owners {
+		if keptSlots[eachOwner] < plan.TargetSlots[eachOwner] {
+			keptSlots[eachOwner]++
+		} else {
+			isReleased[slotIndex] = true
+		}
+	}
+
+	// 5. 내어놓은 슬롯을 앞에서부터 부족한 마스터에게 할당 (주소 순서대로)
+	remainingSlots := make(map[string]int)
+	for _, eachAddress := range masterAddresses {
+		remainingSlots[eachAddress] = plan.TargetSlots[eachAddress] - keptSlots[eachAddress]
+	}
+
+	targetIdx := 0
+	for slotIndex := 0; slotIndex < hash.HashSlotsNumber; slotIndex++ {
+
+		if isReleased[slotIndex] == false {
+			continue
+		}
+
+		for remainingSlots[masterAddresses[targetIdx]] == 0 {
+			targetIdx++
+		}
+
+		targetAddress := masterAddresses[targetIdx]
+		remainingSlots[targetAddress]--
+
+		slotMove := SlotMove{
+			Start:         uint16(slotIndex),
+			End:           uint16(slotIndex),
+			SourceAddress: owners[slotIndex],
+			TargetAddress: targetAddress,
+		}
+
+		if slotMove.SourceAddress == "" {
+			plan.AssignedSlots++
+			plan.Assignments = appendSlotMove(plan.Assignments, slotMove)
+			continue
+		}
+
+		plan.MovedSlots++
+		plan.Moves = appendSlotMove(plan.Moves, slotMove)
+	}
+
+	return plan, nil
+}
+
+// assignPlan : 데이터 이동 없이 @plan 대로 해쉬 슬롯 소유권만 변경 (소스 마스터가 죽은 경우)
+//
+func (hashSlot HashSlot) assignPlan(plan RebalancePlan) error {
+
+	err := hashSlot.assignSlots(plan.Assignments)
+	if err == nil {
+		err = hashSlot.assignSlots(plan.Moves)
+	}
+
+	hashSlot.rebuildHashRanges()
+
+	return err
+}
+
+// assignSlots : 데이터 이동 없이 @moves 의 해쉬 슬롯을 대상 마스터에게 할당
+func (hashSlot HashSlot) assignSlots(moves []SlotMove) error {
+
+	for _, eachMove := range moves {
+
+		targetClient, err := GetMasterWithAddress(eachMove.TargetAddress)
+		if err != nil {
+			return err
+		}
+
+		hashSlot.assign(targetClient, eachMove.Start, eachMove.End+1)
+
+		tools.InfoLogger.Printf(
+			msg.HashSlotAssignResult,
+			targetClient.Address,
+			eachMove.Start,
+			eachMove.End,
+		)
+	}
+
+	return nil
+}
+
+// appendSlotMove : 바로 이전 이동과 연속된 슬롯이면 하나의 범위로 합친다
+func appendSlotMove(moves []SlotMove, move SlotMove) []SlotMove {
+
+	if len(moves) > 0 {
+		lastMove := &moves[len(moves)-1]
+
+		if lastMove.End+1 == move.Start &&
+			lastMove.SourceAddress == move.SourceAddress &&
+			lastMove.TargetAddress == move.TargetAddress {
+
+			lastMove.End = move.End
+			return moves
+		}
+	}
+
+	return append(moves, move)
+}
+
+// ownerAddresses : 해쉬 슬롯 -> 담당 레디스 주소 스냅샷
+func (hashSlot HashSlot) ownerAddresses() []string {
+
+	hashSlot.slotsMutex.RLock()
+	defer hashSlot.slotsMutex.RUnlock()
+
+	owners := make([]string, hash.HashSlotsNumber)
+	for slotIndex := range owners {
+		if redisClient, isSet := hashSlot.slots[uint16(slotIndex)]; isSet && redisClient != nil {
+			owners[slotIndex] = redisClient.Address
+		}
+	}
+
+	return owners
+}
+
+// rebuildHashRanges : 현재 해쉬 슬롯 기준으로 clientHashRangeMap 을 연속된 구간들로 다시 생성
+//
+func (hashSlot HashSlot) rebuildHashRanges() {
+
+	newHashRangeMap := make(map[string][]HashRange)
+
+	for _, eachRange := range hashSlot.slotRanges() {
+		newHashRangeMap[eachRange.MasterAddress] = append(
+			newHashRangeMap[eachRange.MasterAddress],
+			HashRange{
+				startIndex: eachRange.Start,
+				endIndex:   eachRange.End + 1,
+			},
+		)
+	}
+
+	hashSlot.redistributeMutex.Lock()
+	defer hashSlot.redistributeMutex.Unlock()
+
+	for eachAddress := range clientHashRangeMap {
+		delete(clientHashRangeMap, eachAddress)
+	}
+
+	for eachAddress, eachRanges := range newHashRangeMap {
+		clientHashRangeMap[eachAddress] = eachRanges
+	}
+}
@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+
+	"hash_interface/internal/hash"
+)
+
+// slotOwnerRange : 해쉬 슬롯 [start, end] 의 담당 마스터
+type slotOwnerRange struct {
+	start   uint16
+	end     uint16
+	address string
+}
+
+// setTestSlotOwners : 마스터 @addresses 와 해쉬 슬롯 담당 구성을 바꾸고, 원래대로 돌리는 함수 반환
+func setTestSlotOwners(addresses []string, ranges []slotOwnerRange) func() {
+
+	originMasters := redisMasterClients
+	originSlots := hashSlot.slots
+
+	redisMasterClients = make([]RedisClient, 0, len(addresses))
+	for _, eachAddress := range addresses {
+		redisMasterClients = append(redisMasterClients, RedisClient{Address: eachAddress})
+	}
+
+	hashSlot.slots = make(map[uint16]*RedisClient)
+	for _, eachRange := range ranges {
+		for i := range redisMasterClients {
+			if redisMasterClients[i].Address != eachRange.address {
+				continue
+			}
+			for slotIndex := int(eachRange.start); slotIndex <= int(eachRange.end); slotIndex++ {
+				hashSlot.slots[uint16(slotIndex)] = &redisMasterClients[i]
+			}
+		}
+	}
+
+	return func() {
+		redisMasterClients = originMasters
+		hashSlot.slots = originSlots
+	}
+}
+
+func TestPlanRebalance(t *testing.T) {
+
+	lastSlot := uint16(hash.HashSlotsNumber - 1)
+
+	fixtures := []struct {
+		name                string
+		ranges              []slotOwnerRange
+		weights             map[string]float64
+		expectedErr         bool
+		expectedTarget      map[string]int
+		expectedMoves       []SlotMove
+		expectedAssignments []SlotMove
+	}{
+		{
+			name:           "담당 마스터가 없는 슬롯은 할당만",
+			expectedTarget: map[string]int{testMasterA: 8192, testMasterB: 8192},
+			expectedMoves:  []SlotMove{},
+			expectedAssignments: []SlotMove{
+				{Start: 0, End: 8191, TargetAddress: testMasterA},
+				{Start: 8192, End: lastSlot, TargetAddress: testMasterB},
+			},
+		},
+		{
+			name:           "새로운 마스터에게 절반 이동",
+			ranges:         []slotOwnerRange{{0, lastSlot, testMasterA}},
+			expectedTarget: map[string]int{testMasterA: 8192, testMasterB: 8192},
+			expectedMoves: []SlotMove{
+				{Start: 8192, End: lastSlot, SourceAddress: testMasterA, TargetAddress: testMasterB},
+			},
+			expectedAssignments: []SlotMove{},
+		},
+		{
+			name: "일부 슬롯만 담당 마스터가 없는 경우",
+			ranges: []slotOwnerRange{
+				{0, 4095, testMasterA},
+				{4096, 8191, testMasterB},
+			},
+			expectedTarget: map[string]int{testMasterA: 8192, testMasterB: 8192},
+			expectedMoves:  []SlotMove{},
+			expectedAssignments: []SlotMove{
+				{Start: 8192, End: 12287, TargetAddress: testMasterA},
+				{Start: 12288, End: lastSlot, TargetAddress: testMasterB},
+			},
+		},
+		{
+			name:           "가중치 0 인 마스터의 슬롯 이동 & 담당 마스터가 없는 슬롯 할당",
+			ranges:         []slotOwnerRange{{0, 8191, testMasterA}},
+			weights:        map[string]float64{testMasterA: 0},
+			expectedTarget: map[string]int{testMasterA: 0, testMasterB: hash.HashSlotsNumber},
+			expectedMoves: []SlotMove{
+				{Start: 0, End: 8191, SourceAddress: testMasterA, TargetAddress: testMasterB},
+			},
+			expectedAssignments: []SlotMove{
+				{Start: 8192, End: lastSlot, TargetAddress: testMasterB},
+			},
+		},
+		{
+			name:        "모든 가중치 0",
+			weights:     map[string]float64{testMasterA: 0, testMasterB: 0},
+			expectedErr: true,
+		},
+		{
+			name:        "음수 가중치",
+			weights:     map[string]float64{testMasterA: -1},
+			expectedErr: true,
+		},
+		{
+			name:        "등록되지 않은 마스터",
+			weights:     map[string]float64{"127.0.0.1:9000": 1},
+			expectedErr: true,
+		},
+	}
+
+	for _, fixture := range fixtures {
+
+		restore := setTestSlotOwners([]string{testMasterA, testMasterB}, fixture.ranges)
+		plan, err := hashSlot.planRebalance(fixture.weights)
+		restore()
+
+		if (err != nil) != fixture.expectedErr {
+			t.Errorf("%s : planRebalance() 에러 %v, expected : %v", fixture.name, err, fixture.expectedErr)
+			continue
+		}
+		if fixture.expectedErr {
+			continue
+		}
+
+		if !reflect.DeepEqual(plan.TargetSlots, fixture.expectedTarget) {
+			t.Errorf("%s : 목표 슬롯 %v, expected : %v", fixture.name, plan.TargetSlots, fixture.expectedTarget)
+		}
+
+		if !reflect.DeepEqual(plan.Moves, fixture.expectedMoves) {
+			t.Errorf("%s : 이동 %v, expected : %v", fixture.name, plan.Moves, fixture.expectedMoves)
+		}
+
+		if !reflect.DeepEqual(plan.Assignments, fixture.expectedAssignments) {
+			t.Errorf("%s : 할당 %v, expected : %v", fixture.name, plan.Assignments, fixture.expectedAssignments)
+		}
+
+		if plan.MovedSlots != countMoveSlots(fixture.expectedMoves) {
+			t.Errorf("%s : 이동 슬롯 %d, expected : %d", fixture.name, plan.MovedSlots, countMoveSlots(fixture.expectedMoves))
+		}
+
+		if plan.AssignedSlots != countMoveSlots(fixture.expectedAssignments) {
+			t.Errorf("%s : 할당 슬롯 %d, expected : %d", fixture.name, plan.AssignedSlots, countMoveSlots(fixture.expectedAssignments))
+		}
+	}
+}
+
+func countMoveSlots(moves []SlotMove) int {
+
+	slots := 0
+	for _, eachMove := range moves {
+		slots += int(eachMove.End) - int(eachMove.Start) + 1
+	}
+
+	return slots
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	msg "hash_interface/internal/storage/message"
 	"hash_interface/tools"
-	"strings"
 	"sync"
 
 	"github.com/gomodule/redigo/redis"
@@ -51,6 +50,7 @@ var addClientMutex *sync.Mutex
 //   1) 매핑된 Slave를 새로운 마스터로 승격
 //   2) 죽은 masterClient는 재시작
 //  3. 새로운 마스터 승격이 실패할 경우 (Slave 죽은 것으로 판단) 남은 Master Client들에게 해쉬슬롯 재분배
+// 점검 중인 마스터는 확인하지 않는다
 //
 func (masterClient *RedisClient) handleIfDead() error {
 
@@ -58,28 +58,23 @@ func (masterClient *RedisClient) handleIfDead() error {
 		return fmt.Errorf(msg.NotAllowedIfNotMaster)
 	}
 
-	// 모니터 서버에게 생존 확인/투표 요청
-	numberOfTotalVotes := len(monitorClient.ServerAddressList) + 1
-	votes, err := monitorClient.ask(*masterClient, IsAlive)
-	if err != nil {
-		return err
+	if IsInMaintenance(masterClient.Address) {
+		return nil
 	}
 
-	// 호스트 인터페이스 서버의 생존 확인/투표
-	hostPingResult, hostPingErr := redis.String(masterClient.Connection.Do("PING"))
-	if strings.Contains(hostPingResult, "PONG") {
-		votes++
-	}
+	// 모니터 서버들이 최근 알려온 상태와 호스트의 PING 으로 생존 투표
+	// Raft 로그로 합의한 모니터 서버 목록 기준
+	masterVotes, hostPingErr := masterClient.voteAlive()
 
 	// tools.InfoLogger.Printf(
 	// 	msg.FailOverVoteResult,
 	// 	masterClient.Address,
-	// 	votes,
-	// 	numberOfTotalVotes,
+	// 	masterVotes.Alive,
+	// 	masterVotes.Total,
 	// )
 
-	// 과반수가 살아있다고 판단
-	if votes > (numberOfTotalVotes / 2) {
+	// 죽었다는 투표가 정족수에 못 미치면 살아있다고 판단
+	if !masterVotes.isDown() {
 
 		// 호스트 연결 에러시, 재연결 시도
 		if hostPingErr != nil {
@@ -99,7 +94,8 @@ func (masterClient *RedisClient) handleIfDead() error {
 		return nil
 	}
 
-	// 과반수 이상 죽었다고 판단한 경우
+	// 정족수 이상 죽었다고 판단한 경우
+	// 승격 / 재분배는 Raft 로그로 요청하여 모든 인터페이스 서버에 같은 순서로 반영, 반영될 때까지는 다시 시도
 
 	//tools.InfoLogger.Printf(msg.PromotinSlaveStart, masterClient.Address)
 
@@ -108,42 +104,18 @@ func (masterClient *RedisClient) handleIfDead() error {
 		return fmt.Errorf(msg.MasterSlaveMapNotInit)
 	}
 
-	// 슬레이브를 새로운 마스터로 승격
-	if err := slaveClient.promoteToMaster(); err != nil {
-
-		if err.Error() == msg.BothMasterSlaveDead {
-
-			// 마스터 - 슬레이브 모두 죽은 경우
-			// 해쉬 슬롯 재분배 후, 마스터-슬레이브의 모든 데이터 및 설정 삭제
-			if err := hashSlot.distributeFrom(masterClient); err != nil {
-				return err
-			}
-			return nil
-		}
-		return err
+	// 점검 중인 슬레이브는 승격하지 않는다 (마스터의 해쉬 슬롯도 재분배하지 않음)
+	if IsInMaintenance(slaveClient.Address) {
+		return fmt.Errorf(msg.NodeInMaintenance, slaveClient.Address)
 	}
 
-	//tools.InfoLogger.Printf(msg.PromotionSuccess, masterClient.Address)
-
-	// 새로운 마스터로 승격 성공
-	// 죽은 기존 마스터는 재시작 (using docker API)
-	err = docker.restartRedisContainer(masterClient.Address)
+	deadMaster := *masterClient
 
-	// 컨테이너 재시작이 성공한 경우에만 새로운 마스터의 슬레이브로 연결 시도
-	if err == nil {
-
-		masterClient.connectToMaster(&slaveClient)
-
-	} else {
-		tools.ErrorLogger.Println(err)
-	}
-
-	// tools.InfoLogger.Printf(
-	// 	msg.SlaveAsNewMaster,
-	// 	slaveMasterMap[masterClient.Address].Address,
-	// )
+	requestFailover(deadMaster.Address, func() {
+		deadMaster.failover(slaveClient, masterVotes)
+	})
 
-	return nil
+	return fmt.Errorf(msg.FailoverTryAgain, masterClient.Address)
 }
 
 // getSlave : 자신의 슬레이브가 살아있을 경우 반환, 죽어있을 경우 에러 반환
@@ -156,28 +128,18 @@ func (masterClient RedisClient) getSlave() (RedisClient, error) {
 		return RedisClient{}, fmt.Errorf("")
 	}
 
-	// 모니터 서버에 슬레이브 생존 여부 확인
-	numberOfTotalVotes := len(monitorClient.ServerAddressList) + 1
-	votes, err := monitorClient.ask(slaveClient, IsAlive)
-	if err != nil {
-		return RedisClient{}, fmt.Errorf("")
-	}
-
-	// 호스트 인터페이스 서버의 생존 확인/투표
-	hostPingResult, _ := redis.String(slaveClient.Connection.Do("PING"))
-	if strings.Contains(hostPingResult, "PONG") {
-		votes++
-	}
+	// 모니터 서버들이 알려온 슬레이브 생존 여부
+	slaveVotes, _ := slaveClient.voteAlive()
 
 	// tools.InfoLogger.Printf(
 	// 	msg.FailOverVoteResult,
 	// 	slaveClient.Address,
-	// 	votes,
-	// 	numberOfTotalVotes,
+	// 	slaveVotes.Alive,
+	// 	slaveVotes.Total,
 	// )
 
-	// 과반수 이상이 죽었다고 판단
-	if votes < (numberOfTotalVotes / 2) {
+	// 정족수 이상이 죽었다고 판단
+	if slaveVotes.isDown() {
 		err := fmt.Errorf(msg.VoteResultSlaveDead, slaveClient.Address)
 		return RedisClient{}, err
 	}
@@ -198,44 +160,36 @@ func (masterClient RedisClient) handleIfDeadWithLock(errorChannel chan error) {
 		return
 	}
 
-	if err := masterClient.handleIfDead(); err != nil {
+	// Failover 요청 중인 경우는 모니터링 계속
+	if err := masterClient.handleIfDead(); err != nil && !IsTryAgainError(err) {
 		errorChannel <- err
 		return
 	}
 }
 
 // checkSlaveAlive : masterClient 인스턴스의 slave의 생존 여부 확인 & 죽었을 시 재시작
+// 점검 중인 슬레이브는 재시작하지 않는다
 //
 func (masterClient *RedisClient) checkSlaveAlive() {
 
 	slaveClient, isSet := masterSlaveMap[masterClient.Address]
-	if isSet == false {
+	if isSet == false || IsInMaintenance(slaveClient.Address) {
 		return
 	}
 
 	// tools.InfoLogger.Printf(msg.StartSlaveAliveCheck, masterClient.Address)
 
-	numberOfTotalVotes := len(monitorClient.ServerAddressList) + 1
-	votes, err := monitorClient.ask(slaveClient, IsAlive)
-	if err != nil {
-		return
-	}
-
-	// 호스트 인터페이스 서버의 생존 확인/투표
-	hostPingResult, _ := redis.String(slaveClient.Connection.Do("PING"))
-	if strings.Contains(hostPingResult, "PONG") {
-		votes++
-	}
+	slaveVotes, _ := slaveClient.voteAlive()
 
 	// tools.InfoLogger.Printf(
 	// 	msg.FailOverVoteResult,
 	// 	slaveClient.Address,
-	// 	votes,
-	// 	numberOfTotalVotes,
+	// 	slaveVotes.Alive,
+	// 	slaveVotes.Total,
 	// )
 
-	// 과반수가 살아있다고 판단
-	if votes > (numberOfTotalVotes / 2) {
+	// 죽었다는 투표가 정족수에 못 미치면 살아있다고 판단
+	if !slaveVotes.isDown() {
 		//tools.InfoLogger.Printf(msg.SlaveIsAlive, slaveClient.Address)
 		return
 	}
@@ -246,9 +200,13 @@ func (masterClient *RedisClient) checkSlaveAlive() {
 	slaveClient.removeDataLogFile()
 	slaveClient.RemoveFromList()
 
-	err = docker.restartRedisContainer(slaveClient.Address)
+	restartEvent := newFailoverEvent(NodeRestartEvent, slaveClient.Address, "", "")
+	restartEvent.Votes = &slaveVotes
+
+	err := nodeRestarter.RestartNode(slaveClient.Address)
+	recordFailoverEvent(restartEvent, err)
 
-	// 컨테이너 재시작이 성공한 경우에만 새로운 마스터의 슬레이브로 연결 시도
+	// 노드 재시작이 성공한 경우에만 같은 마스터의 슬레이브로 다시 연결 (마스터 - 슬레이브 구성은 그대로)
 	if err == nil {
 		slaveClient.connectToMaster(masterClient)
 	}
@@ -273,11 +231,11 @@ func (masterClient RedisClient) cleanUpMemory() error {
 		return err
 	}
 
-	if _, err := monitorClient.ask(masterClient, EndConnect); err != nil {
+	if _, err := currentMonitorClient().ask(masterClient, EndConnect); err != nil {
 		return err
 	}
 
-	if _, err := monitorClient.ask(slaveClient, EndConnect); err != nil {
+	if _, err := currentMonitorClient().ask(slaveClient, EndConnect); err != nil {
 		return err
 	}
 
@@ -296,36 +254,21 @@ func (masterClient RedisClient) cleanUpMemory() error {
  *
  ****************************************/
 
-// promoteToMaster : slaveClient 인스턴스 생존 여부 확인, 새로운 마스터로 승격
+// promoteToMaster : slaveClient 인스턴스를 새로운 마스터로 승격 (생존 여부는 Failover 를 요청한 서버가 확인)
 //  1. 기존 마스터가 담당하던 해쉬 슬롯 할당
 //  2. 마스터, 슬레이브 관련 변수 초기화
 //
-func (slaveClient *RedisClient) promoteToMaster() error {
-
-	// 슬레이브가 살아있는지 확인
-	numberOfTotalVotes := len(monitorClient.ServerAddressList) + 1
-	votes, err := monitorClient.ask(*slaveClient, IsAlive)
-	if err != nil {
-		return err
-	}
+func (slaveClient *RedisClient) promoteToMaster() (err error) {
 
-	// 호스트 인터페이스 서버의 생존 확인/투표
-	hostPingResult, _ := redis.String(slaveClient.Connection.Do("PING"))
-	if strings.Contains(hostPingResult, "PONG") {
-		votes++
+	// 점검 중인 슬레이브는 승격하지 않는다 (마스터의 해쉬 슬롯도 재분배하지 않음)
+	if IsInMaintenance(slaveClient.Address) {
+		return fmt.Errorf(msg.NodeInMaintenance, slaveClient.Address)
 	}
 
-	// tools.InfoLogger.Printf(
-	// 	msg.FailOverVoteResult,
-	// 	slaveClient.Address,
-	// 	votes,
-	// 	numberOfTotalVotes,
-	// )
-
-	// 과반수 이상이 죽었다고 판단한 경우
-	if votes <= (numberOfTotalVotes / 2) {
-		return fmt.Errorf(msg.BothMasterSlaveDead)
-	}
+	event := newFailoverEvent(PromotionEvent, slaveClient.Address, SlaveRole, MasterRole)
+	defer func() {
+		recordFailoverEvent(event, err)
+	}()
 
 	//tools.InfoLogger.Printf(msg.PromotingSlaveNode, slaveClient.Address)
 
@@ -341,6 +284,7 @@ func (slaveClient *RedisClient) promoteToMaster() error {
 	}
 
 	// 2. 기존 마스터의 해쉬 슬롯을 이어 받음
+	event.SlotsMoved = countSlots(clientHashRangeMap[masterClient.Address])
 	for _, eachHashRangeOfClient := range clientHashRangeMap[masterClient.Address] {
 		hashSlotStart := eachHashRangeOfClient.startIndex
 		hashSlotEnd := eachHashRangeOfClient.endIndex
@@ -380,6 +324,13 @@ func (slaveClient *RedisClient) setUpMasterConfig() error {
 		return err
 	}
 
+	// Native 복제 모드 : 레플리카 해제 후 쓰기 가능한 마스터로 전환
+	if replicationMode == NativeReplication {
+		if err := slaveClient.stopReplication(); err != nil {
+			return err
+		}
+	}
+
 	if err := slaveClient.RemoveFromList(); err != nil {
 		return err
 	}
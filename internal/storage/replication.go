@@ -1,11 +1,79 @@
 package storage
 
 import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/gomodule/redigo/redis"
+
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+// ReplicationMode : 마스터 -> 슬레이브 데이터 복제 방식
+type ReplicationMode string
+
+const (
+	// ReplayReplication : 마스터에 실행한 명령을 인터페이스 서버가 슬레이브에 재실행 (기본값)
+	ReplayReplication ReplicationMode = "replay"
+
+	// NativeReplication : 슬레이브를 REPLICAOF 로 설정, Redis 자체 복제 스트림 이용
+	NativeReplication ReplicationMode = "native"
 )
 
+var replicationMode = ReplayReplication
+
+// ReplicationInfo : 마스터-슬레이브 그룹의 INFO replication 기반 복제 현황
+type ReplicationInfo struct {
+	MasterAddress string    `json:"master_address"`
+	SlaveAddress  string    `json:"slave_address"`
+	MasterOffset  int64     `json:"master_offset"`
+	SlaveOffset   int64     `json:"slave_offset"`
+	Lag           int64     `json:"lag"`
+	IsLinkUp      bool      `json:"is_link_up"`
+	CheckedAt     time.Time `json:"checked_at"`
+}
+
+// replicationInfoMap : 마스터 주소 -> 가장 최근에 확인한 복제 현황
+var replicationInfoMap map[string]ReplicationInfo
+var replicationInfoMutex = &sync.RWMutex{}
+
+func init() {
+	if replicationInfoMap == nil {
+		replicationInfoMap = make(map[string]ReplicationInfo)
+	}
+}
+
+// SetReplicationMode : 복제 방식 설정, 빈 문자열은 기본값(replay)
+// 슬레이브 연결 설정(NodeConnectionSetup) 이전에 호출되어야 한다
+//
+func SetReplicationMode(mode string) error {
+
+	switch ReplicationMode(mode) {
+	case "", ReplayReplication:
+		replicationMode = ReplayReplication
+	case NativeReplication:
+		replicationMode = NativeReplication
+	default:
+		return fmt.Errorf(msg.UnsupportedReplicationMode, mode)
+	}
+
+	return nil
+}
+
+// IsNativeReplication : REPLICAOF 기반 복제를 사용 중인지
+func IsNativeReplication() bool {
+	return replicationMode == NativeReplication
+}
+
 // ReplicateToSlave : masterClient 인스턴스의 슬레이브에게 명령 전파
 // 슬레이브가 죽어있으면 처리하지 않는다 (살아날 때 마스터의 데이터를 복사)
+// Native 복제 모드에서는 Redis가 직접 전파하므로, 슬레이브의 데이터 로그만 기록한다
 //
 func (masterClient RedisClient) ReplicateToSlave(command string, key string, value string) {
 
@@ -18,9 +86,413 @@ func (masterClient RedisClient) ReplicateToSlave(command string, key string, val
 	}
 
 	// 슬레이브가 살아있는 경우
-	redis.String(slaveClient.Connection.Do(command, key, value))
+	if replicationMode == ReplayReplication {
+		slaveClient.Connection.Do(command, commandArgs(command, key, value)...)
+	}
 
 	slaveClient.RecordModificationLog(command, key, value)
 
 	//tools.InfoLogger.Println(msg.EndReplication)
 }
+
+// commandArgs : 슬레이브에 재실행할 명령의 인자, DEL / PERSIST 는 Value 없이 Key 만 전달
+// 자료형 명령 / RESTORE 는 Value 에 묶인 인자들을 풀어서 전달 (typedCommandArgs)
+//
+func commandArgs(command string, key string, value string) []interface{} {
+
+	if command == "DEL" || command == PersistCommand {
+		return []interface{}{key}
+	}
+
+	if IsTypedCommand(command) || command == RestoreCommand {
+		if args, err := typedCommandArgs(command, key, value); err == nil {
+			return args
+		}
+	}
+
+	return []interface{}{key, value}
+}
+
+// replicaOf : slaveClient 인스턴스를 masterClient의 레플리카로 설정 (REPLICAOF host port)
+// 기존 데이터는 Redis의 Full Sync로 대체된다
+//
+func (slaveClient RedisClient) replicaOf(masterClient RedisClient) error {
+
+	host, port, err := net.SplitHostPort(masterClient.Address)
+	if err != nil {
+		return err
+	}
+
+	if _, err := redis.String(slaveClient.Connection.Do("REPLICAOF", host, port)); err != nil {
+		return fmt.Errorf(
+			msg.ReplicaOfFail,
+			slaveClient.Address,
+			masterClient.Address,
+			err.Error(),
+		)
+	}
+
+	return nil
+}
+
+// stopReplication : 인스턴스의 복제 중단, 단독 마스터로 전환 (REPLICAOF NO ONE)
+//
+func (redisClient RedisClient) stopReplication() error {
+
+	if _, err := redis.String(redisClient.Connection.Do("REPLICAOF", "NO", "ONE")); err != nil {
+		return fmt.Errorf(
+			msg.StopReplicationFail,
+			redisClient.Address,
+			err.Error(),
+		)
+	}
+
+	return nil
+}
+
+// getReplicationInfo : 마스터와 슬레이브의 INFO replication 을 비교해 복제 지연(byte offset) 계산
+//
+func (masterClient RedisClient) getReplicationInfo() (ReplicationInfo, error) {
+
+	slaveClient, isSet := masterSlaveMap[masterClient.Address]
+	if isSet == false {
+		return ReplicationInfo{}, fmt.Errorf(msg.MasterSlaveMapNotInit)
+	}
+
+	masterInfo, err := readReplicationSection(masterClient)
+	if err != nil {
+		return ReplicationInfo{}, err
+	}
+
+	slaveInfo, err := readReplicationSection(slaveClient)
+	if err != nil {
+		return ReplicationInfo{}, err
+	}
+
+	masterOffset, _ := strconv.ParseInt(masterInfo["master_repl_offset"], 10, 64)
+	slaveOffset, _ := strconv.ParseInt(slaveInfo["slave_repl_offset"], 10, 64)
+
+	return ReplicationInfo{
+		MasterAddress: masterClient.Address,
+		SlaveAddress:  slaveClient.Address,
+		MasterOffset:  masterOffset,
+		SlaveOffset:   slaveOffset,
+		Lag:           masterOffset - slaveOffset,
+		IsLinkUp:      slaveInfo["master_link_status"] == "up",
+		CheckedAt:     time.Now(),
+	}, nil
+}
+
+// readReplicationSection : INFO replication 응답을 (field -> value) 맵으로 파싱
+func readReplicationSection(redisClient RedisClient) (map[string]string, error) {
+
+	info, err := redis.String(redisClient.Connection.Do("INFO", "replication"))
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]string)
+
+	for _, eachLine := range strings.Split(info, "\r\n") {
+		if eachLine == "" || strings.HasPrefix(eachLine, "#") {
+			continue
+		}
+
+		separatorIdx := strings.Index(eachLine, ":")
+		if separatorIdx < 0 {
+			continue
+		}
+
+		fields[eachLine[:separatorIdx]] = eachLine[separatorIdx+1:]
+	}
+
+	return fields, nil
+}
+
+// StartReplicationWatcher : Native 복제 모드에서 @interval 마다 각 그룹의 복제 지연 갱신
+//
+func StartReplicationWatcher(interval time.Duration) {
+
+	if replicationMode != NativeReplication {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		for range ticker.C {
+			refreshReplicationStatus()
+		}
+	}()
+}
+
+// refreshReplicationStatus : 모든 그룹의 복제 현황 갱신, 제거된 마스터의 복제 현황은 삭제
+// 노드 추가 / 제거 중 마스터 목록과 그룹 Lock 이 바뀌지 않도록 addClientMutex Lock
+//
+func refreshReplicationStatus() {
+
+	addClientMutex.Lock()
+	defer addClientMutex.Unlock()
+
+	masterAddresses := make(map[string]bool)
+
+	for _, eachMaster := range redisMasterClients {
+		masterAddresses[eachMaster.Address] = true
+		eachMaster.refreshReplicationInfo()
+	}
+
+	replicationInfoMutex.Lock()
+	defer replicationInfoMutex.Unlock()
+
+	for eachAddress := range replicationInfoMap {
+		if masterAddresses[eachAddress] == false {
+			delete(replicationInfoMap, eachAddress)
+		}
+	}
+}
+
+// refreshReplicationInfo : 마스터-슬레이브 그룹 Lock 후 복제 현황 갱신, 호출 전 addClientMutex Lock 필요
+// 그룹의 슬레이브(masterSlaveMap)는 자동 Failover 시 그룹 Lock 을 잡고 바뀌므로, 그룹 Lock 안에서 조회한다
+//
+func (masterClient RedisClient) refreshReplicationInfo() {
+
+	groupMutex, isSet := redisMutexMap[masterClient.Address]
+	if isSet == false {
+		return
+	}
+
+	groupMutex.Lock()
+	replicationInfo, err := masterClient.getReplicationInfo()
+	groupMutex.Unlock()
+
+	if err != nil {
+		tools.ErrorLogger.Printf(
+			msg.ReplicationInfoFail,
+			masterClient.Address,
+			err.Error(),
+		)
+		return
+	}
+
+	replicationInfoMutex.Lock()
+	replicationInfoMap[masterClient.Address] = replicationInfo
+	replicationInfoMutex.Unlock()
+}
+
+// GetReplicationStatus : 가장 최근에 확인한 모든 그룹의 복제 현황 (마스터 주소 순)
+// Replay 복제 모드에서는 INFO replication 을 확인하지 않으므로 비어있다
+//
+func GetReplicationStatus() []ReplicationInfo {
+
+	replicationInfoMutex.RLock()
+	defer replicationInfoMutex.RUnlock()
+
+	statusList := make([]ReplicationInfo, 0, len(replicationInfoMap))
+	for _, eachInfo := range replicationInfoMap {
+		statusList = append(statusList, eachInfo)
+	}
+
+	sort.Slice(statusList, func(i, j int) bool {
+		return statusList[i].MasterAddress < statusList[j].MasterAddress
+	})
+
+	return statusList
+}
+
+// GetReplicationMode : 현재 복제 방식 (replay / native)
+func GetReplicationMode() ReplicationMode {
+	return replicationMode
+}
+
+// Durability : SET 요청의 복제 확인 수준
+type Durability string
+
+const (
+	// DurabilityNone : 복제 확인 없이 응답 (기본값)
+	DurabilityNone Durability = "none"
+
+	// DurabilityReplicaAck : 최소 1개의 레플리카 확인 후 응답
+	DurabilityReplicaAck Durability = "replica-ack"
+
+	// DurabilityAllReplicas : 그룹의 모든 레플리카 확인 후 응답
+	DurabilityAllReplicas Durability = "all-replicas"
+)
+
+// ReplicaAckTimeout : 레플리카 확인 대기 시간 (WAIT timeout)
+var ReplicaAckTimeout = 1 * time.Second
+
+// ParseDurability : 요청의 durability 문자열 검증, 빈 문자열은 기본값(none)
+func ParseDurability(level string) (Durability, error) {
+
+	switch Durability(level) {
+	case "", DurabilityNone:
+		return DurabilityNone, nil
+	case DurabilityReplicaAck, DurabilityAllReplicas:
+		return Durability(level), nil
+	}
+
+	return DurabilityNone, fmt.Errorf(msg.UnsupportedDurability, level)
+}
+
+// IsReplicaQuorumError : 레플리카 확인 수 부족으로 인한 에러인지
+// 에러는 클러스터 노드 간 HTTP 응답 메세지로 전달되므로 메세지로 판단한다
+//
+func IsReplicaQuorumError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), msg.ReplicaQuorumNotReachedMarker)
+}
+
+// ReplicateToSlaveWithAck : ReplicateToSlave 의 동기 버전
+// @durability 수준 만큼의 레플리카가 명령을 반영했는지 확인, 부족할 경우 에러 반환
+//  - Native 복제 : 마스터에 WAIT numreplicas timeout
+//  - Replay 복제 : 슬레이브의 명령 실행 결과 확인
+// 마스터에 이미 반영된 명령은 되돌리지 않는다 (Redis WAIT 과 동일)
+//
+func (masterClient RedisClient) ReplicateToSlaveWithAck(
+	command, key, value string,
+	durability Durability,
+) error {
+
+	if durability == DurabilityNone || durability == "" {
+		masterClient.ReplicateToSlave(command, key, value)
+		return nil
+	}
+
+	requiredAcks, err := masterClient.requiredReplicaAcks(durability)
+	if err != nil {
+		return err
+	}
+	acks := 0
+
+	// 슬레이브가 죽은 경우, 확인 수 0
+	slaveClient, err := masterClient.getSlave()
+	if err == nil {
+
+		switch replicationMode {
+		case NativeReplication:
+			acks, err = redis.Int(masterClient.Connection.Do(
+				"WAIT",
+				requiredAcks,
+				int64(ReplicaAckTimeout/time.Millisecond),
+			))
+
+		case ReplayReplication:
+			if _, err = slaveClient.Connection.Do(command, commandArgs(command, key, value)...); err == nil {
+				acks = 1
+			}
+		}
+
+		if err != nil {
+			tools.ErrorLogger.Printf(
+				msg.ReplicaAckFail,
+				slaveClient.Address,
+				err.Error(),
+			)
+		} else {
+			slaveClient.RecordModificationLog(command, key, value)
+		}
+	}
+
+	if acks < requiredAcks {
+		return fmt.Errorf(
+			msg.ReplicaQuorumNotReached,
+			masterClient.Address,
+			acks,
+			requiredAcks,
+			durability,
+		)
+	}
+
+	return nil
+}
+
+// ReplicateTransactionToSlave : 트랜잭션(MULTI/EXEC)으로 실행된 @commands 를 슬레이브에 전파
+//  - Native 복제 : Redis 가 트랜잭션 단위로 전파, @durability 설정 시 WAIT
+//  - Replay 복제 : 슬레이브에도 하나의 트랜잭션으로 재실행
+//
+func (masterClient RedisClient) ReplicateTransactionToSlave(
+	commands []KeyValuePair,
+	durability Durability,
+) error {
+
+	requiredAcks := 0
+	if durability != DurabilityNone && durability != "" {
+		var err error
+		if requiredAcks, err = masterClient.requiredReplicaAcks(durability); err != nil {
+			return err
+		}
+	}
+	acks := 0
+
+	// 슬레이브가 죽은 경우, 확인 수 0
+	slaveClient, err := masterClient.getSlave()
+	if err == nil {
+
+		switch replicationMode {
+		case NativeReplication:
+			if requiredAcks > 0 {
+				acks, err = redis.Int(masterClient.Connection.Do(
+					"WAIT",
+					requiredAcks,
+					int64(ReplicaAckTimeout/time.Millisecond),
+				))
+			}
+
+		case ReplayReplication:
+			if err = runTransaction(slaveClient.Connection, commands); err == nil {
+				acks = 1
+			}
+		}
+
+		if err != nil {
+			tools.ErrorLogger.Printf(
+				msg.ReplicaAckFail,
+				slaveClient.Address,
+				err.Error(),
+			)
+		}
+
+		for _, eachCommand := range commands {
+			slaveClient.RecordModificationLog(
+				EntryCommand(eachCommand),
+				eachCommand.Key,
+				eachCommand.Value,
+			)
+		}
+	}
+
+	if acks < requiredAcks {
+		return fmt.Errorf(
+			msg.ReplicaQuorumNotReached,
+			masterClient.Address,
+			acks,
+			requiredAcks,
+			durability,
+		)
+	}
+
+	return nil
+}
+
+// requiredReplicaAcks : @durability 수준에 필요한 레플리카 확인 수
+// 모든 레플리카 확인(all-replicas)인데 레플리카가 없으면 에러 (확인 없이 성공하지 않도록)
+// To-Do : 1-1 매핑이 아닌, 슬레이브가 여러 대일 경우 처리
+//
+func (masterClient RedisClient) requiredReplicaAcks(durability Durability) (int, error) {
+
+	numberOfReplicas := 0
+	if _, isSet := masterSlaveMap[masterClient.Address]; isSet {
+		numberOfReplicas = 1
+	}
+
+	switch durability {
+	case DurabilityReplicaAck:
+		return 1, nil
+	case DurabilityAllReplicas:
+		if numberOfReplicas == 0 {
+			return 0, fmt.Errorf(msg.NoReplicaAvailable, masterClient.Address, durability)
+		}
+		return numberOfReplicas, nil
+	}
+
+	return 0, nil
+}
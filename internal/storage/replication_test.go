@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"fmt"
+	"testing"
+
+	msg "hash_interface/internal/storage/message"
+)
+
+func TestRequiredReplicaAcks(t *testing.T) {
+
+	masterClient := RedisClient{Address: testMasterA, Role: MasterRole}
+	slaveClient := RedisClient{Address: testMasterB, Role: SlaveRole}
+
+	fixtures := []struct {
+		name        string
+		hasSlave    bool
+		durability  Durability
+		expected    int
+		expectedErr string
+	}{
+		{
+			name:       "확인 없음",
+			durability: DurabilityNone,
+			expected:   0,
+		},
+		{
+			name:       "레플리카 하나 확인",
+			hasSlave:   true,
+			durability: DurabilityReplicaAck,
+			expected:   1,
+		},
+		{
+			name:       "모든 레플리카 확인",
+			hasSlave:   true,
+			durability: DurabilityAllReplicas,
+			expected:   1,
+		},
+		{
+			name:        "레플리카 없이 모든 레플리카 확인",
+			durability:  DurabilityAllReplicas,
+			expectedErr: fmt.Sprintf(msg.NoReplicaAvailable, testMasterA, DurabilityAllReplicas),
+		},
+	}
+
+	for _, fixture := range fixtures {
+
+		if fixture.hasSlave {
+			masterSlaveMap[masterClient.Address] = slaveClient
+		} else {
+			delete(masterSlaveMap, masterClient.Address)
+		}
+
+		requiredAcks, err := masterClient.requiredReplicaAcks(fixture.durability)
+
+		if fixture.expectedErr != "" {
+			if err == nil || err.Error() != fixture.expectedErr || !IsReplicaQuorumError(err) {
+				t.Errorf("%s : requiredReplicaAcks() 에러 %v, expected : %s", fixture.name, err, fixture.expectedErr)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s : requiredReplicaAcks() 에러 : %s", fixture.name, err.Error())
+			continue
+		}
+
+		if requiredAcks != fixture.expected {
+			t.Errorf("%s : requiredReplicaAcks() = %d, expected : %d", fixture.name, requiredAcks, fixture.expected)
+		}
+	}
+
+	delete(masterSlaveMap, masterClient.Address)
+}
+
+// 레플리카가 없는 마스터에 all-replicas 쓰기는 확인 없이 성공하지 않는다
+func TestReplicateToSlaveWithAckWithoutReplica(t *testing.T) {
+
+	masterClient := RedisClient{Address: testMasterA, Role: MasterRole}
+	delete(masterSlaveMap, masterClient.Address)
+
+	expectedErr := fmt.Sprintf(msg.NoReplicaAvailable, testMasterA, DurabilityAllReplicas)
+
+	err := masterClient.ReplicateToSlaveWithAck(SetCommand, "key", "value", DurabilityAllReplicas)
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("ReplicateToSlaveWithAck() 에러 %v, expected : %s", err, expectedErr)
+	}
+
+	err = masterClient.ReplicateTransactionToSlave([]KeyValuePair{{Key: "key", Value: "value"}}, DurabilityAllReplicas)
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("ReplicateTransactionToSlave() 에러 %v, expected : %s", err, expectedErr)
+	}
+}
@@ -0,0 +1,327 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gomodule/redigo/redis"
+
+	"hash_interface/internal/hash"
+	msg "hash_interface/internal/storage/message"
+)
+
+const (
+	// DefaultScanCount : 한 번에 가져올 Key 수의 기본값 (Redis SCAN COUNT 와 동일)
+	DefaultScanCount = 10
+
+	// MaxScanCount : 한 번에 가져올 수 있는 최대 Key 수
+	MaxScanCount = 1000
+
+	// scanStartCursor : 처음 / 마지막 커서 (Redis SCAN 과 동일)
+	scanStartCursor = "0"
+)
+
+// scanCursor : 모든 마스터에 걸친 SCAN 진행 상황, 클라이언트에는 base64(JSON) 로 전달
+//  - Generation : 이 세대 이후의 슬롯 이동은 확인하지 않았다
+//  - Pending : 순서대로 SCAN 할 마스터들과 각 마스터의 Redis SCAN 커서
+//  - Done : SCAN 을 마친 마스터들
+//
+type scanCursor struct {
+	Generation uint64         `json:"g"`
+	Pending    []scanProgress `json:"p"`
+	Done       []string       `json:"d,omitempty"`
+}
+
+// scanProgress : 마스터 하나의 SCAN 진행 상황
+// Slots 가 있으면 슬롯 이동으로 옮겨온 키를 다시 확인하는 중 (해당 슬롯의 키만)
+//
+type scanProgress struct {
+	Address string   `json:"a"`
+	Cursor  uint64   `json:"c"`
+	Slots   []uint16 `json:"s,omitempty"`
+}
+
+// ScanKeys : 모든 마스터의 Key 중 @prefix 로 시작하는 Key 들을 @cursor 부터 최대 @count 개 (SCAN)
+// 다음 커서를 함께 반환, "0" 이면 끝 (처음 요청은 빈 문자열 또는 "0")
+// Redis SCAN 과 같이 SCAN 내내 존재한 Key 는 반드시 한 번 이상 반환되고, 중복될 수 있다
+//  - 슬롯 이동으로 이미 SCAN 한 마스터로 옮겨진 키는, 그 마스터에서 해당 슬롯만 다시 SCAN
+//  - Failover 로 마스터가 바뀌면, 새 마스터를 처음부터 SCAN
+//
+func ScanKeys(prefix, cursor string, count int) ([]string, string, error) {
+
+	if count <= 0 {
+		count = DefaultScanCount
+	}
+	if count > MaxScanCount {
+		count = MaxScanCount
+	}
+
+	progress, err := decodeScanCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := progress.revisitMovedSlots(); err != nil {
+		return nil, "", err
+	}
+
+	keys := []string{}
+	isAdded := make(map[string]bool)
+	pattern := escapeGlob(prefix) + "*"
+
+	for len(progress.Pending) > 0 && len(keys) < count {
+
+		eachProgress := &progress.Pending[0]
+
+		masterClient, err := scanTargetMaster(eachProgress)
+		if err != nil {
+			return nil, "", err
+		}
+
+		reply, err := redis.Values(masterClient.Connection.Do(
+			"SCAN",
+			eachProgress.Cursor,
+			"MATCH", pattern,
+			"COUNT", count,
+		))
+		if err != nil {
+			return nil, "", err
+		}
+
+		var nextCursor uint64
+		var batch []string
+		if _, err := redis.Scan(reply, &nextCursor, &batch); err != nil {
+			return nil, "", err
+		}
+
+		for _, eachKey := range batch {
+			if isAdded[eachKey] || !eachProgress.includes(eachKey) {
+				continue
+			}
+			isAdded[eachKey] = true
+			keys = append(keys, eachKey)
+		}
+
+		if nextCursor != 0 {
+			eachProgress.Cursor = nextCursor
+			continue
+		}
+
+		// 마스터 하나의 SCAN 완료
+		if eachProgress.Slots == nil {
+			progress.Done = append(progress.Done, eachProgress.Address)
+		}
+		progress.Pending = progress.Pending[1:]
+	}
+
+	if len(progress.Pending) == 0 {
+		return keys, scanStartCursor, nil
+	}
+
+	return keys, progress.encode(), nil
+}
+
+// IsScanCursorError : 잘못되었거나 만료된 SCAN 커서라 거절된 에러인지
+func IsScanCursorError(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), msg.ScanCursorMarker)
+}
+
+// decodeScanCursor : 클라이언트가 전달한 커서 해석, 처음 요청이면 현재 모든 마스터로 시작
+func decodeScanCursor(cursor string) (*scanCursor, error) {
+
+	if cursor == "" || cursor == scanStartCursor {
+
+		masterAddresses := []string{}
+		for _, eachMaster := range GetMasterClients() {
+			masterAddresses = append(masterAddresses, eachMaster.Address)
+		}
+		sort.Strings(masterAddresses)
+
+		progress := &scanCursor{Generation: currentScanGeneration()}
+		for _, eachAddress := range masterAddresses {
+			progress.Pending = append(progress.Pending, scanProgress{Address: eachAddress})
+		}
+
+		return progress, nil
+	}
+
+	encodedCursor, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf(msg.InvalidScanCursor, cursor)
+	}
+
+	progress := &scanCursor{}
+	if err := json.Unmarshal(encodedCursor, progress); err != nil {
+		return nil, fmt.Errorf(msg.InvalidScanCursor, cursor)
+	}
+
+	return progress, nil
+}
+
+func (progress *scanCursor) encode() string {
+
+	encodedCursor, _ := json.Marshal(progress)
+
+	return base64.RawURLEncoding.EncodeToString(encodedCursor)
+}
+
+// revisitMovedSlots : 커서의 세대 이후 슬롯 이동 중, 이미 SCAN 을 시작한 마스터로 옮겨진 슬롯은 다시 SCAN 하도록 추가
+// 아직 시작하지 않은 마스터는 나중에 SCAN 하며 옮겨온 키도 보게 된다
+//
+func (progress *scanCursor) revisitMovedSlots() error {
+
+	moves, isKept := slotMovesSince(progress.Generation)
+	if !isKept {
+		return fmt.Errorf(msg.ScanCursorExpired)
+	}
+
+	progress.Generation = currentScanGeneration()
+
+	for _, eachMove := range moves {
+
+		if !progress.hasStarted(eachMove.targetAddress) {
+			continue
+		}
+
+		progress.addRevisit(eachMove.targetAddress, eachMove.slot)
+	}
+
+	return nil
+}
+
+// hasStarted : @address 마스터의 SCAN 을 이미 시작했는지 (끝났거나 진행 중)
+func (progress *scanCursor) hasStarted(address string) bool {
+
+	for _, eachAddress := range progress.Done {
+		if eachAddress == address {
+			return true
+		}
+	}
+
+	for _, eachProgress := range progress.Pending {
+		if eachProgress.Address == address && eachProgress.Slots == nil && eachProgress.Cursor != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addRevisit : @address 마스터의 @slot 다시 SCAN, 아직 시작하지 않은 재확인이 있으면 슬롯만 추가
+func (progress *scanCursor) addRevisit(address string, slot uint16) {
+
+	for i, eachProgress := range progress.Pending {
+
+		if eachProgress.Address != address || eachProgress.Slots == nil || eachProgress.Cursor != 0 {
+			continue
+		}
+
+		for _, eachSlot := range eachProgress.Slots {
+			if eachSlot == slot {
+				return
+			}
+		}
+
+		progress.Pending[i].Slots = append(progress.Pending[i].Slots, slot)
+		return
+	}
+
+	progress.Pending = append(progress.Pending, scanProgress{
+		Address: address,
+		Slots:   []uint16{slot},
+	})
+}
+
+// includes : 재확인 중이면 해당 슬롯의 키만
+func (eachProgress *scanProgress) includes(key string) bool {
+
+	if eachProgress.Slots == nil {
+		return true
+	}
+
+	hashSlotIndex := hash.GetHashSlotIndex(key)
+	for _, eachSlot := range eachProgress.Slots {
+		if eachSlot == hashSlotIndex {
+			return true
+		}
+	}
+
+	return false
+}
+
+// scanTargetMaster : SCAN 할 마스터, Failover 로 슬레이브가 된 경우 새 마스터를 처음부터
+func scanTargetMaster(eachProgress *scanProgress) (*RedisClient, error) {
+
+	masterClient, err := GetMasterWithAddress(eachProgress.Address)
+	if err == nil {
+		return masterClient, nil
+	}
+
+	promotedMaster, isFailedOver := slaveMasterMap[eachProgress.Address]
+	if !isFailedOver {
+		return nil, fmt.Errorf(msg.ScanCursorExpired)
+	}
+
+	eachProgress.Address = promotedMaster.Address
+	eachProgress.Cursor = 0
+
+	return GetMasterWithAddress(promotedMaster.Address)
+}
+
+// currentScanGeneration : 새 SCAN 이 확인해야 할 슬롯 이동의 기준 세대
+// 진행 중인 슬롯 이동은 끝날 때까지 계속 키가 옮겨지므로 확인 대상에 남긴다
+//
+func currentScanGeneration() uint64 {
+
+	migratingSlotsMutex.RLock()
+	defer migratingSlotsMutex.RUnlock()
+
+	generation := slotMoveGeneration
+	for _, eachMigration := range migratingSlots {
+		if eachMigration.generation-1 < generation {
+			generation = eachMigration.generation - 1
+		}
+	}
+
+	return generation
+}
+
+// slotMovesSince : @generation 이후의 슬롯 이동 기록
+// 기록이 이미 지워진 세대면 false
+//
+func slotMovesSince(generation uint64) ([]slotMove, bool) {
+
+	migratingSlotsMutex.RLock()
+	defer migratingSlotsMutex.RUnlock()
+
+	if len(slotMoves) > 0 && slotMoves[0].generation > generation+1 {
+		return nil, false
+	}
+
+	moves := []slotMove{}
+	for _, eachMove := range slotMoves {
+		if eachMove.generation > generation {
+			moves = append(moves, eachMove)
+		}
+	}
+
+	return moves, true
+}
+
+// escapeGlob : Prefix 를 Redis MATCH 패턴에서 문자 그대로 비교하도록
+func escapeGlob(prefix string) string {
+
+	var escaped strings.Builder
+	for _, eachRune := range prefix {
+		switch eachRune {
+		case '*', '?', '[', ']', '\\':
+			escaped.WriteRune('\\')
+		}
+		escaped.WriteRune(eachRune)
+	}
+
+	return escaped.String()
+}
@@ -0,0 +1,222 @@
+package storage
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"testing"
+
+	msg "hash_interface/internal/storage/message"
+)
+
+const (
+	testMasterA = "127.0.0.1:8000"
+	testMasterB = "127.0.0.1:8001"
+	testMasterC = "127.0.0.1:8002"
+)
+
+// setTestMasters : 마스터 목록을 @addresses 로 바꾸고, 원래대로 돌리는 함수 반환
+func setTestMasters(addresses []string) func() {
+
+	originMasters := redisMasterClients
+
+	redisMasterClients = make([]RedisClient, 0, len(addresses))
+	for _, eachAddress := range addresses {
+		redisMasterClients = append(redisMasterClients, RedisClient{Address: eachAddress})
+	}
+
+	return func() {
+		redisMasterClients = originMasters
+	}
+}
+
+// setTestSlotMoves : 슬롯 이동 기록을 바꾸고, 원래대로 돌리는 함수 반환
+func setTestSlotMoves(moves []slotMove) func() {
+
+	originMoves := slotMoves
+	originGeneration := slotMoveGeneration
+
+	slotMoves = moves
+	slotMoveGeneration = 0
+	if len(moves) > 0 {
+		slotMoveGeneration = moves[len(moves)-1].generation
+	}
+
+	return func() {
+		slotMoves = originMoves
+		slotMoveGeneration = originGeneration
+	}
+}
+
+func TestDecodeScanCursor(t *testing.T) {
+
+	restore := setTestMasters([]string{testMasterB, testMasterA})
+	defer restore()
+
+	notJSON := base64.RawURLEncoding.EncodeToString([]byte("cursor"))
+
+	encoded := (&scanCursor{
+		Generation: 3,
+		Pending:    []scanProgress{{Address: testMasterB, Cursor: 7}, {Address: testMasterA, Slots: []uint16{1, 2}}},
+		Done:       []string{testMasterC},
+	}).encode()
+
+	fixtures := []struct {
+		name        string
+		cursor      string
+		expected    *scanCursor
+		expectedErr string
+	}{
+		{
+			name:     "처음 요청 (빈 커서)",
+			cursor:   "",
+			expected: &scanCursor{Pending: []scanProgress{{Address: testMasterA}, {Address: testMasterB}}},
+		},
+		{
+			name:     "처음 요청 (0)",
+			cursor:   scanStartCursor,
+			expected: &scanCursor{Pending: []scanProgress{{Address: testMasterA}, {Address: testMasterB}}},
+		},
+		{
+			name:   "이어서 요청",
+			cursor: encoded,
+			expected: &scanCursor{
+				Generation: 3,
+				Pending:    []scanProgress{{Address: testMasterB, Cursor: 7}, {Address: testMasterA, Slots: []uint16{1, 2}}},
+				Done:       []string{testMasterC},
+			},
+		},
+		{
+			name:        "base64 가 아닌 커서",
+			cursor:      "!!",
+			expectedErr: fmt.Sprintf(msg.InvalidScanCursor, "!!"),
+		},
+		{
+			name:        "JSON 이 아닌 커서",
+			cursor:      notJSON,
+			expectedErr: fmt.Sprintf(msg.InvalidScanCursor, notJSON),
+		},
+	}
+
+	for _, fixture := range fixtures {
+
+		progress, err := decodeScanCursor(fixture.cursor)
+
+		if fixture.expectedErr != "" {
+			if err == nil || err.Error() != fixture.expectedErr || !IsScanCursorError(err) {
+				t.Errorf("%s : decodeScanCursor() 에러 %v, expected : %s", fixture.name, err, fixture.expectedErr)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s : decodeScanCursor() 에러 %s", fixture.name, err.Error())
+			continue
+		}
+
+		if !reflect.DeepEqual(progress, fixture.expected) {
+			t.Errorf("%s : decodeScanCursor() = %+v, expected : %+v", fixture.name, progress, fixture.expected)
+		}
+	}
+}
+
+func TestRevisitMovedSlots(t *testing.T) {
+
+	fixtures := []struct {
+		name        string
+		moves       []slotMove
+		progress    scanCursor
+		expected    scanCursor
+		expectedErr string
+	}{
+		{
+			name: "이미 SCAN 을 시작한 마스터로 옮겨진 슬롯만 다시 SCAN",
+			moves: []slotMove{
+				{generation: 1, slot: 10, targetAddress: testMasterA},
+				{generation: 2, slot: 11, targetAddress: testMasterB},
+				{generation: 3, slot: 12, targetAddress: testMasterC},
+				{generation: 4, slot: 13, targetAddress: testMasterA},
+				{generation: 5, slot: 10, targetAddress: testMasterA},
+			},
+			progress: scanCursor{
+				Pending: []scanProgress{{Address: testMasterB, Cursor: 5}, {Address: testMasterC}},
+				Done:    []string{testMasterA},
+			},
+			expected: scanCursor{
+				Generation: 5,
+				Pending: []scanProgress{
+					{Address: testMasterB, Cursor: 5},
+					{Address: testMasterC},
+					{Address: testMasterA, Slots: []uint16{10, 13}},
+					{Address: testMasterB, Slots: []uint16{11}},
+				},
+				Done: []string{testMasterA},
+			},
+		},
+		{
+			name: "커서 이전의 이동은 무시",
+			moves: []slotMove{
+				{generation: 1, slot: 10, targetAddress: testMasterA},
+				{generation: 2, slot: 11, targetAddress: testMasterA},
+			},
+			progress: scanCursor{
+				Generation: 2,
+				Done:       []string{testMasterA},
+			},
+			expected: scanCursor{
+				Generation: 2,
+				Done:       []string{testMasterA},
+			},
+		},
+		{
+			name: "재확인 중인 슬롯의 SCAN 을 시작했으면 새로 추가",
+			moves: []slotMove{
+				{generation: 1, slot: 10, targetAddress: testMasterA},
+			},
+			progress: scanCursor{
+				Pending: []scanProgress{{Address: testMasterA, Cursor: 3, Slots: []uint16{9}}},
+				Done:    []string{testMasterA},
+			},
+			expected: scanCursor{
+				Generation: 1,
+				Pending: []scanProgress{
+					{Address: testMasterA, Cursor: 3, Slots: []uint16{9}},
+					{Address: testMasterA, Slots: []uint16{10}},
+				},
+				Done: []string{testMasterA},
+			},
+		},
+		{
+			name: "보관하지 않는 이동 이후의 커서는 만료",
+			moves: []slotMove{
+				{generation: 5, slot: 10, targetAddress: testMasterA},
+			},
+			progress:    scanCursor{Generation: 2},
+			expectedErr: msg.ScanCursorExpired,
+		},
+	}
+
+	for _, fixture := range fixtures {
+
+		restore := setTestSlotMoves(fixture.moves)
+		progress := fixture.progress
+		err := progress.revisitMovedSlots()
+		restore()
+
+		if fixture.expectedErr != "" {
+			if err == nil || err.Error() != fixture.expectedErr || !IsScanCursorError(err) {
+				t.Errorf("%s : revisitMovedSlots() 에러 %v, expected : %s", fixture.name, err, fixture.expectedErr)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s : revisitMovedSlots() 에러 %s", fixture.name, err.Error())
+			continue
+		}
+
+		if !reflect.DeepEqual(progress, fixture.expected) {
+			t.Errorf("%s : revisitMovedSlots() = %+v, expected : %+v", fixture.name, progress, fixture.expected)
+		}
+	}
+}
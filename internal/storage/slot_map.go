@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"fmt"
+
+	"hash_interface/internal/hash"
+)
+
+// SlotRange : 연속된 해쉬 슬롯 [Start, End] 와 담당 마스터, 레플리카 (CLUSTER SLOTS)
+type SlotRange struct {
+	Start            uint16   `json:"start"`
+	End              uint16   `json:"end"`
+	MasterAddress    string   `json:"master"`
+	ReplicaAddresses []string `json:"replicas"`
+}
+
+// NodeSlots : 노드 별 역할과 담당 해쉬 슬롯 범위 (CLUSTER NODES)
+type NodeSlots struct {
+	Address       string   `json:"address"`
+	Role          string   `json:"role"`
+	MasterAddress string   `json:"master,omitempty"`
+	SlotCount     int      `json:"slot_count"`
+	Slots         []string `json:"slots"`
+}
+
+// KeySlot : Key 의 해쉬 슬롯과 담당 노드
+type KeySlot struct {
+	Key              string   `json:"key"`
+	Slot             uint16   `json:"slot"`
+	MasterAddress    string   `json:"master"`
+	ReplicaAddresses []string `json:"replicas"`
+
+	// ImportingAddress : 슬롯이 이동 중인 경우, 이동 목표 마스터 (ASK)
+	ImportingAddress string `json:"importing,omitempty"`
+
+	// Epoch : 조회 시점의 해쉬 슬롯 구성 버전
+	Epoch uint64 `json:"epoch"`
+}
+
+// GetSlotRanges : 현재 해쉬 슬롯 소유 현황을 연속된 범위들로 압축하여 반환
+//
+func GetSlotRanges() []SlotRange {
+	return hashSlot.slotRanges()
+}
+
+// GetNodeSlots : 모든 마스터/슬레이브와 (마스터 기준) 담당 해쉬 슬롯 범위
+//
+func GetNodeSlots() []NodeSlots {
+
+	slotRanges := hashSlot.slotRanges()
+
+	masterSlots := make(map[string]NodeSlots)
+	for _, eachRange := range slotRanges {
+
+		nodeSlots := masterSlots[eachRange.MasterAddress]
+		nodeSlots.SlotCount += int(eachRange.End-eachRange.Start) + 1
+		nodeSlots.Slots = append(nodeSlots.Slots, formatSlotRange(eachRange))
+
+		masterSlots[eachRange.MasterAddress] = nodeSlots
+	}
+
+	nodes := []NodeSlots{}
+
+	for _, eachMaster := range redisMasterClients {
+
+		nodeSlots := masterSlots[eachMaster.Address]
+		nodeSlots.Address = eachMaster.Address
+		nodeSlots.Role = eachMaster.Role
+		if nodeSlots.Slots == nil {
+			nodeSlots.Slots = []string{}
+		}
+
+		nodes = append(nodes, nodeSlots)
+	}
+
+	for _, eachSlave := range redisSlaveClients {
+
+		nodeSlots := NodeSlots{
+			Address: eachSlave.Address,
+			Role:    eachSlave.Role,
+			Slots:   []string{},
+		}
+
+		if masterClient, isSet := slaveMasterMap[eachSlave.Address]; isSet {
+			nodeSlots.MasterAddress = masterClient.Address
+		}
+
+		nodes = append(nodes, nodeSlots)
+	}
+
+	return nodes
+}
+
+// GetKeySlot : @key 의 해쉬 슬롯과 현재 담당 마스터, 레플리카
+//
+func GetKeySlot(key string) KeySlot {
+
+	hashSlotIndex := hash.GetHashSlotIndex(key)
+	owners := hashSlot.ownerAddresses()
+
+	keySlot := KeySlot{
+		Key:              key,
+		Slot:             hashSlotIndex,
+		MasterAddress:    owners[hashSlotIndex],
+		ReplicaAddresses: replicaAddressesOf(owners[hashSlotIndex]),
+		Epoch:            GetSlotMapEpoch(),
+	}
+
+	if migration, isMigrating := getSlotMigration(hashSlotIndex); isMigrating {
+		keySlot.ImportingAddress = migration.targetAddress
+	}
+
+	return keySlot
+}
+
+// slotRanges : 해쉬 슬롯 소유 현황을 연속된 범위들로 압축, 담당 노드가 없는 슬롯은 제외
+func (hashSlot HashSlot) slotRanges() []SlotRange {
+
+	owners := hashSlot.ownerAddresses()
+	slotRanges := []SlotRange{}
+
+	rangeStart := 0
+	for slotIndex := 1; slotIndex <= hash.HashSlotsNumber; slotIndex++ {
+
+		if slotIndex < hash.HashSlotsNumber && owners[slotIndex] == owners[rangeStart] {
+			continue
+		}
+
+		if owner := owners[rangeStart]; owner != "" {
+			slotRanges = append(slotRanges, SlotRange{
+				Start:            uint16(rangeStart),
+				End:              uint16(slotIndex - 1),
+				MasterAddress:    owner,
+				ReplicaAddresses: replicaAddressesOf(owner),
+			})
+		}
+
+		rangeStart = slotIndex
+	}
+
+	return slotRanges
+}
+
+// replicaAddressesOf : 마스터의 레플리카 주소 목록
+// To-Do : 1-1 매핑이 아닌, 슬레이브가 여러 대일 경우 처리
+//
+func replicaAddressesOf(masterAddress string) []string {
+
+	replicaAddresses := []string{}
+	if slaveClient, isSet := masterSlaveMap[masterAddress]; isSet {
+		replicaAddresses = append(replicaAddresses, slaveClient.Address)
+	}
+
+	return replicaAddresses
+}
+
+func formatSlotRange(slotRange SlotRange) string {
+
+	if slotRange.Start == slotRange.End {
+		return fmt.Sprintf("%d", slotRange.Start)
+	}
+
+	return fmt.Sprintf("%d-%d", slotRange.Start, slotRange.End)
+}
@@ -0,0 +1,369 @@
+package storage
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	"hash_interface/internal/hash"
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+// slotMigration : 이동 중인 해쉬 슬롯의 상태
+//  - 소스 마스터 입장에서 MIGRATING, 타겟 마스터 입장에서 IMPORTING
+//  - 슬롯의 소유권(hashSlot)은 모든 키가 옮겨질 때까지 소스 마스터에 있다
+//
+type slotMigration struct {
+	sourceAddress string
+	targetAddress string
+
+	// keyMutex : 키 이동과 요청 처리 동기화용
+	// 요청은 RLock, 키 이동 & 소유권 변경은 Lock
+	keyMutex *sync.RWMutex
+
+	// isDone : 소유권 변경 완료 여부, keyMutex 로 보호
+	isDone bool
+
+	// generation : 이 이동이 기록된 slotMoves 의 세대
+	generation uint64
+}
+
+// migratingSlots : 해쉬 슬롯 -> 이동 상태, 이동 중인 슬롯만 존재
+var migratingSlots = make(map[uint16]*slotMigration)
+var migratingSlotsMutex = &sync.RWMutex{}
+
+// slotMove : 슬롯 이동 기록, 여러 요청에 걸친 키 SCAN 이 이동 중 옮겨진 키를 놓치지 않도록
+type slotMove struct {
+	generation    uint64
+	slot          uint16
+	sourceAddress string
+	targetAddress string
+}
+
+// slotMoves : 최근 슬롯 이동 기록 (세대 순), migratingSlotsMutex 로 보호
+// slotMoveGeneration : 마지막 슬롯 이동의 세대
+var slotMoves []slotMove
+var slotMoveGeneration uint64
+
+// maxSlotMoves : 보관하는 슬롯 이동 기록 수, 이보다 오래된 SCAN 커서는 처음부터 다시
+const maxSlotMoves = 4 * hash.HashSlotsNumber
+
+func getSlotMigration(hashSlotIndex uint16) (*slotMigration, bool) {
+
+	migratingSlotsMutex.RLock()
+	defer migratingSlotsMutex.RUnlock()
+
+	migration, isMigrating := migratingSlots[hashSlotIndex]
+	return migration, isMigrating
+}
+
+// GetRedisClientWithKey : @key 를 처리해야하는 Redis Client 반환 (routeKeys 참고)
+// 반환된 @release 는 명령 실행 후 반드시 호출해야 한다 (명령 실행 도중 키가 옮겨지지 않도록)
+//
+func GetRedisClientWithKey(key string) (RedisClient, func(), error) {
+	return GetRedisClientWithKeys([]string{key})
+}
+
+// GetRedisClientWithKeys : 같은 해쉬 슬롯의 @keys 를 함께 처리해야하는 Redis Client 반환
+// 슬롯이 이동 중이고 키들이 소스/타겟 마스터에 나뉘어 있으면 TRYAGAIN 에러 (Redis Cluster 와 동일)
+//
+func GetRedisClientWithKeys(keys []string) (RedisClient, func(), error) {
+
+	route, release, err := routeKeys(keys)
+	if err != nil {
+		return RedisClient{}, release, err
+	}
+
+	return route.redisClient, release, nil
+}
+
+// keyRoute : 키들을 처리할 마스터와 해쉬 슬롯, 이동 목표 마스터가 처리하는지 (ASK)
+type keyRoute struct {
+	redisClient   RedisClient
+	hashSlotIndex uint16
+	isAsk         bool
+}
+
+// routeKeys : 같은 해쉬 슬롯의 @keys 를 처리할 마스터 결정 (GetRedisClientWithKeys, CheckSlotOwner 공용)
+// 슬롯이 이동 중이 아니면 슬롯의 담당 마스터
+// 슬롯이 이동 중이면,
+//  1) 아직 옮겨지지 않은 키(소스에 존재)는 소스 마스터
+//  2) 이미 옮겨졌거나 새로운 키는 타겟 마스터 (ASK)
+// 반환된 @release 는 명령 실행 후 반드시 호출해야 한다 (명령 실행 도중 키가 옮겨지지 않도록)
+//
+func routeKeys(keys []string) (keyRoute, func(), error) {
+
+	hashSlotIndex, err := CheckSameSlot(keys)
+	if err != nil {
+		return keyRoute{}, func() {}, err
+	}
+
+	redisClient, err := GetRedisClient(hashSlotIndex)
+	if err != nil {
+		return keyRoute{}, func() {}, err
+	}
+
+	route := keyRoute{
+		redisClient:   redisClient,
+		hashSlotIndex: hashSlotIndex,
+	}
+
+	migration, isMigrating := getSlotMigration(hashSlotIndex)
+	if isMigrating == false {
+		return route, func() {}, nil
+	}
+
+	migration.keyMutex.RLock()
+
+	// 그 사이 소유권이 변경된 경우, 새로운 소유자로 다시 확인
+	if migration.isDone {
+		migration.keyMutex.RUnlock()
+		return routeKeys(keys)
+	}
+
+	sourceClient, err := GetMasterWithAddress(migration.sourceAddress)
+	if err != nil {
+		migration.keyMutex.RUnlock()
+		return keyRoute{}, func() {}, err
+	}
+
+	existArgs := make([]interface{}, len(keys))
+	for i, eachKey := range keys {
+		existArgs[i] = eachKey
+	}
+
+	existCount, err := redis.Int(sourceClient.Connection.Do("EXISTS", existArgs...))
+	if err != nil {
+		migration.keyMutex.RUnlock()
+		return keyRoute{}, func() {}, err
+	}
+
+	// 모든 키가 아직 소스에 있는 경우
+	if existCount == len(keys) {
+		route.redisClient = *sourceClient
+		return route, migration.keyMutex.RUnlock, nil
+	}
+
+	// 일부 키만 옮겨진 경우
+	if existCount > 0 {
+		migration.keyMutex.RUnlock()
+		return keyRoute{}, func() {}, fmt.Errorf(msg.SlotMigrationTryAgain, hashSlotIndex)
+	}
+
+	// ASK : 타겟 마스터가 처리
+	targetClient, err := GetMasterWithAddress(migration.targetAddress)
+	if err != nil {
+		migration.keyMutex.RUnlock()
+		return keyRoute{}, func() {}, err
+	}
+
+	route.redisClient = *targetClient
+	route.isAsk = true
+
+	return route, migration.keyMutex.RUnlock, nil
+}
+
+// migrateSlotRange : @sourceClient 가 담당하는 해쉬 슬롯 [start, end) 를 한 슬롯 씩 @targetClient 로 이동
+// 슬롯 별로 키를 모두 옮긴 후에야 슬롯의 소유권이 변경된다
+//
+func (sourceClient *RedisClient) migrateSlotRange(targetClient *RedisClient, start uint16, end uint16) error {
+
+	tools.InfoLogger.Printf(
+		msg.SlotMigrationStart,
+		start,
+		end-1,
+		sourceClient.Address,
+		targetClient.Address,
+	)
+
+	for slotIndex := start; slotIndex < end; slotIndex++ {
+		if err := sourceClient.migrateSlot(targetClient, slotIndex); err != nil {
+			return err
+		}
+	}
+
+	tools.InfoLogger.Printf(
+		msg.SlotMigrationFinish,
+		start,
+		end-1,
+		sourceClient.Address,
+		targetClient.Address,
+	)
+
+	return nil
+}
+
+// migrateSlot : @slotIndex 해쉬 슬롯을 MIGRATING/IMPORTING 상태로 설정 후, 소스에 남은 키를 하나씩 이동
+// 키 목록은 이동 상태로 설정한 뒤에 읽는다 (그 전에 쓰인 키도 빠뜨리지 않도록)
+// 이동 상태 설정 전에 라우팅된 쓰기가 늦게 반영될 수 있으므로, 남은 키가 없을 때까지 다시 읽는다
+// 모든 키를 옮기면 슬롯의 소유권을 @targetClient 로 변경
+// 이동 중 에러 발생 시, 슬롯은 이동 상태로 남는다 (요청은 키 위치에 따라 계속 처리 가능)
+//
+func (sourceClient *RedisClient) migrateSlot(targetClient *RedisClient, slotIndex uint16) error {
+
+	migration := &slotMigration{
+		sourceAddress: sourceClient.Address,
+		targetAddress: targetClient.Address,
+		keyMutex:      &sync.RWMutex{},
+	}
+
+	migratingSlotsMutex.Lock()
+	migratingSlots[slotIndex] = migration
+	recordSlotMove(migration, slotIndex)
+	migratingSlotsMutex.Unlock()
+
+	// 이미 옮긴 키 (로그에 남아 있어도 Redis 에서 만료된 키는 다시 옮기지 않는다)
+	movedKeys := make(map[string]bool)
+
+	for {
+		keys, err := sourceClient.slotKeys(slotIndex, movedKeys)
+		if err != nil {
+			return fmt.Errorf(
+				msg.SlotMigrationFail,
+				slotIndex,
+				sourceClient.Address,
+				targetClient.Address,
+				err.Error(),
+			)
+		}
+
+		if len(keys) == 0 {
+			break
+		}
+
+		for _, eachKey := range keys {
+
+			migration.keyMutex.Lock()
+			err := sourceClient.migrateKeyTo(*targetClient, eachKey)
+			migration.keyMutex.Unlock()
+
+			if err != nil {
+				return fmt.Errorf(
+					msg.SlotMigrationFail,
+					slotIndex,
+					sourceClient.Address,
+					targetClient.Address,
+					err.Error(),
+				)
+			}
+
+			movedKeys[eachKey] = true
+		}
+	}
+
+	// 모든 키 이동 완료, 소유권 변경
+	migration.keyMutex.Lock()
+	hashSlot.setOwner(slotIndex, targetClient)
+	migration.isDone = true
+	migration.keyMutex.Unlock()
+
+	migratingSlotsMutex.Lock()
+	delete(migratingSlots, slotIndex)
+	migratingSlotsMutex.Unlock()
+
+	return nil
+}
+
+// slotKeys : 데이터 로그 기준 @slotIndex 해쉬 슬롯에 남아있는 키 중 @movedKeys 에 없는 키 목록
+func (sourceClient *RedisClient) slotKeys(slotIndex uint16, movedKeys map[string]bool) ([]string, error) {
+
+	sourceDataContainer := make(HashToDataMap)
+	if err := sourceClient.getLatestDataFromLog(sourceDataContainer, nil, nil); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(sourceDataContainer[slotIndex]))
+	for eachKey := range sourceDataContainer[slotIndex] {
+		if movedKeys[eachKey] == false {
+			keys = append(keys, eachKey)
+		}
+	}
+
+	return keys, nil
+}
+
+// recordSlotMove : 슬롯 이동 기록 추가, migratingSlotsMutex 잠근 상태로 호출
+func recordSlotMove(migration *slotMigration, slotIndex uint16) {
+
+	slotMoveGeneration++
+	migration.generation = slotMoveGeneration
+
+	slotMoves = append(slotMoves, slotMove{
+		generation:    slotMoveGeneration,
+		slot:          slotIndex,
+		sourceAddress: migration.sourceAddress,
+		targetAddress: migration.targetAddress,
+	})
+
+	if len(slotMoves) > maxSlotMoves {
+		slotMoves = slotMoves[len(slotMoves)-maxSlotMoves:]
+	}
+}
+
+// migrateKeyTo : @key 를 @targetClient 로 이동 (DUMP -> RESTORE REPLACE -> DEL)
+// 이미 삭제된 키는 무시한다
+//
+func (sourceClient RedisClient) migrateKeyTo(targetClient RedisClient, key string) error {
+
+	serializedValue, err := redis.Bytes(sourceClient.Connection.Do("DUMP", key))
+	if err == redis.ErrNil {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf(msg.KeyMigrationFail, key, sourceClient.Address, targetClient.Address, err.Error())
+	}
+
+	// 데이터 로그 기록용, 자료형과 관계없이 직렬화 값 그대로 (RESTORE 로그)
+	value := base64.StdEncoding.EncodeToString(serializedValue)
+
+	ttlInMillisecond, err := redis.Int64(sourceClient.Connection.Do("PTTL", key))
+	if err != nil {
+		return fmt.Errorf(msg.KeyMigrationFail, key, sourceClient.Address, targetClient.Address, err.Error())
+	}
+
+	// 만료 시간이 없는 키
+	if ttlInMillisecond < 0 {
+		ttlInMillisecond = 0
+	}
+
+	_, err = targetClient.Connection.Do("RESTORE", key, ttlInMillisecond, serializedValue, "REPLACE")
+	if err != nil {
+		return fmt.Errorf(msg.KeyMigrationFail, key, sourceClient.Address, targetClient.Address, err.Error())
+	}
+
+	// 타겟 마스터가 중간에 죽어도, 로그 파일에는 기록을 남김
+	if err := targetClient.RecordModificationLog(RestoreCommand, key, value); err != nil {
+		return fmt.Errorf(msg.LogFailWhileMigration, targetClient.Address)
+	}
+
+	targetClient.ReplicateToSlave(RestoreCommand, key, value)
+
+	// RESTORE 로 옮긴 남은 만료 시간도 로그에 기록
+	if ttlInMillisecond > 0 {
+		expireAt := toUnixMillisecond(time.Now()) + ttlInMillisecond
+		if err := targetClient.recordExpiry(key, expireAt, true); err != nil {
+			return fmt.Errorf(msg.LogFailWhileMigration, targetClient.Address)
+		}
+	}
+
+	// Key 의 버전도 타겟 마스터의 데이터 로그로 (CAS 의 expected_version 유지)
+	if version := GetKeyVersion(key); version > 0 {
+		targetClient.recordKeyVersion(key, version)
+	}
+
+	// 기존 데이터 주인이었던 소스 마스터에서는 제거
+	if _, err := sourceClient.Connection.Do("DEL", key); err != nil {
+		return fmt.Errorf(msg.DeleteDataFail, err.Error())
+	}
+
+	if err := sourceClient.RecordModificationLog("DEL", key, ""); err != nil {
+		return fmt.Errorf(msg.LogFailWhileMigration, sourceClient.Address)
+	}
+
+	sourceClient.ReplicateToSlave("DEL", key, "")
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"fmt"
+
+	msg "hash_interface/internal/storage/message"
+)
+
+// slotMapEpoch : 해쉬 슬롯 구성 버전, 슬롯의 담당 마스터가 바뀔 때마다 1 증가 (hashSlot.slotsMutex 로 보호)
+// 클라이언트는 GET /slots 로 받은 슬롯 구성을 이 버전과 함께 캐시하고, 리다이렉트 / 버전 변경 시 다시 받는다
+//
+var slotMapEpoch uint64
+
+// SlotRedirect : 요청한 키들의 해쉬 슬롯 담당이 클라이언트가 알고 있는 노드와 다른 경우 (Redis Cluster MOVED / ASK)
+//  - MOVED : 슬롯의 담당 마스터가 바뀌었다, 슬롯 구성을 다시 받아야 한다
+//  - ASK : 슬롯 이동 중, 이미 옮겨진 키는 이번 요청만 이동 목표 마스터로 (슬롯 구성은 그대로)
+//
+type SlotRedirect struct {
+	Type    string `json:"type"`
+	Slot    uint16 `json:"slot"`
+	Address string `json:"address"`
+	Epoch   uint64 `json:"epoch"`
+}
+
+func (redirect SlotRedirect) String() string {
+	return fmt.Sprintf(msg.SlotRedirected, redirect.Type, redirect.Slot, redirect.Address, redirect.Epoch)
+}
+
+// GetSlotMapEpoch : 현재 해쉬 슬롯 구성 버전
+func GetSlotMapEpoch() uint64 {
+
+	hashSlot.slotsMutex.RLock()
+	defer hashSlot.slotsMutex.RUnlock()
+
+	return slotMapEpoch
+}
+
+// setSlotMapEpoch : 기록된 구성 버전으로 복원 (RestoreTopology), 더 작은 값으로 되돌리지는 않는다
+func setSlotMapEpoch(epoch uint64) {
+
+	hashSlot.slotsMutex.Lock()
+	defer hashSlot.slotsMutex.Unlock()
+
+	if epoch > slotMapEpoch {
+		slotMapEpoch = epoch
+	}
+}
+
+// CheckSlotOwner : @keys 를 처리할 노드가 클라이언트가 알고 있는 @expectedAddress 인지 확인
+// 다르면 리다이렉트를 반환 (슬롯 이동 중이고 키가 이동 목표 마스터에 있으면 ASK, 아니면 MOVED)
+// 키들의 해쉬 슬롯이 다르거나 (CROSSSLOT) 이동 중 나뉘어 있으면 (TRYAGAIN) 에러
+//
+func CheckSlotOwner(keys []string, expectedAddress string) (SlotRedirect, bool, error) {
+
+	route, release, err := routeKeys(keys)
+	release()
+	if err != nil {
+		return SlotRedirect{}, false, err
+	}
+
+	if route.redisClient.Address == expectedAddress {
+		return SlotRedirect{}, false, nil
+	}
+
+	redirect := SlotRedirect{
+		Type:    msg.SlotMovedMarker,
+		Slot:    route.hashSlotIndex,
+		Address: route.redisClient.Address,
+		Epoch:   GetSlotMapEpoch(),
+	}
+
+	if route.isAsk {
+		redirect.Type = msg.SlotAskMarker
+	}
+
+	return redirect, true, nil
+}
+
+// EntryKeys : 엔트리가 변경하는 키들 (MSET / EXEC 는 묶인 명령들의 키)
+func EntryKeys(entry KeyValuePair) []string {
+
+	if len(entry.Commands) > 0 {
+		return keysOf(entry.Commands)
+	}
+
+	return []string{entry.Key}
+}
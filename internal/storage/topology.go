@@ -0,0 +1,386 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+const (
+	// 클러스터 구성(마스터 / 슬레이브 / 해쉬 슬롯) 변경 명령, Key 에 변경 ID, Value 에 TopologyChange (JSON)
+	// Raft 로그로 모든 인터페이스 서버에 같은 순서로 반영되어, 모든 서버가 같은 구성으로 요청을 처리한다
+	//
+	TopologyAddMasterCommand  = "TOPOLOGY_ADD_MASTER"
+	TopologyAddSlaveCommand   = "TOPOLOGY_ADD_SLAVE"
+	TopologyRemoveNodeCommand = "TOPOLOGY_REMOVE_NODE"
+	TopologyPromoteCommand    = "TOPOLOGY_PROMOTE"
+	TopologyRebalanceCommand  = "TOPOLOGY_REBALANCE"
+)
+
+// topologySnapshotPath : 현재 클러스터 구성 기록 파일, 재시작 시 설정 파일의 초기 노드 목록 대신 사용
+const topologySnapshotPath = "./logs/topology"
+
+// maxAppliedTopologyChanges : 스냅샷에 남기는 반영된 변경 ID 수
+const maxAppliedTopologyChanges = 1024
+
+// TopologyChange : 구성 변경 엔트리의 내용
+//  - Address : 추가 / 제거할 노드, 승격할 슬레이브
+//  - MasterAddress : 추가할 슬레이브의 마스터
+//  - Weights : 해쉬 슬롯 재분배 가중치 (ApplyRebalance)
+//  - Failover : 자동 Failover, 승격은 죽은 마스터를 기다리지 않고 / 재분배는 죽은 마스터(Address) 의 데이터 로그로 복구
+//
+type TopologyChange struct {
+	Address       string             `json:"address,omitempty"`
+	MasterAddress string             `json:"master_address,omitempty"`
+	Weights       map[string]float64 `json:"weights,omitempty"`
+	Failover      bool               `json:"failover,omitempty"`
+}
+
+// TopologySnapshot : 반영된 클러스터 구성
+//  - Slaves : 슬레이브 주소 -> 마스터 주소
+//  - Epoch : 해쉬 슬롯 구성 버전, 재시작 후에도 이전보다 작아지지 않도록
+//  - AppliedChanges : 반영한 변경 ID 들 (오래된 순), 재시작 후 WAL 을 다시 받아도 두 번 반영하지 않는다
+//  - Version : 반영한 구성 변경 수, 모든 인터페이스 서버가 같은 값 (자동 Failover 의 변경 ID)
+//
+type TopologySnapshot struct {
+	Masters        []string          `json:"masters"`
+	Slaves         map[string]string `json:"slaves"`
+	Slots          []SlotRange       `json:"slots"`
+	Epoch          uint64            `json:"epoch"`
+	AppliedChanges []string          `json:"applied_changes"`
+	Version        uint64            `json:"version"`
+}
+
+// appliedTopologyChanges : 반영한 변경 ID (스냅샷의 AppliedChanges 와 같은 내용)
+var appliedTopologyChanges = make(map[string]bool)
+var appliedTopologyOrder []string
+var topologyVersion uint64
+var topologyMutex = &sync.Mutex{}
+
+// TopologyDispatcher : 구성 변경 엔트리를 Raft 로그에 기록하고, 반영될 때까지 기다려 반영 결과 반환
+// HTTP 요청이 아닌 곳 (자동 Failover) 에서 구성을 바꿀 때 사용, 인터페이스 서버 시작 시 설정
+//
+type TopologyDispatcher func(entry KeyValuePair) (string, error)
+
+var topologyDispatcher TopologyDispatcher
+
+// SetTopologyDispatcher : 자동 Failover 의 구성 변경 엔트리를 기록할 @dispatcher 설정
+func SetTopologyDispatcher(dispatcher TopologyDispatcher) {
+	topologyDispatcher = dispatcher
+}
+
+// NewTopologyEntry : @command 구성 변경 엔트리, 변경 ID 는 요청받은 인터페이스 서버 기준으로 생성
+func NewTopologyEntry(command string, changeID string, change TopologyChange) (KeyValuePair, error) {
+
+	encodedChange, err := json.Marshal(change)
+	if err != nil {
+		return KeyValuePair{}, err
+	}
+
+	return KeyValuePair{
+		Command: command,
+		Key:     changeID,
+		Value:   string(encodedChange),
+	}, nil
+}
+
+// IsTopologyCommand : 클러스터 구성 변경 명령인지
+func IsTopologyCommand(command string) bool {
+
+	switch command {
+	case TopologyAddMasterCommand, TopologyAddSlaveCommand, TopologyRemoveNodeCommand,
+		TopologyPromoteCommand, TopologyRebalanceCommand:
+		return true
+	}
+
+	return false
+}
+
+// validateTopologyEntry : 변경 ID 가 있는지, 명령에 필요한 노드 주소가 있는지
+func validateTopologyEntry(entry KeyValuePair) error {
+
+	if entry.Key == "" {
+		return fmt.Errorf(msg.TopologyChangeIDMissing, entry.Command)
+	}
+
+	change, err := decodeTopologyChange(entry)
+	if err != nil {
+		return err
+	}
+
+	switch entry.Command {
+	case TopologyAddSlaveCommand:
+		if change.MasterAddress == "" {
+			return fmt.Errorf(msg.InvalidTopologyChange, entry.Command, entry.Value)
+		}
+		fallthrough
+
+	case TopologyAddMasterCommand, TopologyRemoveNodeCommand, TopologyPromoteCommand:
+		if change.Address == "" {
+			return fmt.Errorf(msg.InvalidTopologyChange, entry.Command, entry.Value)
+		}
+
+	case TopologyRebalanceCommand:
+		if change.Failover && change.Address == "" {
+			return fmt.Errorf(msg.InvalidTopologyChange, entry.Command, entry.Value)
+		}
+	}
+
+	return nil
+}
+
+func decodeTopologyChange(entry KeyValuePair) (TopologyChange, error) {
+
+	change := TopologyChange{}
+	if err := json.Unmarshal([]byte(entry.Value), &change); err != nil {
+		return TopologyChange{}, fmt.Errorf(msg.InvalidTopologyChange, entry.Command, entry.Value)
+	}
+
+	return change, nil
+}
+
+// executeTopologyEntry : 구성 변경 반영 후 스냅샷 기록, 반영 결과 반환 (재분배는 RebalancePlan JSON)
+// 이미 반영한 변경 ID 는 다시 반영하지 않는다
+//
+func executeTopologyEntry(entry KeyValuePair) (RedisClient, string, error) {
+
+	if isTopologyChangeApplied(entry.Key) {
+		return RedisClient{}, FormatEntry(entry), nil
+	}
+
+	change, err := decodeTopologyChange(entry)
+	if err != nil {
+		return RedisClient{}, "", err
+	}
+
+	result := FormatEntry(entry)
+
+	switch entry.Command {
+	case TopologyAddMasterCommand:
+		err = AddNewMaster(change.Address)
+
+	case TopologyAddSlaveCommand:
+		var targetMaster *RedisClient
+		if targetMaster, err = GetMasterWithAddress(change.MasterAddress); err == nil {
+			err = AddNewSlave(change.Address, *targetMaster)
+		}
+
+	case TopologyRemoveNodeCommand:
+		err = RemoveNode(change.Address)
+
+	case TopologyPromoteCommand:
+		if change.Failover {
+			err = promoteDeadMaster(change.Address)
+		} else {
+			_, err = ManualFailover(change.Address)
+		}
+
+	case TopologyRebalanceCommand:
+		if change.Failover {
+			err = redistributeDeadMaster(change.Address)
+			break
+		}
+
+		var plan RebalancePlan
+		if plan, err = ApplyRebalance(change.Weights); err == nil {
+			encodedPlan, _ := json.Marshal(plan)
+			result = string(encodedPlan)
+		}
+	}
+
+	if err != nil {
+		return RedisClient{}, "", fmt.Errorf(msg.TopologyRejected, entry.Command, entry.Key, err.Error())
+	}
+
+	markTopologyChangeApplied(entry.Key)
+	tools.InfoLogger.Printf(msg.TopologyChanged, entry.Command, entry.Key, entry.Value)
+
+	SaveTopologySnapshot()
+
+	return RedisClient{}, result, nil
+}
+
+// IsTopologyRejectedError : 구성 변경을 반영할 수 없어 거절된 에러인지 (ex. 이미 등록된 노드 추가)
+func IsTopologyRejectedError(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), msg.TopologyRejectedMarker)
+}
+
+func isTopologyChangeApplied(changeID string) bool {
+
+	topologyMutex.Lock()
+	defer topologyMutex.Unlock()
+
+	return appliedTopologyChanges[changeID]
+}
+
+func markTopologyChangeApplied(changeID string) {
+
+	topologyMutex.Lock()
+	defer topologyMutex.Unlock()
+
+	appliedTopologyChanges[changeID] = true
+	appliedTopologyOrder = append(appliedTopologyOrder, changeID)
+	topologyVersion++
+
+	if len(appliedTopologyOrder) > maxAppliedTopologyChanges {
+		delete(appliedTopologyChanges, appliedTopologyOrder[0])
+		appliedTopologyOrder = appliedTopologyOrder[1:]
+	}
+}
+
+// getTopologyVersion : 반영한 구성 변경 수
+func getTopologyVersion() uint64 {
+
+	topologyMutex.Lock()
+	defer topologyMutex.Unlock()
+
+	return topologyVersion
+}
+
+// GetTopologySnapshot : 현재 클러스터 구성
+func GetTopologySnapshot() TopologySnapshot {
+
+	snapshot := TopologySnapshot{
+		Masters: make([]string, len(redisMasterClients)),
+		Slaves:  make(map[string]string),
+		Slots:   GetSlotRanges(),
+		Epoch:   GetSlotMapEpoch(),
+	}
+
+	for i, eachMaster := range redisMasterClients {
+		snapshot.Masters[i] = eachMaster.Address
+	}
+
+	for eachSlaveAddress, eachMaster := range slaveMasterMap {
+		snapshot.Slaves[eachSlaveAddress] = eachMaster.Address
+	}
+
+	topologyMutex.Lock()
+	snapshot.AppliedChanges = append([]string{}, appliedTopologyOrder...)
+	snapshot.Version = topologyVersion
+	topologyMutex.Unlock()
+
+	return snapshot
+}
+
+// SaveTopologySnapshot : 현재 클러스터 구성을 기록 파일에 저장 (임시 파일에 쓴 뒤 교체)
+// 구성 변경 엔트리를 반영할 때마다 저장한다, 저장에 실패해도 계속 진행
+//
+func SaveTopologySnapshot() {
+
+	encodedSnapshot, err := json.Marshal(GetTopologySnapshot())
+	if err != nil {
+		tools.ErrorLogger.Printf(msg.TopologySnapshotWriteFail, err.Error())
+		return
+	}
+
+	topologyMutex.Lock()
+	defer topologyMutex.Unlock()
+
+	temporaryPath := topologySnapshotPath + ".tmp"
+	if err := ioutil.WriteFile(temporaryPath, encodedSnapshot, 0666); err != nil {
+		tools.ErrorLogger.Printf(msg.TopologySnapshotWriteFail, err.Error())
+		return
+	}
+
+	if err := os.Rename(temporaryPath, topologySnapshotPath); err != nil {
+		tools.ErrorLogger.Printf(msg.TopologySnapshotWriteFail, err.Error())
+	}
+}
+
+// RestoreTopology : 기록된 클러스터 구성으로 마스터 / 슬레이브 연결, 해쉬 슬롯 할당
+// 기록이 없으면 false (설정 파일의 초기 노드 목록으로 구성), 복제 방식 설정 후 호출
+//
+func RestoreTopology() (bool, error) {
+
+	encodedSnapshot, err := ioutil.ReadFile(topologySnapshotPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf(msg.TopologySnapshotReadFail, err.Error())
+	}
+
+	snapshot := TopologySnapshot{}
+	if err := json.Unmarshal(encodedSnapshot, &snapshot); err != nil {
+		return false, fmt.Errorf(msg.TopologySnapshotReadFail, err.Error())
+	}
+
+	if err := NodeConnectionSetup(snapshot.Masters, Default); err != nil {
+		return false, err
+	}
+
+	for _, eachMaster := range snapshot.Masters {
+		redisMutexMap[eachMaster] = &sync.Mutex{}
+	}
+
+	for _, eachRange := range snapshot.Slots {
+
+		masterClient, err := GetMasterWithAddress(eachRange.MasterAddress)
+		if err != nil {
+			return false, err
+		}
+
+		hashSlot.assign(masterClient, eachRange.Start, eachRange.End+1)
+	}
+	hashSlot.rebuildHashRanges()
+	setSlotMapEpoch(snapshot.Epoch)
+
+	for eachSlaveAddress, eachMasterAddress := range snapshot.Slaves {
+
+		masterClient, err := GetMasterWithAddress(eachMasterAddress)
+		if err != nil {
+			return false, err
+		}
+
+		if err := NodeConnectionSetup([]string{eachSlaveAddress}, AddSlave); err != nil {
+			return false, err
+		}
+
+		slaveClient, err := GetSlaveClientWithAddress(eachSlaveAddress)
+		if err != nil {
+			return false, err
+		}
+
+		initMasterSlaveMaps(*masterClient, *slaveClient)
+
+		if replicationMode == NativeReplication {
+			if err := slaveClient.replicaOf(*masterClient); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	for _, eachChangeID := range snapshot.AppliedChanges {
+		markTopologyChangeApplied(eachChangeID)
+	}
+
+	topologyMutex.Lock()
+	topologyVersion = snapshot.Version
+	topologyMutex.Unlock()
+
+	tools.InfoLogger.Printf(msg.TopologyRestored, len(snapshot.Masters), len(snapshot.Slaves))
+
+	return true, nil
+}
+
+// GetNodeAddresses : 등록된 모든 마스터, 슬레이브 주소
+func GetNodeAddresses() []string {
+
+	addresses := make([]string, 0, len(redisMasterClients)+len(redisSlaveClients))
+
+	for _, eachMaster := range redisMasterClients {
+		addresses = append(addresses, eachMaster.Address)
+	}
+
+	for _, eachSlave := range redisSlaveClients {
+		addresses = append(addresses, eachSlave.Address)
+	}
+
+	return addresses
+}
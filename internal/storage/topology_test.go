@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestTopologyEntry(t *testing.T, command string, changeID string, change TopologyChange) KeyValuePair {
+
+	entry, err := NewTopologyEntry(command, changeID, change)
+	if err != nil {
+		t.Fatalf("NewTopologyEntry(%s) 에러 : %s", command, err.Error())
+	}
+
+	return entry
+}
+
+func TestValidateTopologyEntry(t *testing.T) {
+
+	fixtures := []struct {
+		name        string
+		entry       KeyValuePair
+		expectedErr bool
+	}{
+		{
+			name:  "마스터 추가",
+			entry: newTestTopologyEntry(t, TopologyAddMasterCommand, "id", TopologyChange{Address: "127.0.0.1:8000"}),
+		},
+		{
+			name:        "변경 ID 없음",
+			entry:       newTestTopologyEntry(t, TopologyAddMasterCommand, "", TopologyChange{Address: "127.0.0.1:8000"}),
+			expectedErr: true,
+		},
+		{
+			name:        "추가할 마스터 주소 없음",
+			entry:       newTestTopologyEntry(t, TopologyAddMasterCommand, "id", TopologyChange{}),
+			expectedErr: true,
+		},
+		{
+			name: "슬레이브 추가",
+			entry: newTestTopologyEntry(t, TopologyAddSlaveCommand, "id", TopologyChange{
+				Address:       "127.0.0.1:8001",
+				MasterAddress: "127.0.0.1:8000",
+			}),
+		},
+		{
+			name:        "슬레이브의 마스터 주소 없음",
+			entry:       newTestTopologyEntry(t, TopologyAddSlaveCommand, "id", TopologyChange{Address: "127.0.0.1:8001"}),
+			expectedErr: true,
+		},
+		{
+			name:        "슬레이브 주소 없음",
+			entry:       newTestTopologyEntry(t, TopologyAddSlaveCommand, "id", TopologyChange{MasterAddress: "127.0.0.1:8000"}),
+			expectedErr: true,
+		},
+		{
+			name:        "제거할 노드 주소 없음",
+			entry:       newTestTopologyEntry(t, TopologyRemoveNodeCommand, "id", TopologyChange{}),
+			expectedErr: true,
+		},
+		{
+			name:  "자동 Failover 승격",
+			entry: newTestTopologyEntry(t, TopologyPromoteCommand, "id", TopologyChange{Address: "127.0.0.1:8001", Failover: true}),
+		},
+		{
+			name:        "승격할 슬레이브 주소 없음",
+			entry:       newTestTopologyEntry(t, TopologyPromoteCommand, "id", TopologyChange{Failover: true}),
+			expectedErr: true,
+		},
+		{
+			name:  "가중치 재분배",
+			entry: newTestTopologyEntry(t, TopologyRebalanceCommand, "id", TopologyChange{Weights: map[string]float64{"127.0.0.1:8000": 2}}),
+		},
+		{
+			name:  "자동 Failover 재분배",
+			entry: newTestTopologyEntry(t, TopologyRebalanceCommand, "id", TopologyChange{Address: "127.0.0.1:8000", Failover: true}),
+		},
+		{
+			name:        "자동 Failover 재분배의 죽은 마스터 주소 없음",
+			entry:       newTestTopologyEntry(t, TopologyRebalanceCommand, "id", TopologyChange{Failover: true}),
+			expectedErr: true,
+		},
+		{
+			name:        "잘못된 JSON",
+			entry:       KeyValuePair{Command: TopologyAddMasterCommand, Key: "id", Value: "{"},
+			expectedErr: true,
+		},
+	}
+
+	for _, fixture := range fixtures {
+
+		err := validateTopologyEntry(fixture.entry)
+		if (err != nil) != fixture.expectedErr {
+			t.Errorf("%s : validateTopologyEntry() 에러 %v, expected 에러 : %v", fixture.name, err, fixture.expectedErr)
+		}
+	}
+}
+
+// 같은 구성에서 요청한 자동 Failover 는 서버가 달라도 같은 변경 ID, 구성이 바뀌면 다른 변경 ID
+func TestDispatchFailoverChangeID(t *testing.T) {
+
+	dispatchedEntries := []KeyValuePair{}
+
+	originDispatcher := topologyDispatcher
+	SetTopologyDispatcher(func(entry KeyValuePair) (string, error) {
+		dispatchedEntries = append(dispatchedEntries, entry)
+		return "", nil
+	})
+	defer SetTopologyDispatcher(originDispatcher)
+
+	change := TopologyChange{Address: "127.0.0.1:8001", Failover: true}
+
+	dispatchFailoverChange(TopologyPromoteCommand, change)
+	dispatchFailoverChange(TopologyPromoteCommand, change)
+
+	topologyMutex.Lock()
+	topologyVersion++
+	topologyMutex.Unlock()
+
+	dispatchFailoverChange(TopologyPromoteCommand, change)
+
+	if len(dispatchedEntries) != 3 {
+		t.Fatalf("dispatchFailoverChange() 요청 %d 개, expected : 3", len(dispatchedEntries))
+	}
+
+	if dispatchedEntries[0].Key != dispatchedEntries[1].Key {
+		t.Errorf("같은 구성의 변경 ID %q, %q 가 다릅니다", dispatchedEntries[0].Key, dispatchedEntries[1].Key)
+	}
+
+	if dispatchedEntries[1].Key == dispatchedEntries[2].Key {
+		t.Errorf("구성이 바뀐 뒤에도 같은 변경 ID %q", dispatchedEntries[2].Key)
+	}
+
+	for _, eachEntry := range dispatchedEntries {
+		if err := validateTopologyEntry(eachEntry); err != nil {
+			t.Errorf("dispatchFailoverChange() 엔트리 %s 검증 에러 : %s", FormatEntry(eachEntry), err.Error())
+		}
+	}
+}
+
+func TestRequestFailoverOncePerMaster(t *testing.T) {
+
+	release := make(chan struct{})
+	done := make(chan struct{})
+
+	isRequested := requestFailover("127.0.0.1:8000", func() {
+		<-release
+		close(done)
+	})
+	if !isRequested {
+		t.Fatalf("requestFailover() 첫 요청이 거절되었습니다")
+	}
+
+	if requestFailover("127.0.0.1:8000", func() {}) {
+		t.Errorf("requestFailover() 요청 중인 마스터를 다시 요청했습니다")
+	}
+
+	close(release)
+	<-done
+
+	// 요청이 끝난 뒤에는 다시 요청할 수 있다
+	for {
+		failoverRequestsMutex.Lock()
+		isRequesting := failoverRequests["127.0.0.1:8000"]
+		failoverRequestsMutex.Unlock()
+
+		if !isRequesting {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if !requestFailover("127.0.0.1:8000", func() {}) {
+		t.Errorf("requestFailover() 끝난 요청 후 다시 요청이 거절되었습니다")
+	}
+}
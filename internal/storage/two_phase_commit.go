@@ -0,0 +1,406 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"hash_interface/internal/hash"
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+// 여러 마스터에 걸친 분산 트랜잭션(2PC) 단계 별 WAL 엔트리 명령
+//  - TXN_PREPARE : 참여 마스터 확인, 키 버전 비교 & 키 잠금 (Commands 에 실행할 명령, ExpireAt 에 결정 기한)
+//  - TXN_COMMIT / TXN_ABORT : 코디네이터의 결정, 모든 노드가 같은 순서로 반영
+//
+const (
+	TxnPrepareCommand = "TXN_PREPARE"
+	TxnCommitCommand  = "TXN_COMMIT"
+	TxnAbortCommand   = "TXN_ABORT"
+
+	// TransactionTimeout : 결정 없이 이 시간이 지난 PREPARE 는 코디네이터가 죽은 것으로 보고 취소
+	TransactionTimeout = 10 * time.Second
+)
+
+// preparedTransaction : PREPARE 되었지만 아직 결정(COMMIT/ABORT)이 반영되지 않은 분산 트랜잭션
+type preparedTransaction struct {
+	txID     string
+	commands []KeyValuePair
+
+	// committedMasters : COMMIT 도중 실패 시, 이미 반영한 마스터 (재시도 시 건너뜀)
+	committedMasters map[string]bool
+
+	// deadline : 이 시각까지 결정이 없으면 코디네이터가 죽은 것으로 보고 취소
+	deadline time.Time
+}
+
+var (
+	preparedTransactions = make(map[string]*preparedTransaction)
+
+	// decidedTransactions : 트랜잭션 ID -> 반영된 결정 (TXN_COMMIT / TXN_ABORT)
+	decidedTransactions = make(map[string]string)
+
+	// lockedKeys : 키 -> 잠금 중인 트랜잭션 ID
+	lockedKeys = make(map[string]string)
+
+	transactionMutex = &sync.Mutex{}
+)
+
+// NewPrepareEntry : 분산 트랜잭션 @txID 의 PREPARE 엔트리
+// 결정 기한(@now + TransactionTimeout)을 WAL 에 함께 기록해, 모든 노드 / 새 리더가 같은 기한으로 판단한다
+//
+func NewPrepareEntry(txID string, commands []KeyValuePair, now time.Time) KeyValuePair {
+
+	return KeyValuePair{
+		Command:  TxnPrepareCommand,
+		TxID:     txID,
+		Commands: commands,
+		ExpireAt: toUnixMillisecond(now.Add(TransactionTimeout)),
+	}
+}
+
+// PrepareDeadline : PREPARE 엔트리 @entry 의 결정 기한
+// 기한 없이 기록된 엔트리는 @now 부터 TransactionTimeout
+//
+func PrepareDeadline(entry KeyValuePair, now time.Time) time.Time {
+
+	if entry.ExpireAt == 0 {
+		return now.Add(TransactionTimeout)
+	}
+
+	return time.Unix(0, entry.ExpireAt*int64(time.Millisecond))
+}
+
+// IsTwoPhaseCommand : 분산 트랜잭션 단계 명령인지
+func IsTwoPhaseCommand(command string) bool {
+
+	switch command {
+	case TxnPrepareCommand, TxnCommitCommand, TxnAbortCommand:
+		return true
+	}
+
+	return false
+}
+
+// IsKeyLockedError : 분산 트랜잭션이 잠근 키에 대한 요청이라 거절된 에러인지
+func IsKeyLockedError(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), msg.TransactionKeyLockedMarker)
+}
+
+// executeTwoPhaseEntry : 분산 트랜잭션 단계 엔트리 반영
+func executeTwoPhaseEntry(entry KeyValuePair, durability Durability) error {
+
+	if entry.TxID == "" {
+		return fmt.Errorf(msg.TransactionIDMissing)
+	}
+
+	switch entry.Command {
+	case TxnPrepareCommand:
+		return prepareTransaction(entry.TxID, entry.Commands, PrepareDeadline(entry, time.Now()))
+
+	case TxnCommitCommand:
+		return commitTransaction(entry.TxID, durability)
+	}
+
+	return abortTransaction(entry.TxID)
+}
+
+// prepareTransaction : 분산 트랜잭션 @txID 의 1단계
+//  1) 명령들의 키를 잠근다 (다른 트랜잭션이 잠근 키가 있으면 실패)
+//  2) 버전 비교(cas + expected_version)가 있는 키는 현재 버전과 같은지 확인 (WATCH)
+//  3) 키들의 해쉬 슬롯을 담당하는 마스터가 모두 응답 가능한지 확인
+// 잠근 키는 결정까지 다른 쓰기가 거절되므로, COMMIT 때도 확인한 버전 그대로다
+// 이미 PREPARE 된 트랜잭션이면 무시한다
+//
+func prepareTransaction(txID string, commands []KeyValuePair, deadline time.Time) error {
+
+	transactionMutex.Lock()
+	defer transactionMutex.Unlock()
+
+	if _, isPrepared := preparedTransactions[txID]; isPrepared {
+		return nil
+	}
+
+	if decision, isDecided := decidedTransactions[txID]; isDecided {
+		return fmt.Errorf(msg.TransactionAlreadyDecided, txID, decision)
+	}
+
+	for _, eachKey := range keysOf(commands) {
+		if holder, isLocked := lockedKeys[eachKey]; isLocked {
+			return fmt.Errorf(msg.TransactionKeyLocked, eachKey, holder)
+		}
+	}
+
+	if err := checkExpectedVersions(commands); err != nil {
+		return err
+	}
+
+	// 참여 마스터 확인 (죽은 마스터는 GetRedisClient 에서 Failover 처리)
+	for _, eachSlot := range slotsOf(commands) {
+
+		if _, isMigrating := getSlotMigration(eachSlot); isMigrating {
+			return fmt.Errorf(msg.SlotMigrationTryAgain, eachSlot)
+		}
+
+		if _, err := GetRedisClient(eachSlot); err != nil {
+			return fmt.Errorf(msg.TransactionPrepareFail, txID, err.Error())
+		}
+	}
+
+	for _, eachKey := range keysOf(commands) {
+		lockedKeys[eachKey] = txID
+	}
+
+	preparedTransactions[txID] = &preparedTransaction{
+		txID:             txID,
+		commands:         commands,
+		committedMasters: make(map[string]bool),
+		deadline:         deadline,
+	}
+
+	tools.InfoLogger.Printf(msg.TransactionPrepared, txID, len(commands))
+
+	return nil
+}
+
+// commitTransaction : 분산 트랜잭션 @txID 의 2단계 - 마스터 별로 명령들을 하나의 트랜잭션(MULTI/EXEC)으로 실행
+// 일부 마스터에서 실패하면 트랜잭션은 PREPARE 상태로 남고, 다시 COMMIT 하면 남은 마스터만 반영한다
+//
+func commitTransaction(txID string, durability Durability) error {
+
+	transactionMutex.Lock()
+	defer transactionMutex.Unlock()
+
+	transaction, isPrepared := preparedTransactions[txID]
+	if !isPrepared {
+		if decidedTransactions[txID] == TxnAbortCommand {
+			return fmt.Errorf(msg.TransactionAlreadyDecided, txID, TxnAbortCommand)
+		}
+		return nil
+	}
+
+	commandsPerMaster := make(map[string][]KeyValuePair)
+	mastersInOrder := []string{}
+
+	for _, eachCommand := range transaction.commands {
+
+		redisClient, err := GetRedisClient(hash.GetHashSlotIndex(eachCommand.Key))
+		if err != nil {
+			return fmt.Errorf(msg.TransactionCommitFail, txID, "", err.Error())
+		}
+
+		if _, isSet := commandsPerMaster[redisClient.Address]; !isSet {
+			mastersInOrder = append(mastersInOrder, redisClient.Address)
+		}
+		commandsPerMaster[redisClient.Address] = append(commandsPerMaster[redisClient.Address], eachCommand)
+	}
+
+	var commitErr error
+
+	for _, eachAddress := range mastersInOrder {
+
+		if transaction.committedMasters[eachAddress] {
+			continue
+		}
+
+		masterClient, err := GetMasterWithAddress(eachAddress)
+		if err == nil {
+			err = masterClient.commitCommands(commandsPerMaster[eachAddress], durability)
+		}
+
+		if err != nil && !IsReplicaQuorumError(err) {
+			tools.ErrorLogger.Printf(msg.TransactionCommitFail, txID, eachAddress, err.Error())
+			commitErr = fmt.Errorf(msg.TransactionCommitFail, txID, eachAddress, err.Error())
+			continue
+		}
+
+		transaction.committedMasters[eachAddress] = true
+
+		if err != nil && commitErr == nil {
+			commitErr = err
+		}
+	}
+
+	if len(transaction.committedMasters) < len(mastersInOrder) {
+		return commitErr
+	}
+
+	releaseTransaction(transaction, TxnCommitCommand)
+
+	tools.InfoLogger.Printf(msg.TransactionCommitted, txID, len(mastersInOrder))
+
+	// 모든 마스터에 반영되었지만, 요청한 수준의 레플리카 확인을 받지 못한 경우
+	return commitErr
+}
+
+// commitCommands : @commands 를 하나의 트랜잭션으로 실행 후, 로그 기록 & 슬레이브 전파
+func (masterClient RedisClient) commitCommands(commands []KeyValuePair, durability Durability) error {
+
+	if err := runTransaction(masterClient.Connection, commands); err != nil {
+		return err
+	}
+
+	bumpKeyVersions(masterClient, keysOf(commands))
+
+	for _, eachCommand := range commands {
+		err := masterClient.RecordModificationLog(
+			EntryCommand(eachCommand),
+			eachCommand.Key,
+			eachCommand.Value,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return masterClient.ReplicateTransactionToSlave(commands, durability)
+}
+
+// abortTransaction : 분산 트랜잭션 @txID 취소, 잠금 해제
+// PREPARE 단계에서는 아무것도 쓰지 않으므로 되돌릴 데이터는 없다
+//
+func abortTransaction(txID string) error {
+
+	transactionMutex.Lock()
+	defer transactionMutex.Unlock()
+
+	transaction, isPrepared := preparedTransactions[txID]
+	if !isPrepared {
+		if _, isDecided := decidedTransactions[txID]; !isDecided {
+			decidedTransactions[txID] = TxnAbortCommand
+		}
+		return nil
+	}
+
+	// 일부 마스터에 이미 반영된 트랜잭션은 취소할 수 없다 (COMMIT 을 다시 시도해야 한다)
+	if len(transaction.committedMasters) > 0 {
+		return fmt.Errorf(msg.TransactionAlreadyDecided, txID, TxnCommitCommand)
+	}
+
+	releaseTransaction(transaction, TxnAbortCommand)
+
+	tools.InfoLogger.Printf(msg.TransactionAborted, txID)
+
+	return nil
+}
+
+// releaseTransaction : @transaction 의 잠금 해제 & 결정 기록, transactionMutex 잠근 상태로 호출
+func releaseTransaction(transaction *preparedTransaction, decision string) {
+
+	for _, eachKey := range keysOf(transaction.commands) {
+		if lockedKeys[eachKey] == transaction.txID {
+			delete(lockedKeys, eachKey)
+		}
+	}
+
+	delete(preparedTransactions, transaction.txID)
+	decidedTransactions[transaction.txID] = decision
+}
+
+// checkKeysUnlocked : 분산 트랜잭션이 잠근 키가 @keys 에 있으면 에러
+func checkKeysUnlocked(keys []string) error {
+
+	transactionMutex.Lock()
+	defer transactionMutex.Unlock()
+
+	for _, eachKey := range keys {
+		if holder, isLocked := lockedKeys[eachKey]; isLocked {
+			return fmt.Errorf(msg.TransactionKeyLocked, eachKey, holder)
+		}
+	}
+
+	return nil
+}
+
+// checkExpectedVersions : @commands 중 버전 비교가 있는 키의 현재 버전이 기대 버전과 다르면 CONFLICT 에러
+func checkExpectedVersions(commands []KeyValuePair) error {
+
+	keyVersionsMutex.Lock()
+	defer keyVersionsMutex.Unlock()
+
+	for _, eachCommand := range commands {
+
+		if !isVersionCheck(eachCommand) {
+			continue
+		}
+
+		currentVersion := keyVersions[eachCommand.Key]
+		if *eachCommand.ExpectedVersion != currentVersion {
+			return fmt.Errorf(
+				msg.VersionMismatch,
+				eachCommand.Key,
+				*eachCommand.ExpectedVersion,
+				currentVersion,
+			)
+		}
+	}
+
+	return nil
+}
+
+// isVersionCheck : 분산 트랜잭션 명령의 버전 비교 (cas + expected_version, 값 비교 없이)
+func isVersionCheck(command KeyValuePair) bool {
+	return command.Condition == ConditionCompareAndSwap && command.ExpectedVersion != nil && command.ExpectedValue == nil
+}
+
+// withoutVersionChecks : PREPARE 에서 확인하는 버전 비교를 뺀 명령들
+func withoutVersionChecks(commands []KeyValuePair) []KeyValuePair {
+
+	stripped := make([]KeyValuePair, len(commands))
+	for i, eachCommand := range commands {
+		if isVersionCheck(eachCommand) {
+			eachCommand.Condition = ""
+			eachCommand.ExpectedVersion = nil
+		}
+		stripped[i] = eachCommand
+	}
+
+	return stripped
+}
+
+// GetPreparedTransactions : 결정 기한이 @now 이전인데 결정이 반영되지 않은 분산 트랜잭션 ID 목록
+func GetPreparedTransactions(now time.Time) []string {
+
+	transactionMutex.Lock()
+	defer transactionMutex.Unlock()
+
+	txIDs := []string{}
+	for txID, eachTransaction := range preparedTransactions {
+		if !now.Before(eachTransaction.deadline) {
+			txIDs = append(txIDs, txID)
+		}
+	}
+
+	sort.Strings(txIDs)
+
+	return txIDs
+}
+
+func keysOf(commands []KeyValuePair) []string {
+
+	keys := make([]string, len(commands))
+	for i, eachCommand := range commands {
+		keys[i] = eachCommand.Key
+	}
+
+	return keys
+}
+
+func slotsOf(commands []KeyValuePair) []uint16 {
+
+	slots := []uint16{}
+	isAdded := make(map[uint16]bool)
+
+	for _, eachCommand := range commands {
+		hashSlotIndex := hash.GetHashSlotIndex(eachCommand.Key)
+		if !isAdded[hashSlotIndex] {
+			isAdded[hashSlotIndex] = true
+			slots = append(slots, hashSlotIndex)
+		}
+	}
+
+	return slots
+}
@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	msg "hash_interface/internal/storage/message"
+)
+
+// PREPARE 는 버전 비교(cas + expected_version)가 있는 키의 현재 버전을 확인하고, 다르면 잠그지 않는다
+func TestPrepareTransactionChecksVersions(t *testing.T) {
+
+	keyVersionsMutex.Lock()
+	keyVersions["{tx}.watched"] = 2
+	keyVersionsMutex.Unlock()
+
+	defer func() {
+		keyVersionsMutex.Lock()
+		delete(keyVersions, "{tx}.watched")
+		keyVersionsMutex.Unlock()
+	}()
+
+	staleVersion := uint64(1)
+	currentVersion := uint64(2)
+
+	fixtures := []struct {
+		name        string
+		commands    []KeyValuePair
+		expectedErr string
+	}{
+		{
+			name: "현재 버전과 같음",
+			commands: []KeyValuePair{
+				{Key: "{tx}.watched", Value: "x", Condition: ConditionCompareAndSwap, ExpectedVersion: &currentVersion},
+				{Key: "{other}.key", Value: "y"},
+			},
+		},
+		{
+			name: "다른 쓰기가 먼저 반영됨",
+			commands: []KeyValuePair{
+				{Key: "{tx}.watched", Value: "x", Condition: ConditionCompareAndSwap, ExpectedVersion: &staleVersion},
+				{Key: "{other}.key", Value: "y"},
+			},
+			expectedErr: fmt.Sprintf(msg.VersionMismatch, "{tx}.watched", staleVersion, currentVersion),
+		},
+		{
+			name: "쓰기가 없던 키는 버전 0",
+			commands: []KeyValuePair{
+				{Key: "{tx}.new", Value: "x", Condition: ConditionCompareAndSwap, ExpectedVersion: &staleVersion},
+			},
+			expectedErr: fmt.Sprintf(msg.VersionMismatch, "{tx}.new", staleVersion, 0),
+		},
+	}
+
+	for _, fixture := range fixtures {
+
+		err := ValidateEntry(NewPrepareEntry("tx", fixture.commands, time.Now()))
+		if err != nil {
+			t.Errorf("%s : ValidateEntry() 에러 : %s", fixture.name, err.Error())
+			continue
+		}
+
+		err = checkExpectedVersions(fixture.commands)
+
+		if fixture.expectedErr == "" {
+			if err != nil {
+				t.Errorf("%s : checkExpectedVersions() 에러 : %s", fixture.name, err.Error())
+			}
+			continue
+		}
+
+		if err == nil || err.Error() != fixture.expectedErr || !IsConditionFailedError(err) {
+			t.Errorf("%s : checkExpectedVersions() 에러 %v, expected : %s", fixture.name, err, fixture.expectedErr)
+			continue
+		}
+
+		// 버전이 다르면 키를 잠그지 않는다
+		err = prepareTransaction("stale", fixture.commands, time.Now().Add(TransactionTimeout))
+		if err == nil || err.Error() != fixture.expectedErr {
+			t.Errorf("%s : prepareTransaction() 에러 %v, expected : %s", fixture.name, err, fixture.expectedErr)
+		}
+
+		if err := checkKeysUnlocked(keysOf(fixture.commands)); err != nil {
+			t.Errorf("%s : 실패한 PREPARE 가 키를 잠갔습니다 - %s", fixture.name, err.Error())
+		}
+	}
+}
+
+// 값 비교는 PREPARE 에서 확인할 수 없으므로 분산 트랜잭션에서 거절한다
+func TestValidatePrepareEntryRejectsValueCompare(t *testing.T) {
+
+	expectedValue := "before"
+	commands := []KeyValuePair{
+		{Key: "{tx}.watched", Value: "x", Condition: ConditionCompareAndSwap, ExpectedValue: &expectedValue},
+	}
+
+	expectedErr := fmt.Sprintf(msg.ConditionInTransaction, "{tx}.watched")
+
+	err := ValidateEntry(NewPrepareEntry("tx", commands, time.Now()))
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("ValidateEntry() 에러 %v, expected : %s", err, expectedErr)
+	}
+}
+
+// 결정 기한이 지난 트랜잭션만 취소 대상
+func TestGetPreparedTransactions(t *testing.T) {
+
+	now := time.Now()
+
+	transactionMutex.Lock()
+	preparedTransactions["expired"] = &preparedTransaction{txID: "expired", deadline: now.Add(-time.Second)}
+	preparedTransactions["healthy"] = &preparedTransaction{txID: "healthy", deadline: now.Add(TransactionTimeout)}
+	transactionMutex.Unlock()
+
+	defer func() {
+		transactionMutex.Lock()
+		delete(preparedTransactions, "expired")
+		delete(preparedTransactions, "healthy")
+		transactionMutex.Unlock()
+	}()
+
+	expected := []string{"expired"}
+
+	if txIDs := GetPreparedTransactions(now); !reflect.DeepEqual(txIDs, expected) {
+		t.Errorf("GetPreparedTransactions() = %v, expected : %v", txIDs, expected)
+	}
+}
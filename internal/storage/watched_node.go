@@ -0,0 +1,237 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+
+	msg "hash_interface/internal/storage/message"
+	"hash_interface/tools"
+)
+
+// watchedLatencyHistory : 노드 별로 기억하는 최근 PING 응답 시간 개수
+const watchedLatencyHistory = 16
+
+// watchedNode : 모니터 서버가 감시하는 레디스 노드 하나 (마스터 / 슬레이브 구분 없음)
+// 인터페이스 서버의 마스터 / 슬레이브 목록과 별개로, 감시 전용 연결을 가진다
+//
+type watchedNode struct {
+	address    string
+	connection redis.Conn
+
+	// latencies : 최근 PING 응답 시간 (오래된 순), 실패한 PING 은 기록하지 않는다
+	latencies []time.Duration
+
+	registeredAt        time.Time
+	lastSeen            time.Time
+	consecutiveFailures int
+
+	mutex sync.Mutex
+}
+
+// WatchedNodeStatus : 감시 중인 노드 상태 (GET /monitor/nodes)
+//  - LatencyHistory : 최근 PING 응답 시간 (millisecond, 오래된 순)
+//  - LastSeen : 마지막으로 PING 에 응답한 시각 (처음은 감시를 시작한 시각)
+//  - ConsecutiveFailures : 마지막 응답 이후 연속으로 실패한 PING 수
+//
+type WatchedNodeStatus struct {
+	Address             string    `json:"address"`
+	IsConnected         bool      `json:"is_connected"`
+	LatencyHistory      []float64 `json:"latency_history_ms"`
+	RegisteredAt        time.Time `json:"registered_at"`
+	LastSeen            time.Time `json:"last_seen"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+}
+
+// watchedNodes : 노드 주소 -> 감시 중인 노드
+var watchedNodes = make(map[string]*watchedNode)
+var watchedNodesMutex = &sync.RWMutex{}
+
+// WatchNode : @address 노드 감시 시작, 연결에 실패하면 등록하지 않는다
+// 이미 감시 중인 노드는 새로 연결해 기록을 초기화
+//
+func WatchNode(address string) error {
+
+	connection, err := dialWatchedNode(address, ConnTimeoutDuration)
+	if err != nil {
+		return fmt.Errorf(msg.WatchNodeFail, address, err.Error())
+	}
+
+	now := time.Now()
+	newNode := &watchedNode{
+		address:      address,
+		connection:   connection,
+		registeredAt: now,
+		lastSeen:     now,
+	}
+
+	watchedNodesMutex.Lock()
+	previousNode, isSet := watchedNodes[address]
+	watchedNodes[address] = newNode
+	watchedNodesMutex.Unlock()
+
+	if isSet {
+		previousNode.close()
+	}
+
+	tools.InfoLogger.Printf(msg.NodeWatched, address)
+
+	return nil
+}
+
+// UnwatchNode : @address 노드 감시 중단, 감시 중이 아니면 무시
+func UnwatchNode(address string) {
+
+	watchedNodesMutex.Lock()
+	targetNode, isSet := watchedNodes[address]
+	delete(watchedNodes, address)
+	watchedNodesMutex.Unlock()
+
+	if !isSet {
+		return
+	}
+
+	targetNode.close()
+
+	tools.InfoLogger.Printf(msg.NodeUnwatched, address)
+}
+
+// PingWatchedNode : 감시 중인 @address 노드에 PING, 응답 시간 / 마지막 응답 시각 / 연속 실패 수 갱신
+// 연결이 끊긴 노드는 다시 연결한 뒤 PING (@timeout 은 연결, 응답 대기 각각의 제한)
+//
+func PingWatchedNode(address string, timeout time.Duration) (bool, error) {
+
+	watchedNodesMutex.RLock()
+	targetNode, isSet := watchedNodes[address]
+	watchedNodesMutex.RUnlock()
+
+	if !isSet {
+		return false, fmt.Errorf(msg.NodeNotWatched, address)
+	}
+
+	return targetNode.ping(timeout), nil
+}
+
+// GetWatchedNodes : 감시 중인 모든 노드 상태 (주소 순)
+func GetWatchedNodes() []WatchedNodeStatus {
+
+	nodes := getWatchedNodeList()
+
+	statuses := make([]WatchedNodeStatus, len(nodes))
+	for i, eachNode := range nodes {
+		statuses[i] = eachNode.status()
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Address < statuses[j].Address
+	})
+
+	return statuses
+}
+
+// getWatchedNodeAddresses : 감시 중인 모든 노드 주소
+func getWatchedNodeAddresses() []string {
+
+	nodes := getWatchedNodeList()
+
+	addresses := make([]string, len(nodes))
+	for i, eachNode := range nodes {
+		addresses[i] = eachNode.address
+	}
+
+	return addresses
+}
+
+func getWatchedNodeList() []*watchedNode {
+
+	watchedNodesMutex.RLock()
+	defer watchedNodesMutex.RUnlock()
+
+	nodes := make([]*watchedNode, 0, len(watchedNodes))
+	for _, eachNode := range watchedNodes {
+		nodes = append(nodes, eachNode)
+	}
+
+	return nodes
+}
+
+func (node *watchedNode) ping(timeout time.Duration) bool {
+
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	// 이전 PING 이 실패해 끊어진 연결은 다시 연결
+	if node.connection == nil {
+		connection, err := dialWatchedNode(node.address, timeout)
+		if err != nil {
+			node.consecutiveFailures++
+			return false
+		}
+		node.connection = connection
+	}
+
+	startedAt := time.Now()
+	pong, err := redis.String(node.connection.Do("PING"))
+	if err != nil || pong != "PONG" {
+		node.connection.Close()
+		node.connection = nil
+		node.consecutiveFailures++
+		return false
+	}
+
+	now := time.Now()
+	node.latencies = append(node.latencies, now.Sub(startedAt))
+	if len(node.latencies) > watchedLatencyHistory {
+		node.latencies = node.latencies[len(node.latencies)-watchedLatencyHistory:]
+	}
+	node.lastSeen = now
+	node.consecutiveFailures = 0
+
+	return true
+}
+
+func (node *watchedNode) status() WatchedNodeStatus {
+
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	latencyHistory := make([]float64, len(node.latencies))
+	for i, eachLatency := range node.latencies {
+		latencyHistory[i] = float64(eachLatency) / float64(time.Millisecond)
+	}
+
+	return WatchedNodeStatus{
+		Address:             node.address,
+		IsConnected:         node.connection != nil,
+		LatencyHistory:      latencyHistory,
+		RegisteredAt:        node.registeredAt,
+		LastSeen:            node.lastSeen,
+		ConsecutiveFailures: node.consecutiveFailures,
+	}
+}
+
+func (node *watchedNode) close() {
+
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	if node.connection != nil {
+		node.connection.Close()
+		node.connection = nil
+	}
+}
+
+// dialWatchedNode : 감시용 연결 (요청 처리용 연결과 분리), 응답 대기도 @timeout 으로 제한
+func dialWatchedNode(address string, timeout time.Duration) (redis.Conn, error) {
+
+	return redis.Dial(
+		"tcp",
+		address,
+		redis.DialConnectTimeout(timeout),
+		redis.DialReadTimeout(timeout),
+		redis.DialWriteTimeout(timeout),
+	)
+}
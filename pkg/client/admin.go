@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Nodes : 등록된 Redis 마스터, 슬레이브 (GET /clients)
+func (client *Client) Nodes(ctx context.Context) (NodeList, error) {
+	return client.nodeListRequest(ctx, request{method: http.MethodGet, path: "/clients"})
+}
+
+// AddMaster : 새로운 Redis 마스터 등록, 해쉬 슬롯을 나눠 받는다
+func (client *Client) AddMaster(ctx context.Context, address string) (NodeList, error) {
+
+	return client.nodeListRequest(ctx, request{
+		method: http.MethodPost,
+		path:   "/clients",
+		body: newClientRequest{
+			Address: address,
+			Role:    "master",
+		},
+		isWrite: true,
+	})
+}
+
+// AddSlave : @masterAddress 마스터의 슬레이브로 새로운 Redis 노드 등록
+func (client *Client) AddSlave(ctx context.Context, address string, masterAddress string) (NodeList, error) {
+
+	return client.nodeListRequest(ctx, request{
+		method: http.MethodPost,
+		path:   "/clients",
+		body: newClientRequest{
+			Address:       address,
+			Role:          "slave",
+			MasterAddress: masterAddress,
+		},
+		isWrite: true,
+	})
+}
+
+// RemoveNode : Redis 마스터 (해쉬 슬롯은 다른 마스터들로 이동) / 슬레이브 제거
+func (client *Client) RemoveNode(ctx context.Context, address string) (NodeList, error) {
+
+	return client.nodeListRequest(ctx, request{
+		method:  http.MethodDelete,
+		path:    "/clients/" + url.PathEscape(address),
+		isWrite: true,
+	})
+}
+
+func (client *Client) nodeListRequest(ctx context.Context, req request) (NodeList, error) {
+
+	result := nodeListResponse{}
+	if err := client.do(ctx, req, &result); err != nil {
+		return NodeList{}, err
+	}
+
+	nodeList := NodeList{
+		Masters:     make([]string, len(result.Masters)),
+		Slaves:      make([]string, len(result.Slaves)),
+		Maintenance: result.Maintenance,
+	}
+
+	for i, eachMaster := range result.Masters {
+		nodeList.Masters[i] = eachMaster.Address
+	}
+
+	for i, eachSlave := range result.Slaves {
+		nodeList.Slaves[i] = eachSlave.Address
+	}
+
+	return nodeList, nil
+}
+
+// KeySlot : @key 의 해쉬 슬롯과 담당 노드
+func (client *Client) KeySlot(ctx context.Context, key string) (KeySlot, error) {
+
+	keySlot := KeySlot{}
+	err := client.do(ctx, request{
+		method: http.MethodGet,
+		path:   "/slots/key/" + url.PathEscape(key),
+	}, &keySlot)
+
+	return keySlot, err
+}
+
+// Leader : 요청받은 인터페이스 서버가 알고 있는 현재 리더 주소
+func (client *Client) Leader(ctx context.Context) (string, error) {
+
+	result := basicResponse{}
+	err := client.do(ctx, request{method: http.MethodGet, path: "/cluster/leader"}, &result)
+
+	return result.Message, err
+}
+
+// ClusterNodes : 요청받은 인터페이스 서버에 등록된 클러스터 (인터페이스 서버) 노드들
+func (client *Client) ClusterNodes(ctx context.Context) ([]string, error) {
+
+	result := clusterNodesResponse{}
+	err := client.do(ctx, request{method: http.MethodGet, path: "/cluster"}, &result)
+
+	return result.Nodes, err
+}
+
+// RegisterClusterNode : 요청받은 인터페이스 서버와 @host 서버가 서로를 클러스터 노드로 등록
+// 클러스터 시작 전 요청이므로 리더가 아닌 현재 서버로 보낸다
+//
+func (client *Client) RegisterClusterNode(ctx context.Context, host string, handshake bool, startPoint bool) error {
+
+	return client.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/cluster",
+		query: url.Values{
+			"handshake":  []string{strconv.FormatBool(handshake)},
+			"startPoint": []string{strconv.FormatBool(startPoint)},
+		},
+		body: clusterRegisterRequest{Address: host},
+	}, nil)
+}
+
+// StartCluster : 등록된 노드들로 클러스터 시작
+func (client *Client) StartCluster(ctx context.Context) error {
+	return client.do(ctx, request{method: http.MethodPut, path: "/cluster"}, nil)
+}
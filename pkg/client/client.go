@@ -0,0 +1,442 @@
+// Package client : 인터페이스 서버 REST API (/api/v1) 의 Go 클라이언트
+//  - 여러 인터페이스 서버 주소 중 살아있는 서버로 요청, 실패 시 다른 서버로 재시도 (Backoff)
+//  - 읽기 일관성 선택 (ReadYourWrites / Eventual / LeaderRead)
+//  - 리더 / 클러스터 노드 찾기 (Config.Discovery), 해쉬 슬롯 구성 캐시 (Config.SlotRouting)
+//
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// 인터페이스 서버와 같은 헤더 이름 (cluster.IndexTimeHeader, handlers.SlotOwnerHeader, handlers.SlotEpochHeader)
+	indexTimeHeader = "indexTime"
+	slotOwnerHeader = "slotOwner"
+	slotEpochHeader = "slotEpoch"
+
+	apiPath = "/api/v1"
+
+	defaultMaxRetries    = 3
+	defaultMinBackoff    = 50 * time.Millisecond
+	defaultMaxBackoff    = 1 * time.Second
+	defaultTimeout       = 10 * time.Second
+	defaultWatchInterval = 500 * time.Millisecond
+)
+
+// Config : Client 설정, 생략한 값은 기본값 사용
+//  - Endpoints : 인터페이스 서버 주소들 (host:port 또는 http://host:port), 하나 이상 필요
+//  - Discovery : 클러스터 노드 목록 (GET /cluster) 과 리더 (GET /cluster/leader) 를 받아 엔드포인트에 추가,
+//                쓰기는 리더에게 먼저 보낸다 (다른 서버는 리더에게 전달하므로 한 번 덜 거친다)
+//  - SlotRouting : 해쉬 슬롯 구성 (GET /slots) 을 캐시하고 Key 요청에 담당 마스터를 함께 보낸다, MOVED / ASK 응답 시 갱신
+//  - Consistency : 읽기 일관성 기본값 (ReadYourWrites)
+//  - MaxRetries : 재시도 횟수 (기본 3), MinBackoff / MaxBackoff : 재시도 대기 시간 범위 (기본 50ms / 1s)
+//  - Timeout : 요청 하나의 최대 시간 (기본 10s), HTTPClient 를 지정하면 HTTPClient 의 설정을 따른다
+//  - WatchInterval : Watch 의 조회 주기 (기본 500ms)
+//
+type Config struct {
+	Endpoints   []string
+	Discovery   bool
+	SlotRouting bool
+	Consistency Consistency
+
+	MaxRetries int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	Timeout    time.Duration
+	HTTPClient *http.Client
+
+	WatchInterval time.Duration
+}
+
+// Client : 여러 고루틴에서 함께 사용할 수 있다
+type Client struct {
+	config     Config
+	httpClient *http.Client
+
+	endpointMutex *sync.Mutex
+	endpoints     []string
+	current       int
+	leader        string
+	discoverOnce  *sync.Once
+
+	// indexTime : 응답받은 가장 큰 Index Time, ReadYourWrites / LeaderRead 읽기에 함께 보낸다
+	indexTime uint64
+
+	slots *slotCache
+}
+
+// New : @config 로 Client 생성
+func New(config Config) (*Client, error) {
+
+	endpoints := []string{}
+	for _, eachEndpoint := range config.Endpoints {
+		if endpoint := normalizeEndpoint(eachEndpoint); endpoint != "" {
+			endpoints = appendEndpoint(endpoints, endpoint)
+		}
+	}
+
+	if len(endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	if config.MaxRetries <= 0 {
+		config.MaxRetries = defaultMaxRetries
+	}
+	if config.MinBackoff <= 0 {
+		config.MinBackoff = defaultMinBackoff
+	}
+	if config.MaxBackoff < config.MinBackoff {
+		config.MaxBackoff = defaultMaxBackoff
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+	if config.WatchInterval <= 0 {
+		config.WatchInterval = defaultWatchInterval
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: config.Timeout}
+	}
+
+	return &Client{
+		config:        config,
+		httpClient:    httpClient,
+		endpointMutex: &sync.Mutex{},
+		endpoints:     endpoints,
+		discoverOnce:  &sync.Once{},
+		slots:         newSlotCache(),
+	}, nil
+}
+
+// Endpoints : 현재 알고 있는 인터페이스 서버 주소들
+func (client *Client) Endpoints() []string {
+
+	client.endpointMutex.Lock()
+	defer client.endpointMutex.Unlock()
+
+	return append([]string{}, client.endpoints...)
+}
+
+// IndexTime : 응답받은 가장 큰 Index Time
+func (client *Client) IndexTime() uint64 {
+	return atomic.LoadUint64(&client.indexTime)
+}
+
+// Discover : 알고 있는 서버들에게 클러스터 노드 목록과 리더를 물어 엔드포인트에 추가
+// 한 서버에게라도 응답을 받으면 성공
+//
+func (client *Client) Discover(ctx context.Context) error {
+
+	var lastErr error
+
+	for _, eachEndpoint := range client.Endpoints() {
+
+		nodes := clusterNodesResponse{}
+		if err := client.send(ctx, eachEndpoint, request{method: http.MethodGet, path: "/cluster"}, "", &nodes); err != nil {
+			lastErr = err
+			continue
+		}
+
+		leader := basicResponse{}
+		if err := client.send(ctx, eachEndpoint, request{method: http.MethodGet, path: "/cluster/leader"}, "", &leader); err != nil {
+			lastErr = err
+			continue
+		}
+
+		client.endpointMutex.Lock()
+		for _, eachNode := range nodes.Nodes {
+			if endpoint := normalizeEndpoint(eachNode); endpoint != "" {
+				client.endpoints = appendEndpoint(client.endpoints, endpoint)
+			}
+		}
+		client.leader = normalizeEndpoint(leader.Message)
+		if client.leader != "" {
+			client.endpoints = appendEndpoint(client.endpoints, client.leader)
+		}
+		client.endpointMutex.Unlock()
+
+		return nil
+	}
+
+	return lastErr
+}
+
+// request : 인터페이스 서버에 보낼 요청 하나
+//  - path : /api/v1 이후 경로
+//  - keys : 요청이 다루는 Key 들, SlotRouting 이면 담당 마스터를 함께 보낸다
+//  - isWrite : WAL 에 기록되는 요청, 전달 여부를 알 수 없는 실패는 재시도하지 않는다
+//
+type request struct {
+	method  string
+	path    string
+	query   url.Values
+	body    interface{}
+	keys    []string
+	isWrite bool
+
+	consistency Consistency
+}
+
+// do : 요청을 보내고 응답을 @result 에 디코딩, 실패 종류에 따라 다른 서버로 재시도
+//  - 연결 실패 : 다음 서버로 (쓰기는 연결조차 되지 않은 경우만)
+//  - 서버 오류 (5xx) : 읽기만 다음 서버로
+//  - TRYAGAIN (슬롯 이동 중 키가 나뉨) : 잠시 후 재시도
+//  - MOVED : 슬롯 구성을 다시 받은 뒤 재시도, ASK : 이번 요청만 이동 목표 마스터로 재시도
+//
+func (client *Client) do(ctx context.Context, req request, result interface{}) error {
+
+	if client.config.Discovery {
+		client.discoverOnce.Do(func() {
+			client.Discover(ctx)
+		})
+	}
+
+	var lastErr error
+	var askAddress string
+
+	for attempt := 0; attempt <= client.config.MaxRetries; attempt++ {
+
+		if attempt > 0 {
+			if err := client.backoff(ctx, attempt); err != nil {
+				return lastErr
+			}
+		}
+
+		endpoint := client.pickEndpoint(req)
+		err := client.send(ctx, endpoint, req, askAddress, result)
+		askAddress = ""
+
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		var redirect *RedirectError
+		var serverErr *Error
+
+		switch {
+		case errors.As(err, &redirect):
+			if redirect.Type == RedirectAsk {
+				askAddress = redirect.Address
+			} else {
+				client.slots.invalidate()
+			}
+
+		case IsTryAgain(err):
+
+		case errors.As(err, &serverErr):
+			if req.isWrite || serverErr.StatusCode < http.StatusInternalServerError {
+				return err
+			}
+			client.markFailed(endpoint)
+
+		default:
+			// 연결 실패
+			if req.isWrite && !isDialError(err) {
+				return err
+			}
+			client.markFailed(endpoint)
+		}
+	}
+
+	return lastErr
+}
+
+// send : @endpoint 서버에 요청 한 번, 2xx 가 아닌 응답은 *Error / *RedirectError
+func (client *Client) send(ctx context.Context, endpoint string, req request, askAddress string, result interface{}) error {
+
+	requestURL := "http://" + endpoint + apiPath + req.path
+	if len(req.query) > 0 {
+		requestURL += "?" + req.query.Encode()
+	}
+
+	var requestBody *bytes.Reader
+	if req.body != nil {
+		encodedBody, err := json.Marshal(req.body)
+		if err != nil {
+			return err
+		}
+		requestBody = bytes.NewReader(encodedBody)
+	} else {
+		requestBody = bytes.NewReader(nil)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, req.method, requestURL, requestBody)
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	if req.isWrite == false && len(req.keys) > 0 {
+		httpRequest.Header.Set(indexTimeHeader, client.readIndexTime(req.consistency))
+	}
+
+	if len(req.keys) > 0 {
+		if owner := client.slotOwner(ctx, req.keys, askAddress); owner != "" {
+			httpRequest.Header.Set(slotOwnerHeader, owner)
+		}
+	}
+
+	httpResponse, err := client.httpClient.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+
+	client.observeIndexTime(httpResponse.Header.Get(indexTimeHeader))
+	client.slots.observeEpoch(httpResponse.Header.Get(slotEpochHeader))
+
+	responseBody, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return err
+	}
+
+	if httpResponse.StatusCode == http.StatusMisdirectedRequest {
+		redirect := redirectResponse{}
+		if err := json.Unmarshal(responseBody, &redirect); err != nil {
+			return &Error{StatusCode: httpResponse.StatusCode, Message: string(responseBody)}
+		}
+		return &redirect.Redirect
+	}
+
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode >= 300 {
+		basic := basicResponse{}
+		if err := json.Unmarshal(responseBody, &basic); err != nil || basic.Message == "" {
+			basic.Message = strings.TrimSpace(string(responseBody))
+		}
+		return &Error{StatusCode: httpResponse.StatusCode, Message: basic.Message}
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(responseBody, result)
+}
+
+// pickEndpoint : 쓰기 / 리더 읽기는 리더를 알고 있으면 리더, 아니면 현재 서버
+func (client *Client) pickEndpoint(req request) string {
+
+	client.endpointMutex.Lock()
+	defer client.endpointMutex.Unlock()
+
+	if client.leader != "" && (req.isWrite || req.consistency == LeaderRead) {
+		return client.leader
+	}
+
+	return client.endpoints[client.current]
+}
+
+// markFailed : 실패한 서버를 리더에서 빼고, 현재 서버였으면 다음 서버로
+func (client *Client) markFailed(endpoint string) {
+
+	client.endpointMutex.Lock()
+	defer client.endpointMutex.Unlock()
+
+	if client.leader == endpoint {
+		client.leader = ""
+	}
+
+	if client.endpoints[client.current] == endpoint {
+		client.current = (client.current + 1) % len(client.endpoints)
+	}
+}
+
+// backoff : @attempt 번째 재시도 전 대기 (지수 증가, 최대 MaxBackoff, 절반 범위 Jitter)
+func (client *Client) backoff(ctx context.Context, attempt int) error {
+
+	delay := client.config.MinBackoff << uint(attempt-1)
+	if delay <= 0 || delay > client.config.MaxBackoff {
+		delay = client.config.MaxBackoff
+	}
+	delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (client *Client) readIndexTime(consistency Consistency) string {
+
+	if consistency == Eventual {
+		return "0"
+	}
+
+	return strconv.FormatUint(client.IndexTime(), 10)
+}
+
+func (client *Client) observeIndexTime(indexTimeString string) {
+
+	indexTime, err := strconv.ParseUint(indexTimeString, 10, 64)
+	if err != nil {
+		return
+	}
+
+	for {
+		current := atomic.LoadUint64(&client.indexTime)
+		if indexTime <= current || atomic.CompareAndSwapUint64(&client.indexTime, current, indexTime) {
+			return
+		}
+	}
+}
+
+// normalizeEndpoint : "http://host:port/" -> "host:port"
+func normalizeEndpoint(endpoint string) string {
+
+	endpoint = strings.TrimSpace(endpoint)
+	endpoint = strings.TrimPrefix(endpoint, "http://")
+	endpoint = strings.TrimRight(endpoint, "/")
+
+	return endpoint
+}
+
+func appendEndpoint(endpoints []string, endpoint string) []string {
+
+	for _, eachEndpoint := range endpoints {
+		if eachEndpoint == endpoint {
+			return endpoints
+		}
+	}
+
+	return append(endpoints, endpoint)
+}
+
+// isDialError : 서버에 연결조차 되지 않아 요청이 전달되지 않은 실패인지
+func isDialError(err error) bool {
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// requestError : 요청 전 확인한 잘못된 인자
+func requestError(format string, args ...interface{}) error {
+	return fmt.Errorf("client : "+format, args...)
+}
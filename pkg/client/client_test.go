@@ -0,0 +1,331 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"hash_interface/internal/hash"
+	msg "hash_interface/internal/storage/message"
+)
+
+const (
+	testMasterA = "127.0.0.1:8000"
+	testMasterB = "127.0.0.1:8001"
+)
+
+// scriptedResponse : 테스트 서버가 Key 요청에 차례로 돌려줄 응답
+type scriptedResponse struct {
+	statusCode int
+	body       interface{}
+	epoch      string
+}
+
+// scriptedServer : 해쉬 슬롯 구성 (GET /slots) 과 Key 요청 (/hash/data, /hash/mget) 만 응답하는 인터페이스 서버
+// Key 요청에 함께 받은 담당 마스터 (slotOwner 헤더) 와 슬롯 구성 요청 수를 기록한다
+type scriptedServer struct {
+	*httptest.Server
+
+	mutex       *sync.Mutex
+	slotMaps    []SlotMap
+	responses   []scriptedResponse
+	owners      []string
+	slotFetches int
+}
+
+func newScriptedServer(slotMaps []SlotMap, responses []scriptedResponse) *scriptedServer {
+
+	server := &scriptedServer{
+		mutex:     &sync.Mutex{},
+		slotMaps:  slotMaps,
+		responses: responses,
+		owners:    []string{},
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(apiPath+"/slots", func(res http.ResponseWriter, req *http.Request) {
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+
+		slotMap := server.slotMaps[len(server.slotMaps)-1]
+		if server.slotFetches < len(server.slotMaps) {
+			slotMap = server.slotMaps[server.slotFetches]
+		}
+		server.slotFetches++
+
+		json.NewEncoder(res).Encode(slotMap)
+	})
+
+	keyHandler := func(res http.ResponseWriter, req *http.Request) {
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+
+		response := scriptedResponse{statusCode: http.StatusOK, body: getResponse{Result: "bar", Exists: true}}
+		if len(server.owners) < len(server.responses) {
+			response = server.responses[len(server.owners)]
+		}
+		server.owners = append(server.owners, req.Header.Get(slotOwnerHeader))
+
+		if response.epoch != "" {
+			res.Header().Set(slotEpochHeader, response.epoch)
+		}
+		res.WriteHeader(response.statusCode)
+		json.NewEncoder(res).Encode(response.body)
+	}
+
+	mux.HandleFunc(apiPath+"/hash/data", keyHandler)
+	mux.HandleFunc(apiPath+"/hash/data/", keyHandler)
+	mux.HandleFunc(apiPath+"/hash/mget", keyHandler)
+
+	server.Server = httptest.NewServer(mux)
+
+	return server
+}
+
+func newTestSlotMap(epoch uint64, masterAddress string) SlotMap {
+	return SlotMap{
+		Epoch:  epoch,
+		Ranges: []SlotRange{{Start: 0, End: hash.HashSlotsNumber - 1, MasterAddress: masterAddress}},
+	}
+}
+
+func newTestClient(t *testing.T, slotRouting bool, endpoints ...string) *Client {
+
+	kvClient, err := New(Config{
+		Endpoints:   endpoints,
+		SlotRouting: slotRouting,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  2 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("New() 에러 : %s", err.Error())
+	}
+
+	return kvClient
+}
+
+func TestSlotCacheOwner(t *testing.T) {
+
+	cache := newSlotCache()
+	cache.update(SlotMap{
+		Epoch: 1,
+		Ranges: []SlotRange{
+			{Start: 100, End: 199, MasterAddress: testMasterB},
+			{Start: 0, End: 49, MasterAddress: testMasterA},
+		},
+	})
+
+	fixtures := []struct {
+		slot            uint16
+		expectedOwner   string
+		expectedIsFound bool
+	}{
+		{0, testMasterA, true},
+		{49, testMasterA, true},
+		{50, "", false},
+		{100, testMasterB, true},
+		{199, testMasterB, true},
+		{200, "", false},
+	}
+
+	for _, fixture := range fixtures {
+
+		owner, isFound := cache.owner(fixture.slot)
+		if owner != fixture.expectedOwner || isFound != fixture.expectedIsFound {
+			t.Errorf(
+				"owner(%d) = (%q, %v), expected : (%q, %v)",
+				fixture.slot,
+				owner,
+				isFound,
+				fixture.expectedOwner,
+				fixture.expectedIsFound,
+			)
+		}
+	}
+
+	// 같은 구성 버전은 그대로, 다른 버전이면 다시 받을 때까지 캐시를 쓰지 않는다
+	cache.observeEpoch("1")
+	if _, isFound := cache.owner(0); !isFound {
+		t.Errorf("같은 구성 버전(1) 응답 후 캐시를 쓰지 않습니다")
+	}
+
+	cache.observeEpoch("2")
+	if _, isFound := cache.owner(0); isFound {
+		t.Errorf("다른 구성 버전(2) 응답 후에도 캐시를 씁니다")
+	}
+}
+
+func TestSlotRedirect(t *testing.T) {
+
+	fixtures := []struct {
+		name                string
+		slotMaps            []SlotMap
+		responses           []scriptedResponse
+		gets                int
+		expectedOwners      []string
+		expectedSlotFetches int
+	}{
+		{
+			name:     "MOVED : 슬롯 구성을 다시 받은 뒤 재시도",
+			slotMaps: []SlotMap{newTestSlotMap(1, testMasterA), newTestSlotMap(2, testMasterB)},
+			responses: []scriptedResponse{
+				{
+					statusCode: http.StatusMisdirectedRequest,
+					body:       redirectResponse{Redirect: RedirectError{Type: RedirectMoved, Address: testMasterB, Epoch: 2}},
+				},
+			},
+			gets:                1,
+			expectedOwners:      []string{testMasterA, testMasterB},
+			expectedSlotFetches: 2,
+		},
+		{
+			name:     "ASK : 이번 요청만 이동 목표 마스터로",
+			slotMaps: []SlotMap{newTestSlotMap(1, testMasterA)},
+			responses: []scriptedResponse{
+				{
+					statusCode: http.StatusMisdirectedRequest,
+					body:       redirectResponse{Redirect: RedirectError{Type: RedirectAsk, Address: testMasterB, Epoch: 1}},
+				},
+			},
+			gets:                2,
+			expectedOwners:      []string{testMasterA, testMasterB, testMasterA},
+			expectedSlotFetches: 1,
+		},
+		{
+			name:     "TRYAGAIN : 같은 담당 마스터로 재시도",
+			slotMaps: []SlotMap{newTestSlotMap(1, testMasterA)},
+			responses: []scriptedResponse{
+				{
+					statusCode: http.StatusBadRequest,
+					body:       basicResponse{Message: msg.SlotMigrationTryAgainMarker + " 재시도"},
+				},
+			},
+			gets:                1,
+			expectedOwners:      []string{testMasterA, testMasterA},
+			expectedSlotFetches: 1,
+		},
+		{
+			name:     "응답의 구성 버전이 다르면 다음 요청 전에 다시 받기",
+			slotMaps: []SlotMap{newTestSlotMap(1, testMasterA), newTestSlotMap(2, testMasterB)},
+			responses: []scriptedResponse{
+				{statusCode: http.StatusOK, body: getResponse{Result: "bar", Exists: true}, epoch: "2"},
+			},
+			gets:                2,
+			expectedOwners:      []string{testMasterA, testMasterB},
+			expectedSlotFetches: 2,
+		},
+	}
+
+	for _, fixture := range fixtures {
+
+		server := newScriptedServer(fixture.slotMaps, fixture.responses)
+		kvClient := newTestClient(t, true, server.URL)
+
+		for i := 0; i < fixture.gets; i++ {
+			if _, err := kvClient.Get(context.Background(), "foo", WithConsistency(Eventual)); err != nil {
+				t.Errorf("%s : Get() 에러 %s", fixture.name, err.Error())
+			}
+		}
+
+		server.Close()
+
+		if len(server.owners) != len(fixture.expectedOwners) {
+			t.Errorf("%s : 담당 마스터 %v, expected : %v", fixture.name, server.owners, fixture.expectedOwners)
+		} else {
+			for i, eachOwner := range fixture.expectedOwners {
+				if server.owners[i] != eachOwner {
+					t.Errorf("%s : 담당 마스터 %v, expected : %v", fixture.name, server.owners, fixture.expectedOwners)
+					break
+				}
+			}
+		}
+
+		if server.slotFetches != fixture.expectedSlotFetches {
+			t.Errorf("%s : 슬롯 구성 요청 %d번, expected : %d", fixture.name, server.slotFetches, fixture.expectedSlotFetches)
+		}
+	}
+}
+
+// 서버 오류 (5xx) 는 읽기만 다음 서버로 재시도, 쓰기는 전달되었을 수 있으므로 그대로 실패
+func TestServerErrorRetry(t *testing.T) {
+
+	failing := newScriptedServer(nil, []scriptedResponse{
+		{statusCode: http.StatusInternalServerError, body: basicResponse{Message: "error"}},
+	})
+	defer failing.Close()
+
+	healthy := newScriptedServer(nil, nil)
+	defer healthy.Close()
+
+	kvClient := newTestClient(t, false, failing.URL, healthy.URL)
+
+	value, err := kvClient.Get(context.Background(), "foo")
+	if err != nil {
+		t.Fatalf("Get() 에러 : %s", err.Error())
+	}
+	if value.Value != "bar" {
+		t.Errorf("Get() = %q, expected : %q", value.Value, "bar")
+	}
+
+	failing.mutex.Lock()
+	failing.owners = []string{}
+	failing.mutex.Unlock()
+
+	kvClient = newTestClient(t, false, failing.URL, healthy.URL)
+
+	err = kvClient.Set(context.Background(), "foo", "baz")
+
+	var serverErr *Error
+	if !errors.As(err, &serverErr) || serverErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Set() 에러 %v, expected : %d 응답", err, http.StatusInternalServerError)
+	}
+
+	if len(healthy.owners) != 1 {
+		t.Errorf("Set() 을 다른 서버로 재시도했습니다 (요청 %d번)", len(healthy.owners))
+	}
+}
+
+// 없는 Key 는 응답의 값이 아닌 exists 로 판단한다
+func TestMissingKey(t *testing.T) {
+
+	server := newScriptedServer(nil, []scriptedResponse{
+		{statusCode: http.StatusOK, body: getResponse{Result: "nil(없음)", Exists: false}},
+		{statusCode: http.StatusOK, body: getResponse{Result: "nil(없음)", Exists: true}},
+		{statusCode: http.StatusOK, body: multiGetResponse{Results: []multiGetResult{
+			{Key: "{user}.missing", Value: "nil(없음)", Exists: false},
+			{Key: "{user}.name", Value: "nil(없음)", Exists: true},
+		}}},
+	})
+	defer server.Close()
+
+	kvClient := newTestClient(t, false, server.URL)
+
+	if _, err := kvClient.Get(context.Background(), "foo"); !IsNotFound(err) {
+		t.Errorf("없는 Key Get() 에러 %v, expected : %v", err, ErrNotFound)
+	}
+
+	value, err := kvClient.Get(context.Background(), "foo")
+	if err != nil {
+		t.Fatalf("Get() 에러 : %s", err.Error())
+	}
+	if value.Value != "nil(없음)" {
+		t.Errorf("Get() = %q, expected : %q", value.Value, "nil(없음)")
+	}
+
+	values, err := kvClient.MGet(context.Background(), []string{"{user}.missing", "{user}.name"})
+	if err != nil {
+		t.Fatalf("MGet() 에러 : %s", err.Error())
+	}
+
+	expected := map[string]string{"{user}.name": "nil(없음)"}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("MGet() = %v, expected : %v", values, expected)
+	}
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	msg "hash_interface/internal/storage/message"
+)
+
+var (
+	// ErrNoEndpoints : Config.Endpoints 에 인터페이스 서버 주소가 없음
+	ErrNoEndpoints = errors.New("client : 인터페이스 서버 주소가 필요합니다")
+
+	// ErrNotFound : Key 가 없음
+	ErrNotFound = errors.New("client : Key 가 없습니다")
+)
+
+const (
+	RedirectMoved = msg.SlotMovedMarker
+	RedirectAsk   = msg.SlotAskMarker
+)
+
+// Error : 인터페이스 서버의 오류 응답 (상태 코드, 응답 메세지)
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (err *Error) Error() string {
+	return fmt.Sprintf("%d %s - %s", err.StatusCode, http.StatusText(err.StatusCode), err.Message)
+}
+
+// RedirectError : 421 응답, 요청한 Key 의 담당 마스터가 보낸 것(slotOwner)과 다름
+//  - MOVED : 담당 마스터가 바뀌었다 (슬롯 구성 다시 받기)
+//  - ASK : 슬롯 이동 중, 이번 요청만 Address 로
+//
+type RedirectError struct {
+	Type    string `json:"type"`
+	Slot    uint16 `json:"slot"`
+	Address string `json:"address"`
+	Epoch   uint64 `json:"epoch"`
+}
+
+func (err *RedirectError) Error() string {
+	return fmt.Sprintf(msg.SlotRedirected, err.Type, err.Slot, err.Address, err.Epoch)
+}
+
+// IsNotFound : Key 가 없어 실패했는지 (ErrNotFound, 404)
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound) || hasStatus(err, http.StatusNotFound, msg.NoSuchKeyMarker)
+}
+
+// IsConditionFailed : 조건부 쓰기 (CAS / IfAbsent / IfPresent) 의 조건 불일치인지
+func IsConditionFailed(err error) bool {
+	return hasStatus(err, http.StatusConflict, msg.ConditionFailedMarker)
+}
+
+// IsKeyLocked : 분산 트랜잭션이 잠근 Key 라서 실패했는지
+func IsKeyLocked(err error) bool {
+	return hasStatus(err, http.StatusConflict, msg.TransactionKeyLockedMarker)
+}
+
+// IsTryAgain : 슬롯 이동 중 요청한 키들이 나뉘어 있거나, 마스터 Failover 중이라 실패했는지 (잠시 후 재시도)
+func IsTryAgain(err error) bool {
+	return hasStatus(err, http.StatusBadRequest, msg.SlotMigrationTryAgainMarker)
+}
+
+// IsCrossSlot : 같은 해쉬 슬롯이어야 하는 요청 (MGet / MSet) 의 키들이 다른 슬롯인지
+func IsCrossSlot(err error) bool {
+	return hasStatus(err, http.StatusBadRequest, msg.CrossSlotMarker)
+}
+
+// IsReplicaQuorumNotReached : 커밋은 되었지만 요청한 수준 (Durability) 의 레플리카 확인을 받지 못했는지
+func IsReplicaQuorumNotReached(err error) bool {
+	return hasStatus(err, http.StatusGatewayTimeout, msg.ReplicaQuorumNotReachedMarker)
+}
+
+func hasStatus(err error, statusCode int, marker string) bool {
+
+	var serverErr *Error
+	if !errors.As(err, &serverErr) || serverErr.StatusCode != statusCode {
+		return false
+	}
+
+	return strings.HasPrefix(serverErr.Message, marker)
+}
@@ -0,0 +1,311 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"hash_interface/internal/hash"
+)
+
+// 인터페이스 서버의 명령, 조건부 쓰기 이름 (storage.DelCommand, storage.ConditionCompareAndSwap 등)
+const (
+	delCommand = "DEL"
+
+	conditionIfAbsent       = "nx"
+	conditionIfPresent      = "xx"
+	conditionCompareAndSwap = "cas"
+)
+
+type readOptions struct {
+	consistency Consistency
+}
+
+// ReadOption : 읽기 요청 하나의 설정
+type ReadOption func(*readOptions)
+
+// WithConsistency : 이 요청의 읽기 일관성 (생략 시 Config.Consistency)
+func WithConsistency(consistency Consistency) ReadOption {
+	return func(options *readOptions) {
+		options.consistency = consistency
+	}
+}
+
+type writeOptions struct {
+	durability Durability
+	ttl        time.Duration
+	condition  string
+}
+
+// WriteOption : 쓰기 요청 하나의 설정
+type WriteOption func(*writeOptions)
+
+// WithDurability : 응답 전 복제 확인 수준
+func WithDurability(durability Durability) WriteOption {
+	return func(options *writeOptions) {
+		options.durability = durability
+	}
+}
+
+// WithTTL : 만료 시간 (millisecond 단위로 전달)
+func WithTTL(ttl time.Duration) WriteOption {
+	return func(options *writeOptions) {
+		options.ttl = ttl
+	}
+}
+
+// IfAbsent : Key 가 없을 때만 쓴다 (SET NX), 조건이 맞지 않으면 IsConditionFailed 에러
+func IfAbsent() WriteOption {
+	return func(options *writeOptions) {
+		options.condition = conditionIfAbsent
+	}
+}
+
+// IfPresent : Key 가 있을 때만 쓴다 (SET XX), 조건이 맞지 않으면 IsConditionFailed 에러
+func IfPresent() WriteOption {
+	return func(options *writeOptions) {
+		options.condition = conditionIfPresent
+	}
+}
+
+func (client *Client) readOptions(opts []ReadOption) readOptions {
+
+	options := readOptions{consistency: client.config.Consistency}
+	for _, eachOption := range opts {
+		eachOption(&options)
+	}
+
+	return options
+}
+
+func newWriteOptions(opts []WriteOption) writeOptions {
+
+	options := writeOptions{}
+	for _, eachOption := range opts {
+		eachOption(&options)
+	}
+
+	return options
+}
+
+// Get : @key 의 값과 버전, Key 가 없으면 ErrNotFound
+func (client *Client) Get(ctx context.Context, key string, opts ...ReadOption) (Value, error) {
+
+	value, isFound, err := client.get(ctx, key, opts)
+	if err != nil {
+		return Value{}, err
+	}
+
+	if isFound == false {
+		return value, ErrNotFound
+	}
+
+	return value, nil
+}
+
+// get : Key 가 없어도 버전은 채운다 (삭제도 버전을 올린다)
+func (client *Client) get(ctx context.Context, key string, opts []ReadOption) (Value, bool, error) {
+
+	if key == "" {
+		return Value{}, false, requestError("Key 가 비어 있습니다")
+	}
+
+	options := client.readOptions(opts)
+	result := getResponse{}
+
+	err := client.do(ctx, request{
+		method:      http.MethodGet,
+		path:        "/hash/data/" + url.PathEscape(key),
+		keys:        []string{key},
+		consistency: options.consistency,
+	}, &result)
+	if err != nil {
+		return Value{}, false, err
+	}
+
+	value := Value{
+		Key:     key,
+		Value:   result.Result,
+		Version: result.Version,
+		Node:    result.Node,
+	}
+
+	if result.Exists == false {
+		value.Value = ""
+		return value, false, nil
+	}
+
+	return value, true, nil
+}
+
+// Set : @key 에 @value 저장
+func (client *Client) Set(ctx context.Context, key string, value string, opts ...WriteOption) error {
+
+	options := newWriteOptions(opts)
+
+	return client.set(ctx, keyValuePair{
+		Key:       key,
+		Value:     value,
+		Condition: options.condition,
+		TTL:       int64(options.ttl / time.Millisecond),
+	}, options)
+}
+
+// CAS : @key 의 버전이 @expectedVersion (Get 의 Version) 일 때만 @value 저장
+// 그 사이 다른 쓰기가 있었으면 IsConditionFailed 에러
+//
+func (client *Client) CAS(ctx context.Context, key string, expectedVersion uint64, value string, opts ...WriteOption) error {
+
+	options := newWriteOptions(opts)
+
+	return client.set(ctx, keyValuePair{
+		Key:             key,
+		Value:           value,
+		Condition:       conditionCompareAndSwap,
+		ExpectedVersion: &expectedVersion,
+		TTL:             int64(options.ttl / time.Millisecond),
+	}, options)
+}
+
+func (client *Client) set(ctx context.Context, keyValue keyValuePair, options writeOptions) error {
+
+	if keyValue.Key == "" {
+		return requestError("Key 가 비어 있습니다")
+	}
+
+	return client.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/hash/data",
+		body: dataRequest{
+			Data:       []keyValuePair{keyValue},
+			Durability: options.durability,
+		},
+		keys:    []string{keyValue.Key},
+		isWrite: true,
+	}, nil)
+}
+
+// MSet : 같은 해쉬 슬롯의 (Key, Value) 들을 원자적으로 저장 (Hash Tag 사용 ex. {user}:1, {user}:2)
+// 다른 슬롯의 Key 가 섞여 있으면 IsCrossSlot 에러
+//
+func (client *Client) MSet(ctx context.Context, keyValues map[string]string, opts ...WriteOption) error {
+
+	if len(keyValues) == 0 {
+		return requestError("Key 가 비어 있습니다")
+	}
+
+	options := newWriteOptions(opts)
+
+	data := make([]keyValuePair, 0, len(keyValues))
+	keys := make([]string, 0, len(keyValues))
+	for eachKey, eachValue := range keyValues {
+		data = append(data, keyValuePair{Key: eachKey, Value: eachValue})
+		keys = append(keys, eachKey)
+	}
+
+	return client.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/hash/mset",
+		body: dataRequest{
+			Data:       data,
+			Durability: options.durability,
+		},
+		keys:    keys,
+		isWrite: true,
+	}, nil)
+}
+
+// MGet : 같은 해쉬 슬롯의 Key 들의 값, 없는 Key 는 결과에서 빠진다
+// 다른 슬롯의 Key 가 섞여 있으면 IsCrossSlot 에러
+//
+func (client *Client) MGet(ctx context.Context, keys []string, opts ...ReadOption) (map[string]string, error) {
+
+	if len(keys) == 0 {
+		return nil, requestError("Key 가 비어 있습니다")
+	}
+
+	options := client.readOptions(opts)
+	result := multiGetResponse{}
+
+	err := client.do(ctx, request{
+		method:      http.MethodGet,
+		path:        "/hash/mget",
+		query:       url.Values{"key": keys},
+		keys:        keys,
+		consistency: options.consistency,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(result.Results))
+	for _, eachResult := range result.Results {
+		if eachResult.Exists {
+			values[eachResult.Key] = eachResult.Value
+		}
+	}
+
+	return values, nil
+}
+
+// Delete : @keys 삭제, 모두 같은 해쉬 슬롯이면 하나의 트랜잭션 (MULTI/EXEC),
+// 아니면 분산 트랜잭션 (Two-Phase Commit) 으로 원자적으로 삭제
+//
+func (client *Client) Delete(ctx context.Context, keys []string, opts ...WriteOption) error {
+
+	if len(keys) == 0 {
+		return requestError("Key 가 비어 있습니다")
+	}
+
+	options := newWriteOptions(opts)
+
+	commands := make([]keyValuePair, len(keys))
+	isSameSlot := true
+	for i, eachKey := range keys {
+		commands[i] = keyValuePair{Command: delCommand, Key: eachKey}
+		isSameSlot = isSameSlot && hash.GetHashSlotIndex(eachKey) == hash.GetHashSlotIndex(keys[0])
+	}
+
+	req := request{
+		method: http.MethodPost,
+		path:   "/hash/transaction",
+		body: transactionRequest{
+			Commands:   commands,
+			Durability: options.durability,
+		},
+		keys:    keys,
+		isWrite: true,
+	}
+
+	if isSameSlot == false {
+		req.path = "/hash/transaction/distributed"
+		req.keys = nil
+	}
+
+	return client.do(ctx, req, nil)
+}
+
+// Incr : @key 의 정수 값을 @delta 만큼 증가 (음수면 감소, 없는 Key 는 0 에서 시작), 증가 후 값 반환
+func (client *Client) Incr(ctx context.Context, key string, delta int64, opts ...WriteOption) (int64, error) {
+
+	if key == "" {
+		return 0, requestError("Key 가 비어 있습니다")
+	}
+
+	options := newWriteOptions(opts)
+	result := counterResponse{}
+
+	err := client.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/hash/counter/" + url.PathEscape(key),
+		body: counterRequest{
+			Delta:      &delta,
+			Durability: options.durability,
+		},
+		keys:    []string{key},
+		isWrite: true,
+	}, &result)
+
+	return result.Value, err
+}
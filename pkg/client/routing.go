@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	"hash_interface/internal/hash"
+)
+
+// slotCache : 캐시한 해쉬 슬롯 구성 (Config.SlotRouting)
+// MOVED 응답을 받거나, 응답의 구성 버전(slotEpoch)이 캐시한 버전과 다르면 다음 요청 전에 다시 받는다
+//
+type slotCache struct {
+	mutex   *sync.RWMutex
+	epoch   uint64
+	ranges  []SlotRange
+	isStale bool
+}
+
+func newSlotCache() *slotCache {
+	return &slotCache{
+		mutex:   &sync.RWMutex{},
+		isStale: true,
+	}
+}
+
+func (cache *slotCache) invalidate() {
+
+	cache.mutex.Lock()
+	cache.isStale = true
+	cache.mutex.Unlock()
+}
+
+func (cache *slotCache) observeEpoch(epochString string) {
+
+	epoch, err := strconv.ParseUint(epochString, 10, 64)
+	if err != nil {
+		return
+	}
+
+	cache.mutex.Lock()
+	if epoch != cache.epoch {
+		cache.isStale = true
+	}
+	cache.mutex.Unlock()
+}
+
+func (cache *slotCache) update(slotMap SlotMap) {
+
+	ranges := append([]SlotRange{}, slotMap.Ranges...)
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start < ranges[j].Start
+	})
+
+	cache.mutex.Lock()
+	cache.epoch = slotMap.Epoch
+	cache.ranges = ranges
+	cache.isStale = false
+	cache.mutex.Unlock()
+}
+
+// owner : @hashSlotIndex 의 담당 마스터, 캐시가 오래되었거나 담당이 없으면 false
+func (cache *slotCache) owner(hashSlotIndex uint16) (string, bool) {
+
+	cache.mutex.RLock()
+	defer cache.mutex.RUnlock()
+
+	if cache.isStale {
+		return "", false
+	}
+
+	rangeIdx := sort.Search(len(cache.ranges), func(i int) bool {
+		return cache.ranges[i].End >= hashSlotIndex
+	})
+
+	if rangeIdx == len(cache.ranges) || cache.ranges[rangeIdx].Start > hashSlotIndex {
+		return "", false
+	}
+
+	return cache.ranges[rangeIdx].MasterAddress, true
+}
+
+// slotOwner : Key 요청에 함께 보낼 담당 마스터 (SlotRouting 이 아니면 "")
+// ASK 로 재시도하는 경우 @askAddress, 캐시가 오래되었으면 슬롯 구성을 다시 받는다
+//
+func (client *Client) slotOwner(ctx context.Context, keys []string, askAddress string) string {
+
+	if client.config.SlotRouting == false {
+		return ""
+	}
+
+	if askAddress != "" {
+		return askAddress
+	}
+
+	hashSlotIndex := hash.GetHashSlotIndex(keys[0])
+
+	if owner, isCached := client.slots.owner(hashSlotIndex); isCached {
+		return owner
+	}
+
+	if _, err := client.SlotMap(ctx); err != nil {
+		return ""
+	}
+
+	owner, _ := client.slots.owner(hashSlotIndex)
+	return owner
+}
+
+// SlotMap : 현재 해쉬 슬롯 구성 (SlotRouting 이면 캐시도 갱신)
+func (client *Client) SlotMap(ctx context.Context) (SlotMap, error) {
+
+	slotMap := SlotMap{}
+	err := client.do(ctx, request{method: http.MethodGet, path: "/slots"}, &slotMap)
+	if err != nil {
+		return SlotMap{}, err
+	}
+
+	client.slots.update(slotMap)
+
+	return slotMap, nil
+}
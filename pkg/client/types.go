@@ -0,0 +1,155 @@
+package client
+
+// Consistency : 읽기 일관성
+type Consistency int
+
+const (
+	// ReadYourWrites : 이 Client 가 응답받은 마지막 쓰기까지 반영한 서버에서 읽는다 (기본값)
+	// 요청받은 서버가 뒤쳐져 있으면 따라잡을 때까지 기다린 뒤 응답한다
+	ReadYourWrites Consistency = iota
+
+	// Eventual : 요청받은 서버가 바로 응답, 가장 빠르지만 최근 쓰기가 아직 반영되지 않았을 수 있다
+	Eventual
+
+	// LeaderRead : 리더 서버에서 읽는다 (Config.Discovery 로 리더를 알고 있는 경우, 아니면 ReadYourWrites 와 같다)
+	LeaderRead
+)
+
+// Durability : 쓰기 응답 전 복제 확인 수준
+type Durability string
+
+const (
+	DurabilityNone        Durability = "none"
+	DurabilityReplicaAck  Durability = "replica-ack"
+	DurabilityAllReplicas Durability = "all-replicas"
+)
+
+// Value : Key 의 값과 버전 (CAS 의 expectedVersion 으로 사용), 처리한 Redis 노드
+type Value struct {
+	Key     string
+	Value   string
+	Version uint64
+	Node    string
+}
+
+// NodeList : 등록된 Redis 마스터, 슬레이브 주소와 점검 중인 노드
+type NodeList struct {
+	Masters     []string
+	Slaves      []string
+	Maintenance []string
+}
+
+// SlotRange : 연속된 해쉬 슬롯 [Start, End] 와 담당 마스터, 레플리카
+type SlotRange struct {
+	Start            uint16   `json:"start"`
+	End              uint16   `json:"end"`
+	MasterAddress    string   `json:"master"`
+	ReplicaAddresses []string `json:"replicas"`
+}
+
+// NodeSlots : 노드 별 역할과 담당 해쉬 슬롯 범위
+type NodeSlots struct {
+	Address       string   `json:"address"`
+	Role          string   `json:"role"`
+	MasterAddress string   `json:"master,omitempty"`
+	SlotCount     int      `json:"slot_count"`
+	Slots         []string `json:"slots"`
+}
+
+// SlotMap : 해쉬 슬롯 구성과 구성 버전 (GET /slots)
+type SlotMap struct {
+	Epoch  uint64      `json:"epoch"`
+	Ranges []SlotRange `json:"ranges"`
+	Nodes  []NodeSlots `json:"nodes"`
+}
+
+// KeySlot : Key 의 해쉬 슬롯과 담당 노드, 슬롯 이동 중이면 이동 목표 마스터 (ImportingAddress)
+type KeySlot struct {
+	Key              string   `json:"key"`
+	Slot             uint16   `json:"slot"`
+	MasterAddress    string   `json:"master"`
+	ReplicaAddresses []string `json:"replicas"`
+	ImportingAddress string   `json:"importing,omitempty"`
+	Epoch            uint64   `json:"epoch"`
+}
+
+/* 인터페이스 서버의 요청 / 응답 형식 (internal/models) */
+
+type keyValuePair struct {
+	Key             string  `json:"key"`
+	Value           string  `json:"value"`
+	Command         string  `json:"command,omitempty"`
+	Condition       string  `json:"condition,omitempty"`
+	ExpectedVersion *uint64 `json:"expected_version,omitempty"`
+	TTL             int64   `json:"ttl,omitempty"`
+}
+
+type dataRequest struct {
+	Data       []keyValuePair `json:"data"`
+	Durability Durability     `json:"durability,omitempty"`
+}
+
+type transactionRequest struct {
+	Commands   []keyValuePair `json:"commands"`
+	Durability Durability     `json:"durability,omitempty"`
+}
+
+type counterRequest struct {
+	Delta      *int64     `json:"delta,omitempty"`
+	Durability Durability `json:"durability,omitempty"`
+}
+
+type newClientRequest struct {
+	Address       string `json:"address"`
+	Role          string `json:"role"`
+	MasterAddress string `json:"master_address"`
+}
+
+type clusterRegisterRequest struct {
+	Address string
+}
+
+type basicResponse struct {
+	Message string `json:"message"`
+}
+
+type getResponse struct {
+	Result  string `json:"result"`
+	Node    string `json:"handled_node"`
+	Version uint64 `json:"version"`
+	Exists  bool   `json:"exists"`
+}
+
+type multiGetResult struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Exists bool   `json:"exists"`
+}
+
+type multiGetResponse struct {
+	Results []multiGetResult `json:"results"`
+	Node    string           `json:"handled_node"`
+}
+
+type counterResponse struct {
+	Value int64 `json:"value"`
+}
+
+type redisNode struct {
+	Address string `json:"address"`
+}
+
+type nodeListResponse struct {
+	Masters     []redisNode `json:"masters"`
+	Slaves      []redisNode `json:"slaves"`
+	Maintenance []string    `json:"maintenance"`
+}
+
+type clusterNodesResponse struct {
+	Nodes   []string
+	Message string `json:"message"`
+}
+
+type redirectResponse struct {
+	Redirect RedirectError `json:"redirect"`
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// WatchEvent : Watch 한 Key 의 변경
+//  - Deleted : Key 가 삭제되었거나 만료됨
+//  - Err : 조회 실패 (Watch 는 계속된다)
+//
+type WatchEvent struct {
+	Key     string
+	Value   string
+	Version uint64
+	Deleted bool
+	Err     error
+}
+
+// Watch : @key 의 값이 바뀔 때마다 이벤트 전달, @ctx 가 끝나면 채널을 닫는다
+// 인터페이스 서버에 변경 알림 API 가 없으므로 Config.WatchInterval 주기로 조회하여 버전 / 값을 비교한다
+// (주기 사이에 여러 번 바뀌면 마지막 값만 전달)
+//
+func (client *Client) Watch(ctx context.Context, key string, opts ...ReadOption) <-chan WatchEvent {
+
+	events := make(chan WatchEvent)
+
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(client.config.WatchInterval)
+		defer ticker.Stop()
+
+		var lastValue Value
+		var wasFound, hasLast bool
+
+		for {
+			value, isFound, err := client.get(ctx, key, opts)
+
+			event := WatchEvent{Key: key}
+			isChanged := false
+
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				event.Err = err
+				isChanged = true
+
+			} else if hasLast && (value.Version != lastValue.Version ||
+				value.Value != lastValue.Value || isFound != wasFound) {
+
+				event.Value = value.Value
+				event.Version = value.Version
+				event.Deleted = isFound == false
+				isChanged = true
+			}
+
+			if err == nil {
+				lastValue, wasFound, hasLast = value, isFound, true
+			}
+
+			if isChanged {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events
+}